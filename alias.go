@@ -0,0 +1,99 @@
+package lucene_parser
+
+import (
+	op "github.com/zhuliquan/lucene_parser/operator"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// AliasFunc: resolve unescaped name of field to names of real fields, empty result means field isn't alias
+type AliasFunc func(field string) []string
+
+// AliasMap: make AliasFunc from map, key is alias and value is names of real fields
+func AliasMap(m map[string][]string) AliasFunc {
+	return func(field string) []string {
+		return m[field]
+	}
+}
+
+// ResolveAlias: rewrite fields of query according to alias, origin query isn't modified.
+// alias which is mapped to one field is renamed (i.e. `user:foo` => `user.name.keyword:foo`),
+// alias which is mapped to several fields is expanded to OR across these fields (i.e. `ip:1^2` => `( source.ip:1^2 OR dest.ip:1^2 )`),
+// term (including boost / fuzzy modifiers and term group) is copied to every field.
+func ResolveAlias(q *Lucene, alias AliasFunc) *Lucene {
+	var res = q.Clone()
+	if alias != nil {
+		resolveLucene(res, func(f *term.Field) []string {
+			return alias(term.Unescape(f.String()))
+		})
+	}
+	return res
+}
+
+// ExpandFields: rewrite field query of field pattern (i.e. `http.*:error`, `(title OR body):foo`) to OR across
+// concrete fields of schema which match pattern (i.e. `( http.request:error OR http.response:error )`),
+// it's like dis_max query of ES (query_string) but scores aren't combined here. origin query isn't modified.
+// pattern which doesn't match any field of schema is kept.
+func ExpandFields(q *Lucene, schema []string) *Lucene {
+	var res = q.Clone()
+	resolveLucene(res, func(f *term.Field) []string {
+		if f.IsPattern() {
+			return f.Expand(schema)
+		}
+		return nil
+	})
+	return res
+}
+
+// fieldResolver: resolve field to unescaped names of concrete fields, empty result means field is kept
+type fieldResolver func(f *term.Field) []string
+
+func resolveLucene(q *Lucene, resolve fieldResolver) {
+	if q == nil {
+		return
+	}
+	resolveOrQuery(q.OrQuery, resolve)
+	for _, x := range q.OSQuery {
+		if x != nil {
+			resolveOrQuery(x.OrQuery, resolve)
+		}
+	}
+}
+
+func resolveOrQuery(q *OrQuery, resolve fieldResolver) {
+	if q == nil {
+		return
+	}
+	resolveAndQuery(q.AndQuery, resolve)
+	for _, x := range q.AnSQuery {
+		if x != nil {
+			resolveAndQuery(x.AndQuery, resolve)
+		}
+	}
+}
+
+func resolveAndQuery(q *AndQuery, resolve fieldResolver) {
+	if q == nil {
+		return
+	} else if q.ParenQuery != nil {
+		resolveLucene(q.ParenQuery.SubQuery, resolve)
+	} else if q.FieldQuery != nil && q.FieldQuery.Field != nil {
+		var names = resolve(queryField(q.FieldQuery))
+		if len(names) == 1 {
+			q.FieldQuery = withField(q.FieldQuery, names[0])
+		} else if len(names) > 1 {
+			var sub = &Lucene{OrQuery: &OrQuery{}}
+			for _, name := range names {
+				var t = &AndQuery{FieldQuery: withField(q.FieldQuery, name)}
+				if sub.OrQuery.AndQuery == nil {
+					sub.OrQuery.AndQuery = t
+				} else if q.FieldQuery.Field.IsMissing() {
+					// `_missing_:ip` is missing from all fields of alias
+					sub.OrQuery.AnSQuery = append(sub.OrQuery.AnSQuery, &AnSQuery{AndSymbol: &op.AndSymbol{Symbol: "AND"}, AndQuery: t})
+				} else {
+					sub.OSQuery = append(sub.OSQuery, &OSQuery{OrSymbol: &op.OrSymbol{Symbol: "OR"}, OrQuery: &OrQuery{AndQuery: t}})
+				}
+			}
+			q.FieldQuery, q.ParenQuery = nil, &ParenQuery{SubQuery: sub}
+		}
+	}
+}
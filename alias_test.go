@@ -0,0 +1,110 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAlias(t *testing.T) {
+	var alias = AliasMap(map[string][]string{
+		"user":    {"user.name.keyword"},
+		"ip":      {"source.ip", "destination.ip"},
+		"a:b":     {"c d"},
+		"x-y":     {"x-y.keyword"},
+		"unknown": {},
+	})
+	type testCase struct {
+		name  string
+		input string
+		want  string
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_rename",
+			input: `user:foo AND x:1 OR unknown:2`,
+			want:  `user.name.keyword:foo AND x:1 OR unknown:2`,
+		},
+		{
+			name:  "test_rename_escape",
+			input: `a\:b:foo~2 AND x-y:1`,
+			want:  `c\ d:foo~2 AND x-y.keyword:1`,
+		},
+		{
+			name:  "test_expand",
+			input: `ip:1^2 AND NOT ip:"1.1.1.1"~2`,
+			want:  `( source.ip:1^2 OR destination.ip:1^2 ) AND NOT ( source.ip:"1.1.1.1"~2 OR destination.ip:"1.1.1.1"~2 )`,
+		},
+		{
+			name:  "test_expand_in_paren",
+			input: `x:1 AND (user:foo OR ip:[1 TO 2}^3)`,
+			want:  `x:1 AND ( user.name.keyword:foo OR ( source.ip:[ 1 TO 2 }^3 OR destination.ip:[ 1 TO 2 }^3 ) )`,
+		},
+		{
+			name:  "test_expand_term_group",
+			input: `ip:(1 OR "2")^2`,
+			want:  `( source.ip:( 1 OR "2" )^2 OR destination.ip:( 1 OR "2" )^2 )`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var origin = q.String()
+			var res = ResolveAlias(q, alias)
+			assert.Equal(t, tt.want, res.String())
+			assert.Equal(t, origin, q.String())
+			r, err := ParseLucene(res.String())
+			assert.Nil(t, err)
+			assert.True(t, Equal(res, r))
+		})
+	}
+
+	t.Run("test_callback", func(t *testing.T) {
+		q, _ := ParseLucene(`x:1 AND y:2`)
+		var res = ResolveAlias(q, func(field string) []string {
+			return []string{"prefix." + field}
+		})
+		assert.Equal(t, `prefix.x:1 AND prefix.y:2`, res.String())
+		assert.Nil(t, ResolveAlias(nil, nil))
+	})
+}
+
+func TestExpandFields(t *testing.T) {
+	var schema = []string{"title", "body", "http.request", "http.response", "internal.id"}
+	type testCase struct {
+		name  string
+		input string
+		want  string
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_concrete",
+			input: `title:foo AND unknown:1`,
+			want:  `title:foo AND unknown:1`,
+		},
+		{
+			name:  "test_wildcard",
+			input: `http.*:error~1 AND NOT title\*:foo^2`,
+			want:  `( http.request:error~1 OR http.response:error~1 ) AND NOT title:foo^2`,
+		},
+		{
+			name:  "test_group",
+			input: `( title OR body ):(foo OR bar)`,
+			want:  `( title:( foo OR bar ) OR body:( foo OR bar ) )`,
+		},
+		{
+			name:  "test_no_match",
+			input: `x:1 OR foo.*:bar`,
+			want:  `x:1 OR foo.*:bar`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var origin = q.String()
+			var res = ExpandFields(q, schema)
+			assert.Equal(t, tt.want, res.String())
+			assert.Equal(t, origin, q.String())
+		})
+	}
+}
@@ -0,0 +1,709 @@
+package lucene_parser
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	op "github.com/zhuliquan/lucene_parser/operator"
+	"github.com/zhuliquan/lucene_parser/term"
+	tk "github.com/zhuliquan/lucene_parser/token"
+)
+
+// binary encoding of lucene query is consist of header, string table and tree.
+// header is magic "LQ" and one byte of BinaryVersion, string table is uvarint count of strings and each string is
+// written as uvarint length and bytes. nodes of tree are written in pre order, pointer of node is written as one byte
+// (0 is nil, 1 is not nil) and followed by fields, string is written as uvarint index of string table,
+// slice is written as uvarint length + 1 (0 is nil) and followed by elements.
+// version 2 adds group of field after value of field, binary of version 1 can still be decoded.
+const (
+	binaryMagic    = "LQ"
+	BinaryVersion  = 2
+	maxBinaryDepth = 512
+)
+
+var (
+	ErrBinaryMagic   = fmt.Errorf("invalid magic of binary lucene")
+	ErrBinaryVersion = fmt.Errorf("unsupported version of binary lucene")
+	ErrBinaryCorrupt = fmt.Errorf("corrupt binary lucene")
+	ErrBinaryTooDeep = fmt.Errorf("binary lucene is nested too deep")
+)
+
+// MarshalBinary: encode lucene query to compact binary, repeated strings (i.e. field names) are written once in string table
+func (q *Lucene) MarshalBinary() ([]byte, error) {
+	var e = &binaryEncoder{index: map[string]uint64{}}
+	e.lucene(q)
+	var res = make([]byte, 0, len(binaryMagic)+1+len(e.tree)+len(e.strings)*4)
+	res = append(res, binaryMagic...)
+	res = append(res, BinaryVersion)
+	res = appendUvarint(res, uint64(len(e.strings)))
+	for _, s := range e.strings {
+		res = appendUvarint(res, uint64(len(s)))
+		res = append(res, s...)
+	}
+	return append(res, e.tree...), nil
+}
+
+// UnmarshalBinary: decode binary made by MarshalBinary, corrupt input results in error instead of panic
+func (q *Lucene) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrBinaryMagic
+	} else if data[len(binaryMagic)] == 0 || data[len(binaryMagic)] > BinaryVersion {
+		return ErrBinaryVersion
+	}
+	var d = &binaryDecoder{data: data[len(binaryMagic)+1:], version: data[len(binaryMagic)]}
+	d.stringTable()
+	var res = d.lucene()
+	if d.err == nil && len(d.data) != 0 {
+		d.err = ErrBinaryCorrupt
+	}
+	if d.err != nil {
+		return d.err
+	} else if res == nil {
+		*q = Lucene{}
+	} else {
+		*q = *res
+	}
+	return nil
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+type binaryEncoder struct {
+	tree    []byte
+	strings []string
+	index   map[string]uint64
+}
+
+func (e *binaryEncoder) uvarint(v uint64) {
+	e.tree = appendUvarint(e.tree, v)
+}
+
+// node: write whether node is nil, and return true if fields of node should be written
+func (e *binaryEncoder) node(present bool) bool {
+	if present {
+		e.tree = append(e.tree, 1)
+	} else {
+		e.tree = append(e.tree, 0)
+	}
+	return present
+}
+
+func (e *binaryEncoder) length(n int, isNil bool) {
+	if isNil {
+		e.uvarint(0)
+	} else {
+		e.uvarint(uint64(n) + 1)
+	}
+}
+
+func (e *binaryEncoder) str(s string) {
+	if i, ok := e.index[s]; ok {
+		e.uvarint(i)
+	} else {
+		e.index[s] = uint64(len(e.strings))
+		e.uvarint(uint64(len(e.strings)))
+		e.strings = append(e.strings, s)
+	}
+}
+
+func (e *binaryEncoder) strs(sl []string) {
+	e.length(len(sl), sl == nil)
+	for _, s := range sl {
+		e.str(s)
+	}
+}
+
+// chars: chars of term are written as strings, types of chars are recovered by lexer when decoding
+func (e *binaryEncoder) chars(sl []tk.Char) {
+	e.length(len(sl), sl == nil)
+	for _, c := range sl {
+		e.str(c.Value)
+	}
+}
+
+func (e *binaryEncoder) lucene(q *Lucene) {
+	if e.node(q != nil) {
+		e.orQuery(q.OrQuery)
+		e.length(len(q.OSQuery), q.OSQuery == nil)
+		for _, x := range q.OSQuery {
+			e.osQuery(x)
+		}
+	}
+}
+
+func (e *binaryEncoder) orQuery(q *OrQuery) {
+	if e.node(q != nil) {
+		e.andQuery(q.AndQuery)
+		e.length(len(q.AnSQuery), q.AnSQuery == nil)
+		for _, x := range q.AnSQuery {
+			e.ansQuery(x)
+		}
+	}
+}
+
+func (e *binaryEncoder) osQuery(q *OSQuery) {
+	if e.node(q != nil) {
+		e.orSymbol(q.OrSymbol)
+		e.orQuery(q.OrQuery)
+	}
+}
+
+func (e *binaryEncoder) andQuery(q *AndQuery) {
+	if e.node(q != nil) {
+		e.notSymbol(q.NotSymbol)
+		e.parenQuery(q.ParenQuery)
+		e.fieldQuery(q.FieldQuery)
+	}
+}
+
+func (e *binaryEncoder) ansQuery(q *AnSQuery) {
+	if e.node(q != nil) {
+		e.andSymbol(q.AndSymbol)
+		e.notSymbol(q.NotSymbol)
+		e.andQuery(q.AndQuery)
+	}
+}
+
+func (e *binaryEncoder) parenQuery(q *ParenQuery) {
+	if e.node(q != nil) {
+		e.lucene(q.SubQuery)
+	}
+}
+
+func (e *binaryEncoder) fieldQuery(q *FieldQuery) {
+	if e.node(q != nil) {
+		e.field(q.Field)
+		e.term(q.Term)
+	}
+}
+
+func (e *binaryEncoder) field(f *term.Field) {
+	if e.node(f != nil) {
+		e.strs(f.Value)
+		e.length(len(f.Group), f.Group == nil)
+		for _, x := range f.Group {
+			e.field(x)
+		}
+	}
+}
+
+func (e *binaryEncoder) andSymbol(o *op.AndSymbol) {
+	if e.node(o != nil) {
+		e.str(o.Symbol)
+	}
+}
+
+func (e *binaryEncoder) orSymbol(o *op.OrSymbol) {
+	if e.node(o != nil) {
+		e.str(o.Symbol)
+	}
+}
+
+func (e *binaryEncoder) notSymbol(o *op.NotSymbol) {
+	if e.node(o != nil) {
+		e.str(o.Symbol)
+	}
+}
+
+func (e *binaryEncoder) term(t *term.Term) {
+	if e.node(t != nil) {
+		if e.node(t.RegexpTerm != nil) {
+			e.strs(t.RegexpTerm.Chars)
+		}
+		if e.node(t.FuzzyTerm != nil) {
+			e.singleTerm(t.FuzzyTerm.SingleTerm)
+			e.phraseTerm(t.FuzzyTerm.PhraseTerm)
+			e.str(t.FuzzyTerm.FuzzySymbol)
+			e.str(t.FuzzyTerm.BoostSymbol)
+		}
+		if e.node(t.RangeTerm != nil) {
+			e.sRangeTerm(t.RangeTerm.SRangeTerm)
+			e.dRangeTerm(t.RangeTerm.DRangeTerm)
+			e.str(t.RangeTerm.BoostSymbol)
+		}
+		if e.node(t.TermGroup != nil) {
+			e.logicTermGroup(t.TermGroup.LogicTermGroup)
+			e.str(t.TermGroup.BoostSymbol)
+		}
+	}
+}
+
+func (e *binaryEncoder) singleTerm(t *term.SingleTerm) {
+	if e.node(t != nil) {
+		e.str(t.Begin.Value)
+		e.chars(t.Chars)
+	}
+}
+
+func (e *binaryEncoder) phraseTerm(t *term.PhraseTerm) {
+	if e.node(t != nil) {
+		e.strs(t.Chars)
+	}
+}
+
+func (e *binaryEncoder) sRangeTerm(t *term.SRangeTerm) {
+	if e.node(t != nil) {
+		e.str(t.Symbol)
+		e.rangeValue(t.Value)
+	}
+}
+
+func (e *binaryEncoder) dRangeTerm(t *term.DRangeTerm) {
+	if e.node(t != nil) {
+		e.str(t.LBRACKET)
+		e.rangeValue(t.LValue)
+		e.rangeValue(t.RValue)
+		e.str(t.RBRACKET)
+	}
+}
+
+func (e *binaryEncoder) rangeValue(v *term.RangeValue) {
+	if e.node(v != nil) {
+		e.node(v.SideFlag)
+		e.str(v.InfinityVal)
+		e.strs(v.PhraseValue)
+		e.strs(v.SingleValue)
+	}
+}
+
+func (e *binaryEncoder) fieldTermGroup(t *term.FieldTermGroup) {
+	if e.node(t != nil) {
+		e.singleTerm(t.SingleTerm)
+		e.phraseTerm(t.PhraseTerm)
+		e.sRangeTerm(t.SRangeTerm)
+		e.dRangeTerm(t.DRangeTerm)
+	}
+}
+
+func (e *binaryEncoder) logicTermGroup(t *term.LogicTermGroup) {
+	if e.node(t != nil) {
+		e.orTermGroup(t.OrTermGroup)
+		e.length(len(t.OSTermGroup), t.OSTermGroup == nil)
+		for _, x := range t.OSTermGroup {
+			if e.node(x != nil) {
+				e.orSymbol(x.OrSymbol)
+				e.orTermGroup(x.OrTermGroup)
+			}
+		}
+	}
+}
+
+func (e *binaryEncoder) orTermGroup(t *term.OrTermGroup) {
+	if e.node(t != nil) {
+		e.andTermGroup(t.AndTermGroup)
+		e.length(len(t.AnSTermGroup), t.AnSTermGroup == nil)
+		for _, x := range t.AnSTermGroup {
+			if e.node(x != nil) {
+				e.andSymbol(x.AndSymbol)
+				e.notSymbol(x.NotSymbol)
+				e.andTermGroup(x.AndTermGroup)
+			}
+		}
+	}
+}
+
+func (e *binaryEncoder) andTermGroup(t *term.AndTermGroup) {
+	if e.node(t != nil) {
+		e.notSymbol(t.NotSymbol)
+		if e.node(t.ParenTermGroup != nil) {
+			e.logicTermGroup(t.ParenTermGroup.SubTermGroup)
+		}
+		e.fieldTermGroup(t.FieldTermGroup)
+	}
+}
+
+// binaryDecoder: error is sticky, after the first error all reading return zero value
+type binaryDecoder struct {
+	data    []byte
+	strings []string
+	version byte
+	depth   int
+	err     error
+}
+
+func (d *binaryDecoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v, n = binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail(ErrBinaryCorrupt)
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// node: read whether node is nil, and return true if fields of node should be read
+func (d *binaryDecoder) node() bool {
+	if d.err != nil {
+		return false
+	} else if len(d.data) == 0 {
+		d.fail(ErrBinaryCorrupt)
+		return false
+	}
+	var b = d.data[0]
+	d.data = d.data[1:]
+	if b > 1 {
+		d.fail(ErrBinaryCorrupt)
+	}
+	return b == 1 && d.err == nil
+}
+
+// length: return -1 if slice is nil, every element takes one byte at least,
+// so length which is greater than rest of data must be corrupt and it won't cause huge allocation.
+func (d *binaryDecoder) length() int {
+	var v = d.uvarint()
+	if d.err != nil || v == 0 {
+		return -1
+	} else if v-1 > uint64(len(d.data)) {
+		d.fail(ErrBinaryCorrupt)
+		return -1
+	}
+	return int(v - 1)
+}
+
+// enter: limit depth of nested node, so that corrupt input won't exhaust stack
+func (d *binaryDecoder) enter() bool {
+	if d.depth++; d.depth > maxBinaryDepth {
+		d.fail(ErrBinaryTooDeep)
+	}
+	return d.err == nil
+}
+
+func (d *binaryDecoder) leave() {
+	d.depth--
+}
+
+func (d *binaryDecoder) stringTable() {
+	var n = d.uvarint()
+	if d.err == nil && n > uint64(len(d.data)) {
+		d.fail(ErrBinaryCorrupt)
+	}
+	if d.err != nil {
+		return
+	}
+	d.strings = make([]string, 0, n)
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		var l = d.uvarint()
+		if d.err == nil && l > uint64(len(d.data)) {
+			d.fail(ErrBinaryCorrupt)
+		} else if d.err == nil {
+			d.strings = append(d.strings, string(d.data[:l]))
+			d.data = d.data[l:]
+		}
+	}
+}
+
+func (d *binaryDecoder) str() string {
+	var i = d.uvarint()
+	if d.err != nil {
+		return ""
+	} else if i >= uint64(len(d.strings)) {
+		d.fail(ErrBinaryCorrupt)
+		return ""
+	}
+	return d.strings[i]
+}
+
+func (d *binaryDecoder) strs() []string {
+	var n = d.length()
+	if n < 0 {
+		return nil
+	}
+	var res = make([]string, 0, n)
+	for i := 0; i < n && d.err == nil; i++ {
+		res = append(res, d.str())
+	}
+	return res
+}
+
+func (d *binaryDecoder) char() tk.Char {
+	var s = d.str()
+	return tk.Char{Type: tk.GetTokenType(s), Value: s}
+}
+
+func (d *binaryDecoder) chars() []tk.Char {
+	var n = d.length()
+	if n < 0 {
+		return nil
+	}
+	var res = make([]tk.Char, 0, n)
+	for i := 0; i < n && d.err == nil; i++ {
+		res = append(res, d.char())
+	}
+	return res
+}
+
+func (d *binaryDecoder) lucene() *Lucene {
+	if !d.node() || !d.enter() {
+		return nil
+	}
+	defer d.leave()
+	var q = &Lucene{OrQuery: d.orQuery()}
+	if n := d.length(); n >= 0 {
+		q.OSQuery = make([]*OSQuery, 0, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			q.OSQuery = append(q.OSQuery, d.osQuery())
+		}
+	}
+	return q
+}
+
+func (d *binaryDecoder) orQuery() *OrQuery {
+	if !d.node() {
+		return nil
+	}
+	var q = &OrQuery{AndQuery: d.andQuery()}
+	if n := d.length(); n >= 0 {
+		q.AnSQuery = make([]*AnSQuery, 0, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			q.AnSQuery = append(q.AnSQuery, d.ansQuery())
+		}
+	}
+	return q
+}
+
+func (d *binaryDecoder) osQuery() *OSQuery {
+	if !d.node() {
+		return nil
+	}
+	var q = &OSQuery{}
+	q.OrSymbol = d.orSymbol()
+	q.OrQuery = d.orQuery()
+	return q
+}
+
+func (d *binaryDecoder) andQuery() *AndQuery {
+	if !d.node() {
+		return nil
+	}
+	var q = &AndQuery{}
+	q.NotSymbol = d.notSymbol()
+	q.ParenQuery = d.parenQuery()
+	q.FieldQuery = d.fieldQuery()
+	return q
+}
+
+func (d *binaryDecoder) ansQuery() *AnSQuery {
+	if !d.node() {
+		return nil
+	}
+	var q = &AnSQuery{}
+	q.AndSymbol = d.andSymbol()
+	q.NotSymbol = d.notSymbol()
+	q.AndQuery = d.andQuery()
+	return q
+}
+
+func (d *binaryDecoder) parenQuery() *ParenQuery {
+	if !d.node() {
+		return nil
+	}
+	return &ParenQuery{SubQuery: d.lucene()}
+}
+
+func (d *binaryDecoder) fieldQuery() *FieldQuery {
+	if !d.node() {
+		return nil
+	}
+	var q = &FieldQuery{}
+	q.Field = d.field()
+	q.Term = d.term()
+	return q
+}
+
+func (d *binaryDecoder) field() *term.Field {
+	if !d.node() || !d.enter() {
+		return nil
+	}
+	defer d.leave()
+	var f = &term.Field{Value: d.strs()}
+	if d.version < 2 {
+		return f
+	}
+	if n := d.length(); n >= 0 {
+		f.Group = make([]*term.Field, 0, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			f.Group = append(f.Group, d.field())
+		}
+	}
+	return f
+}
+
+func (d *binaryDecoder) andSymbol() *op.AndSymbol {
+	if !d.node() {
+		return nil
+	}
+	return &op.AndSymbol{Symbol: d.str()}
+}
+
+func (d *binaryDecoder) orSymbol() *op.OrSymbol {
+	if !d.node() {
+		return nil
+	}
+	return &op.OrSymbol{Symbol: d.str()}
+}
+
+func (d *binaryDecoder) notSymbol() *op.NotSymbol {
+	if !d.node() {
+		return nil
+	}
+	return &op.NotSymbol{Symbol: d.str()}
+}
+
+func (d *binaryDecoder) term() *term.Term {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.Term{}
+	if d.node() {
+		t.RegexpTerm = &term.RegexpTerm{Chars: d.strs()}
+	}
+	if d.node() {
+		t.FuzzyTerm = &term.FuzzyTerm{}
+		t.FuzzyTerm.SingleTerm = d.singleTerm()
+		t.FuzzyTerm.PhraseTerm = d.phraseTerm()
+		t.FuzzyTerm.FuzzySymbol = d.str()
+		t.FuzzyTerm.BoostSymbol = d.str()
+	}
+	if d.node() {
+		t.RangeTerm = &term.RangeTerm{}
+		t.RangeTerm.SRangeTerm = d.sRangeTerm()
+		t.RangeTerm.DRangeTerm = d.dRangeTerm()
+		t.RangeTerm.BoostSymbol = d.str()
+	}
+	if d.node() {
+		t.TermGroup = &term.TermGroup{}
+		t.TermGroup.LogicTermGroup = d.logicTermGroup()
+		t.TermGroup.BoostSymbol = d.str()
+	}
+	return t
+}
+
+func (d *binaryDecoder) singleTerm() *term.SingleTerm {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.SingleTerm{}
+	t.Begin = d.char()
+	t.Chars = d.chars()
+	return t
+}
+
+func (d *binaryDecoder) phraseTerm() *term.PhraseTerm {
+	if !d.node() {
+		return nil
+	}
+	return &term.PhraseTerm{Chars: d.strs()}
+}
+
+func (d *binaryDecoder) sRangeTerm() *term.SRangeTerm {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.SRangeTerm{}
+	t.Symbol = d.str()
+	t.Value = d.rangeValue()
+	return t
+}
+
+func (d *binaryDecoder) dRangeTerm() *term.DRangeTerm {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.DRangeTerm{}
+	t.LBRACKET = d.str()
+	t.LValue = d.rangeValue()
+	t.RValue = d.rangeValue()
+	t.RBRACKET = d.str()
+	return t
+}
+
+func (d *binaryDecoder) rangeValue() *term.RangeValue {
+	if !d.node() {
+		return nil
+	}
+	var v = &term.RangeValue{}
+	v.SideFlag = d.node()
+	v.InfinityVal = d.str()
+	v.PhraseValue = d.strs()
+	v.SingleValue = d.strs()
+	return v
+}
+
+func (d *binaryDecoder) fieldTermGroup() *term.FieldTermGroup {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.FieldTermGroup{}
+	t.SingleTerm = d.singleTerm()
+	t.PhraseTerm = d.phraseTerm()
+	t.SRangeTerm = d.sRangeTerm()
+	t.DRangeTerm = d.dRangeTerm()
+	return t
+}
+
+func (d *binaryDecoder) logicTermGroup() *term.LogicTermGroup {
+	if !d.node() || !d.enter() {
+		return nil
+	}
+	defer d.leave()
+	var t = &term.LogicTermGroup{OrTermGroup: d.orTermGroup()}
+	if n := d.length(); n >= 0 {
+		t.OSTermGroup = make([]*term.OSTermGroup, 0, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			var x *term.OSTermGroup
+			if d.node() {
+				x = &term.OSTermGroup{}
+				x.OrSymbol = d.orSymbol()
+				x.OrTermGroup = d.orTermGroup()
+			}
+			t.OSTermGroup = append(t.OSTermGroup, x)
+		}
+	}
+	return t
+}
+
+func (d *binaryDecoder) orTermGroup() *term.OrTermGroup {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.OrTermGroup{AndTermGroup: d.andTermGroup()}
+	if n := d.length(); n >= 0 {
+		t.AnSTermGroup = make([]*term.AnSTermGroup, 0, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			var x *term.AnSTermGroup
+			if d.node() {
+				x = &term.AnSTermGroup{}
+				x.AndSymbol = d.andSymbol()
+				x.NotSymbol = d.notSymbol()
+				x.AndTermGroup = d.andTermGroup()
+			}
+			t.AnSTermGroup = append(t.AnSTermGroup, x)
+		}
+	}
+	return t
+}
+
+func (d *binaryDecoder) andTermGroup() *term.AndTermGroup {
+	if !d.node() {
+		return nil
+	}
+	var t = &term.AndTermGroup{}
+	t.NotSymbol = d.notSymbol()
+	if d.node() {
+		t.ParenTermGroup = &term.ParenTermGroup{SubTermGroup: d.logicTermGroup()}
+	}
+	t.FieldTermGroup = d.fieldTermGroup()
+	return t
+}
@@ -0,0 +1,36 @@
+//go:build go1.18
+// +build go1.18
+
+package lucene_parser
+
+import "testing"
+
+// FuzzUnmarshalBinary: decoded query is unchanged by encoding it again. fuzz test needs go1.18 (go.mod declares
+// go1.14), so that this file is built by newer toolchains only.
+func FuzzUnmarshalBinary(f *testing.F) {
+	for _, input := range binaryTestQueries {
+		q, err := ParseLucene(input)
+		if err != nil {
+			f.Fatal(err)
+		}
+		b, _ := q.MarshalBinary()
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var q = &Lucene{}
+		if err := q.UnmarshalBinary(data); err != nil {
+			return
+		}
+		b, err := q.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out = &Lucene{}
+		if err := out.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(out) {
+			t.Fatalf("decoded query is changed after re-encoding: %s != %s", q, out)
+		}
+	})
+}
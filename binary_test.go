@@ -0,0 +1,125 @@
+package lucene_parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var binaryTestQueries = []string{
+	`x:1`,
+	`x:1 AND NOT y:2 OR z:[1 TO 2]^2`,
+	`x:1 NOT y:>=2`,
+	`(x:foo~2 || y:"foo bar"^3) && !z:/\d+/`,
+	`x:(foo* OR (bar AND !"baz") OR >1 OR [1 TO 2}) AND y:{* TO 10]`,
+	`x\:y:1\+1 OR z:<=2^0.5`,
+	`http.*:error AND (title OR body\*):(foo OR bar)^2`,
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, input := range binaryTestQueries {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			b, err := q.MarshalBinary()
+			assert.Nil(t, err)
+			var out = &Lucene{}
+			assert.Nil(t, out.UnmarshalBinary(b))
+			assert.Equal(t, q, out)
+			assert.Equal(t, q.String(), out.String())
+		})
+	}
+}
+
+func TestBinaryVersion1(t *testing.T) {
+	// binary of `x:1` encoded by version 1, there isn't group of field
+	var b = []byte("LQ\x01\x03\x01x\x011\x00\x01\x01\x01\x00\x00\x01\x01\x02\x00\x01\x00\x01\x01\x01\x00\x00\x02\x02\x00\x00\x00\x00")
+	var out = &Lucene{}
+	assert.Nil(t, out.UnmarshalBinary(b))
+	q, err := ParseLucene(`x:1`)
+	assert.Nil(t, err)
+	assert.Equal(t, q, out)
+}
+
+func TestBinarySize(t *testing.T) {
+	var sl = []string{}
+	for i := 0; i < 50; i++ {
+		sl = append(sl, `user.name.keyword:foo`)
+	}
+	q, err := ParseLucene(strings.Join(sl, " OR "))
+	assert.Nil(t, err)
+	b, err := q.MarshalBinary()
+	assert.Nil(t, err)
+	j, err := json.Marshal(q)
+	assert.Nil(t, err)
+	// field name is written once in string table
+	assert.Equal(t, 1, strings.Count(string(b), "user"))
+	assert.Less(t, len(b)*10, len(j))
+}
+
+func TestBinaryError(t *testing.T) {
+	q, err := ParseLucene(`x:1 AND y:(foo OR bar)`)
+	assert.Nil(t, err)
+	b, err := q.MarshalBinary()
+	assert.Nil(t, err)
+
+	type testCase struct {
+		name  string
+		input []byte
+		err   error
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_empty",
+			input: nil,
+			err:   ErrBinaryMagic,
+		},
+		{
+			name:  "test_wrong_magic",
+			input: []byte("XX\x01"),
+			err:   ErrBinaryMagic,
+		},
+		{
+			name:  "test_wrong_version",
+			input: []byte("LQ\x03"),
+			err:   ErrBinaryVersion,
+		},
+		{
+			name:  "test_zero_version",
+			input: []byte("LQ\x00"),
+			err:   ErrBinaryVersion,
+		},
+		{
+			name:  "test_truncated",
+			input: b[:len(b)-1],
+			err:   ErrBinaryCorrupt,
+		},
+		{
+			name:  "test_trailing",
+			input: append(append([]byte{}, b...), 0),
+			err:   ErrBinaryCorrupt,
+		},
+		{
+			name:  "test_huge_string_table",
+			input: []byte("LQ\x01\xff\xff\xff\xff\x0f"),
+			err:   ErrBinaryCorrupt,
+		},
+		{
+			name:  "test_string_index_out_of_range",
+			input: []byte("LQ\x01\x01\x01\x01\x01\x01\x01\x01\x01\x01\x05"),
+			err:   ErrBinaryCorrupt,
+		},
+		{
+			name:  "test_too_deep",
+			input: append([]byte("LQ\x01\x00"), []byte(strings.Repeat("\x01\x01\x01\x00\x01", maxBinaryDepth+1))...),
+			err:   ErrBinaryTooDeep,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &Lucene{}
+			assert.Equal(t, tt.err, out.UnmarshalBinary(tt.input))
+		})
+	}
+}
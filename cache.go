@@ -0,0 +1,222 @@
+package lucene_parser
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DEFAULT_CACHE_SIZE: max number of queries kept by Cache without WithCacheSize
+const DEFAULT_CACHE_SIZE = 1024
+
+// Cache: LRU cache of parsed queries which is keyed by query string, Cache is safe for concurrent use
+// by multiple goroutines. ast returned by cache is shared by callers and it mustn't be modified,
+// use WithClone if callers modify ast (i.e. rewrite or fill default field).
+type Cache struct {
+	parser      *Parser
+	size        int
+	ttl         time.Duration
+	clone       bool
+	fingerprint bool
+	now         func() time.Time // clock of ttl, it's replaced in tests
+
+	mu           sync.Mutex
+	lru          *list.List               // front is the most recently used entry
+	items        map[string]*list.Element // query string -> entry
+	fingerprints map[string]*list.Element // fingerprint -> the latest entry with this fingerprint
+	stats        CacheStats
+}
+
+// CacheStats: counters of Cache
+type CacheStats struct {
+	Hits            uint64 // queries are found in cache
+	Misses          uint64 // queries are parsed by parser
+	FingerprintHits uint64 // parsed queries share ast of cached query with same fingerprint
+	Evictions       uint64 // entries are removed since cache is full or entries are expired
+	Entries         int    // number of entries in cache
+}
+
+// cacheEntry: element of lru list
+type cacheEntry struct {
+	key         string
+	query       *Lucene
+	fingerprint string
+	expires     time.Time // zero means entry never expires
+}
+
+// CacheOption: option of Cache
+type CacheOption func(*Cache)
+
+// WithCacheSize: max number of queries kept by cache, the least recently used query is evicted when cache is full.
+// size which isn't positive is regarded as DEFAULT_CACHE_SIZE.
+func WithCacheSize(size int) CacheOption {
+	return func(c *Cache) {
+		if size > 0 {
+			c.size = size
+		}
+	}
+}
+
+// WithTTL: time to live of cached query since it's parsed, default is 0 and queries never expire.
+func WithTTL(ttl time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// WithClone: return deep copy of cached ast, so that callers can modify result. default is false and cached ast
+// is shared, which is much cheaper than clone.
+func WithClone(clone bool) CacheOption {
+	return func(c *Cache) {
+		c.clone = clone
+	}
+}
+
+// WithFingerprint: share ast between queries with same Fingerprint (i.e. `x:1 AND y:2` and `y:2 && x:1`).
+// query which isn't in cache is still parsed to get its fingerprint, so that it saves memory of cache
+// rather than time of parsing, and result of query may be ast of equivalent query which is spelled differently.
+// literals are compared as they are written (see Fingerprint), so that `zip:1234` never shares ast of `zip:01234`.
+func WithFingerprint(fingerprint bool) CacheOption {
+	return func(c *Cache) {
+		c.fingerprint = fingerprint
+	}
+}
+
+// NewCache: build cache of parser with options, nil parser is the parser of ParseLucene.
+func NewCache(parser *Parser, opts ...CacheOption) *Cache {
+	if parser == nil {
+		parser = defaultParser
+	}
+	var c = &Cache{
+		parser:       parser,
+		size:         DEFAULT_CACHE_SIZE,
+		now:          time.Now,
+		lru:          list.New(),
+		items:        map[string]*list.Element{},
+		fingerprints: map[string]*list.Element{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Parse: return cached ast of query, or parse query by parser and cache it. errors aren't cached.
+func (c *Cache) Parse(queryString string) (*Lucene, error) {
+	return c.ParseContext(context.Background(), queryString)
+}
+
+// ParseContext: the same as Parse, ctx is passed to Parser.ParseContext if query isn't in cache.
+func (c *Cache) ParseContext(ctx context.Context, queryString string) (*Lucene, error) {
+	if q := c.get(queryString); q != nil {
+		return c.result(q), nil
+	}
+	// query is parsed without lock, so that slow query doesn't block others
+	var q, err = c.parser.ParseContext(ctx, queryString)
+	if err != nil {
+		return nil, err
+	}
+	var fingerprint string
+	if c.fingerprint {
+		fingerprint = Fingerprint(q)
+	}
+	return c.result(c.add(queryString, q, fingerprint)), nil
+}
+
+// Stats: snapshot of counters of cache
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var stats = c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Len: number of entries in cache, expired entries are counted until they are looked up or evicted
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge: remove all entries, counters are kept
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.items = map[string]*list.Element{}
+	c.fingerprints = map[string]*list.Element{}
+}
+
+func (c *Cache) get(key string) *Lucene {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		if !c.expired(el) {
+			c.lru.MoveToFront(el)
+			c.stats.Hits++
+			return el.Value.(*cacheEntry).query
+		}
+		c.remove(el)
+		c.stats.Evictions++
+	}
+	c.stats.Misses++
+	return nil
+}
+
+// add: put parsed query to cache and return ast which should be returned to caller. if other goroutine has cached
+// the same query or query with the same fingerprint, cached ast is returned, so that equal queries share one ast.
+func (c *Cache) add(key string, q *Lucene, fingerprint string) *Lucene {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		if !c.expired(el) {
+			c.lru.MoveToFront(el)
+			return el.Value.(*cacheEntry).query
+		}
+		c.remove(el)
+		c.stats.Evictions++
+	}
+	if el, ok := c.fingerprints[fingerprint]; ok && len(fingerprint) != 0 {
+		if !c.expired(el) {
+			q = el.Value.(*cacheEntry).query
+			c.stats.FingerprintHits++
+		}
+	}
+	var entry = &cacheEntry{key: key, query: q, fingerprint: fingerprint}
+	if c.ttl > 0 {
+		entry.expires = c.now().Add(c.ttl)
+	}
+	var el = c.lru.PushFront(entry)
+	c.items[key] = el
+	if len(fingerprint) != 0 {
+		c.fingerprints[fingerprint] = el
+	}
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+	return q
+}
+
+// remove: remove element from list and maps, caller must hold lock
+func (c *Cache) remove(el *list.Element) {
+	var entry = c.lru.Remove(el).(*cacheEntry)
+	delete(c.items, entry.key)
+	if c.fingerprints[entry.fingerprint] == el {
+		delete(c.fingerprints, entry.fingerprint)
+	}
+}
+
+func (c *Cache) expired(el *list.Element) bool {
+	var expires = el.Value.(*cacheEntry).expires
+	return !expires.IsZero() && !c.now().Before(expires)
+}
+
+func (c *Cache) result(q *Lucene) *Lucene {
+	if c.clone {
+		return q.Clone()
+	}
+	return q
+}
@@ -0,0 +1,186 @@
+package lucene_parser
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	var c = NewCache(nil)
+	q1, err := c.Parse(`x:1 AND y:2`)
+	assert.Nil(t, err)
+	q2, err := c.Parse(`x:1 AND y:2`)
+	assert.Nil(t, err)
+	assert.True(t, q1 == q2, "ast should be shared")
+	assert.Equal(t, `x:1 AND y:2`, q2.String())
+
+	q, err := c.Parse(`x:1 y:2`)
+	assert.Nil(t, q)
+	assert.NotNil(t, err)
+	_, err = c.Parse(`x:1 y:2`)
+	assert.NotNil(t, err)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Entries: 1}, c.Stats())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	q3, err := c.Parse(`x:1 AND y:2`)
+	assert.Nil(t, err)
+	assert.False(t, q1 == q3)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 4, Entries: 1}, c.Stats())
+
+	c = NewCache(NewParser(WithDefaultField("body")))
+	q, err = c.Parse(`foo`)
+	assert.Nil(t, err)
+	assert.Equal(t, `body:foo`, q.String())
+}
+
+func TestCacheEviction(t *testing.T) {
+	var c = NewCache(nil, WithCacheSize(2))
+	for _, query := range []string{`x:1`, `x:2`, `x:1`, `x:3`} {
+		_, err := c.Parse(query)
+		assert.Nil(t, err)
+	}
+	// x:2 is the least recently used query
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Evictions: 1, Entries: 2}, c.Stats())
+	_, _ = c.Parse(`x:1`)
+	_, _ = c.Parse(`x:3`)
+	assert.Equal(t, uint64(3), c.Stats().Hits)
+	_, _ = c.Parse(`x:2`)
+	assert.Equal(t, uint64(4), c.Stats().Misses)
+
+	assert.Equal(t, DEFAULT_CACHE_SIZE, NewCache(nil, WithCacheSize(0)).size)
+}
+
+func TestCacheTTL(t *testing.T) {
+	var now = time.Unix(0, 0)
+	var c = NewCache(nil, WithTTL(time.Minute))
+	c.now = func() time.Time { return now }
+	q1, _ := c.Parse(`x:1`)
+	now = now.Add(59 * time.Second)
+	q2, _ := c.Parse(`x:1`)
+	assert.True(t, q1 == q2)
+	now = now.Add(time.Second)
+	q3, _ := c.Parse(`x:1`)
+	assert.False(t, q1 == q3)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2, Evictions: 1, Entries: 1}, c.Stats())
+}
+
+func TestCacheClone(t *testing.T) {
+	var c = NewCache(nil, WithClone(true))
+	q1, err := c.Parse(`x:(a OR b) AND y:>1`)
+	assert.Nil(t, err)
+	q2, err := c.Parse(`x:(a OR b) AND y:>1`)
+	assert.Nil(t, err)
+	assert.False(t, q1 == q2)
+	assert.Equal(t, q1, q2)
+	q1.OrQuery.AndQuery.FieldQuery.Field.Value = []string{"z"}
+	q3, _ := c.Parse(`x:(a OR b) AND y:>1`)
+	assert.Equal(t, `x:( a OR b ) AND y:{ 1 TO * }`, q3.String())
+}
+
+func TestCacheFingerprint(t *testing.T) {
+	var c = NewCache(nil, WithFingerprint(true))
+	q1, err := c.Parse(`x:1 AND y:2`)
+	assert.Nil(t, err)
+	q2, err := c.Parse(`y:2 && (x:1)`)
+	assert.Nil(t, err)
+	assert.True(t, q1 == q2, "equivalent queries should share ast")
+	q3, err := c.Parse(`y:2 && (x:1)`)
+	assert.Nil(t, err)
+	assert.True(t, q1 == q3)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2, FingerprintHits: 1, Entries: 2}, c.Stats())
+
+	// different literals never share ast
+	for _, queries := range [][]string{
+		{`zip:01234`, `zip:1234`},
+		{`x:1.0`, `x:1`, `x:+1`},
+		{`x:[ 01 TO 2 ]`, `x:[ 1 TO 2 ]`},
+	} {
+		for _, query := range queries {
+			q, err := c.Parse(query)
+			assert.Nil(t, err)
+			assert.Equal(t, query, q.String())
+		}
+	}
+
+	c = NewCache(nil)
+	q1, _ = c.Parse(`x:1 AND y:2`)
+	q2, _ = c.Parse(`y:2 && (x:1)`)
+	assert.False(t, q1 == q2)
+	assert.Equal(t, `y:2 AND ( x:1 )`, q2.String())
+}
+
+func TestCacheContext(t *testing.T) {
+	var c = NewCache(NewParser(WithEngine(DESCENT_ENGINE), WithLimits(DefaultParseLimits)))
+	_, err := c.Parse(`x:1`)
+	assert.Nil(t, err)
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	// cached query doesn't need to be parsed
+	q, err := c.ParseContext(ctx, `x:1`)
+	assert.Nil(t, err)
+	assert.Equal(t, `x:1`, q.String())
+	q, err = c.ParseContext(ctx, `x:2`)
+	assert.Nil(t, q)
+	assert.True(t, errors.Is(err, context.Canceled), "%v", err)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	var caches = []*Cache{
+		NewCache(nil, WithCacheSize(8)),
+		NewCache(nil, WithCacheSize(8), WithClone(true), WithFingerprint(true)),
+		NewCache(NewParser(WithEngine(DESCENT_ENGINE)), WithCacheSize(8), WithTTL(time.Millisecond)),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				var query = `x:` + strconv.Itoa((i+n)%12) + ` AND y:( a OR "b c" )^2 AND NOT z:>1`
+				for _, c := range caches {
+					q, err := c.Parse(query)
+					if assert.Nil(t, err) {
+						assert.Equal(t, `x:`+strconv.Itoa((i+n)%12)+` AND y:( a OR "b c" )^2 AND NOT z:{ 1 TO * }`, q.String())
+						_ = Fingerprint(q)
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, c := range caches {
+		var stats = c.Stats()
+		assert.Equal(t, uint64(16*100), stats.Hits+stats.Misses)
+		assert.LessOrEqual(t, stats.Entries, 8)
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	for _, bench := range []struct {
+		name  string
+		parse func(string) (*Lucene, error)
+	}{
+		{"participle", NewParser().Parse},
+		{"descent", NewParser(WithEngine(DESCENT_ENGINE)).Parse},
+		{"cache_shared", NewCache(nil).Parse},
+		{"cache_clone", NewCache(nil, WithClone(true)).Parse},
+	} {
+		for i, query := range benchmarkQueries {
+			b.Run(bench.name+"_"+strconv.Itoa(i), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					if _, err := bench.parse(query); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package lucene_parser
+
+// Clone: deep copy lucene query, the copy doesn't share any memory with origin
+func (q *Lucene) Clone() *Lucene {
+	if q == nil {
+		return nil
+	}
+	var res = &Lucene{OrQuery: q.OrQuery.Clone()}
+	if q.OSQuery != nil {
+		res.OSQuery = make([]*OSQuery, 0, len(q.OSQuery))
+		for _, x := range q.OSQuery {
+			res.OSQuery = append(res.OSQuery, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *OrQuery) Clone() *OrQuery {
+	if q == nil {
+		return nil
+	}
+	var res = &OrQuery{AndQuery: q.AndQuery.Clone()}
+	if q.AnSQuery != nil {
+		res.AnSQuery = make([]*AnSQuery, 0, len(q.AnSQuery))
+		for _, x := range q.AnSQuery {
+			res.AnSQuery = append(res.AnSQuery, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *OSQuery) Clone() *OSQuery {
+	if q == nil {
+		return nil
+	}
+	return &OSQuery{OrSymbol: q.OrSymbol.Clone(), OrQuery: q.OrQuery.Clone()}
+}
+
+func (q *AndQuery) Clone() *AndQuery {
+	if q == nil {
+		return nil
+	}
+	return &AndQuery{
+		NotSymbol:  q.NotSymbol.Clone(),
+		ParenQuery: q.ParenQuery.Clone(),
+		FieldQuery: q.FieldQuery.Clone(),
+	}
+}
+
+func (q *AnSQuery) Clone() *AnSQuery {
+	if q == nil {
+		return nil
+	}
+	return &AnSQuery{
+		AndSymbol: q.AndSymbol.Clone(),
+		NotSymbol: q.NotSymbol.Clone(),
+		AndQuery:  q.AndQuery.Clone(),
+	}
+}
+
+func (q *ParenQuery) Clone() *ParenQuery {
+	if q == nil {
+		return nil
+	}
+	return &ParenQuery{SubQuery: q.SubQuery.Clone()}
+}
+
+func (q *FieldQuery) Clone() *FieldQuery {
+	if q == nil {
+		return nil
+	}
+	return &FieldQuery{Field: q.Field.Clone(), Term: q.Term.Clone()}
+}
@@ -0,0 +1,44 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tk "github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestClone(t *testing.T) {
+	for _, input := range []string{
+		`x:1 AND NOT y:2 OR z:[1 TO 2]^2`,
+		`x:1 NOT y:>=2`,
+		`(x:foo~2 || y:"foo bar"^3) && !z:/\d+/`,
+		`x:(foo OR (bar AND !baz))^2`,
+		`(title OR body.*):foo AND http.*:error`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			var c = q.Clone()
+			assert.Equal(t, q, c)
+			assert.Equal(t, q.String(), c.String())
+
+			// modify copy won't affect origin
+			c.OrQuery.AndQuery = nil
+			assert.NotEqual(t, q.String(), c.String())
+		})
+	}
+
+	t.Run("test_no_aliasing", func(t *testing.T) {
+		q, err := ParseLucene(`x:foo`)
+		assert.Nil(t, err)
+		var c = q.Clone()
+		c.OrQuery.AndQuery.FieldQuery.Field.Value[0] = "y"
+		c.OrQuery.AndQuery.FieldQuery.Term.FuzzyTerm.SingleTerm.Begin = tk.Char{Type: tk.IDENT_TOKEN_TYPE, Value: "bar"}
+		assert.Equal(t, `x:foo`, q.String())
+		assert.Equal(t, `y:bar`, c.String())
+	})
+
+	var q *Lucene
+	assert.Nil(t, q.Clone())
+	assert.Nil(t, (*FieldQuery)(nil).Clone())
+}
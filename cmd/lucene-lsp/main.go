@@ -0,0 +1,64 @@
+// Command lucene-lsp: language server of `.lucene` files, it communicates with editor by JSON-RPC over stdio.
+//
+//	lucene-lsp [-fields a,b,c] [-schema fields.txt] [-disable rule,...]
+//
+// fields of schema (given by -fields or file with one field per line) are completed, and lint rules can be disabled by name.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/lint"
+	"github.com/zhuliquan/lucene_parser/lsp"
+)
+
+func main() {
+	var fields = flag.String("fields", "", "comma separated field names of schema")
+	var schema = flag.String("schema", "", "file of field names of schema, one field per line")
+	var disable = flag.String("disable", "", "comma separated names of disabled lint rules")
+	flag.Parse()
+
+	var opts = lsp.Options{Fields: split(*fields), Rules: lint.Disable(lint.DefaultRules(), split(*disable)...)}
+	if *schema != "" {
+		var names, err = readLines(*schema)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		opts.Fields = append(opts.Fields, names...)
+	}
+	if err := lsp.NewServer(opts).Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func split(s string) []string {
+	var res = []string{}
+	for _, x := range strings.Split(s, ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func readLines(path string) ([]string, error) {
+	var f, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var res = []string{}
+	var scanner = bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			res = append(res, line)
+		}
+	}
+	return res, scanner.Err()
+}
@@ -0,0 +1,224 @@
+// Command lucene: parse, format, validate and tokenize lucene queries.
+//
+//	lucene <command> [flags] [query ...]
+//
+// commands are `parse`, `fmt`, `validate` and `tokens`. queries are read from arguments,
+// or from file (-file) or stdin with one query per line if there isn't any argument.
+// syntax of queries is selected by -syntax (`default`, `prefix` or `standard`).
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/participle/lexer"
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/prefix"
+	"github.com/zhuliquan/lucene_parser/standard"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1 // some queries are invalid
+	exitUsage   = 2 // invalid command or flags
+)
+
+const usage = `usage: lucene <command> [flags] [query ...]
+
+commands:
+  parse     dump ast of queries (-format json|tree)
+  fmt       print formatted queries
+  validate  check queries, exit code is 1 if any query is invalid
+  tokens    dump tokens of queries
+
+queries are read from arguments, or from -file / stdin (one query per line) if there isn't any argument.
+run 'lucene <command> -h' for flags of command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// command: options of command
+type command struct {
+	syntax string
+	file   string
+	format string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	var name = args[0]
+	var handlers = map[string]func(c *command, query string) error{
+		"parse":    (*command).parse,
+		"fmt":      (*command).reformat,
+		"validate": (*command).validate,
+		"tokens":   (*command).tokens,
+	}
+	var handler, ok = handlers[name]
+	if !ok {
+		if name != "-h" && name != "-help" && name != "help" {
+			fmt.Fprintf(stderr, "unknown command %q\n", name)
+		}
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	var c = &command{stdout: stdout, stderr: stderr}
+	var fs = flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.syntax, "syntax", "default", "syntax of queries: default, prefix or standard")
+	fs.StringVar(&c.file, "file", "", "file of queries, one query per line")
+	if name == "parse" {
+		fs.StringVar(&c.format, "format", "json", "format of ast: json or tree")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	if err := c.check(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	var queries, err = c.queries(fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	var code = exitOK
+	for _, query := range queries {
+		if err := handler(c, query); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", query, err)
+			code = exitInvalid
+		}
+	}
+	return code
+}
+
+func (c *command) check() error {
+	switch c.syntax {
+	case "default", "prefix", "standard":
+	default:
+		return fmt.Errorf("unknown syntax %q, syntax must be default, prefix or standard", c.syntax)
+	}
+	switch c.format {
+	case "", "json", "tree":
+	default:
+		return fmt.Errorf("unknown format %q, format must be json or tree", c.format)
+	}
+	return nil
+}
+
+// queries: return queries of arguments, file or stdin
+func (c *command) queries(args []string, stdin io.Reader) ([]string, error) {
+	if len(args) != 0 {
+		return args, nil
+	}
+	var r = stdin
+	if c.file != "" {
+		var f, err = os.Open(c.file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var res = []string{}
+	var scanner = bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			res = append(res, line)
+		}
+	}
+	return res, scanner.Err()
+}
+
+// ast: parse query with syntax, ast of standard syntax doesn't support String
+func (c *command) ast(query string) (interface{}, error) {
+	switch c.syntax {
+	case "prefix":
+		return prefix.ParseLucene(query)
+	case "standard":
+		return standard.ParseLucene(query)
+	default:
+		return lucene_parser.ParseLucene(query)
+	}
+}
+
+func (c *command) parse(query string) error {
+	var q, err = c.ast(query)
+	if err != nil {
+		return err
+	}
+	if c.format == "tree" {
+		writeTree(c.stdout, q)
+		return nil
+	}
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, string(data))
+	return nil
+}
+
+// reformat: print formatted query, `format` is taken by flag of parse command
+func (c *command) reformat(query string) error {
+	var q, err = c.ast(query)
+	if err != nil {
+		return err
+	}
+	var s, ok = q.(fmt.Stringer)
+	if !ok {
+		return fmt.Errorf("formatting isn't supported by %s syntax", c.syntax)
+	}
+	fmt.Fprintln(c.stdout, s.String())
+	return nil
+}
+
+func (c *command) validate(query string) error {
+	var q, err = c.ast(query)
+	if err != nil {
+		return err
+	}
+	if x, ok := q.(*lucene_parser.Lucene); ok {
+		if err := lucene_parser.Validate(x); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(c.stdout, "%s: ok\n", query)
+	return nil
+}
+
+func (c *command) tokens(query string) error {
+	var def lexer.Definition = token.Lexer
+	if c.syntax == "standard" {
+		def = standard.Lexer
+	}
+	var lex, err = def.Lex(strings.NewReader(query))
+	if err != nil {
+		return err
+	}
+	var names = lexer.SymbolsByRune(def)
+	for {
+		var t, err = lex.Next()
+		if err != nil {
+			return err
+		} else if t.EOF() {
+			return nil
+		}
+		fmt.Fprintf(c.stdout, "%d:%d\t%s\t%q\n", t.Pos.Line, t.Pos.Column, names[t.Type], t.Value)
+	}
+}
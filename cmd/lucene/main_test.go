@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	type testCase struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}
+	var testCases = []testCase{
+		{
+			name: "test_no_command", args: []string{},
+			code: exitUsage, stderr: "usage: lucene",
+		},
+		{
+			name: "test_unknown_command", args: []string{"foo"},
+			code: exitUsage, stderr: `unknown command "foo"`,
+		},
+		{
+			name: "test_unknown_syntax", args: []string{"parse", "-syntax", "foo", "a:1"},
+			code: exitUsage, stderr: `unknown syntax "foo"`,
+		},
+		{
+			name: "test_unknown_format", args: []string{"parse", "-format", "yaml", "a:1"},
+			code: exitUsage, stderr: `unknown format "yaml"`,
+		},
+		{
+			name: "test_unknown_flag", args: []string{"fmt", "-to", "es", "a:1"},
+			code: exitUsage, stderr: "flag provided but not defined: -to",
+		},
+		{
+			name: "test_parse_json", args: []string{"parse", "a:1"},
+			code: exitOK, stdout: `{"type":"lucene","version":1,"or_query":`,
+		},
+		{
+			name: "test_parse_tree", args: []string{"parse", "-format", "tree", "a:1"},
+			code:   exitOK,
+			stdout: "Lucene\n  OrQuery: OrQuery\n    AndQuery: AndQuery\n      FieldQuery: FieldQuery\n        Field: Field\n          Value[0]: \"a\"\n",
+		},
+		{
+			name: "test_parse_standard", args: []string{"parse", "-syntax", "standard", "a:1 b:2"},
+			code: exitOK, stdout: `{"Query":`,
+		},
+		{
+			name: "test_parse_error", args: []string{"parse", "a:(1"},
+			code: exitInvalid, stderr: "a:(1: ",
+		},
+		{
+			name: "test_fmt_args", args: []string{"fmt", "a:1 and b:[1 TO 2]", "c:(1 or 2)"},
+			code: exitOK, stdout: "a:1 AND b:[ 1 TO 2 ]\nc:( 1 OR 2 )\n",
+		},
+		{
+			name: "test_fmt_stdin", args: []string{"fmt"}, stdin: "a:1 and b:2\n\n  c:3  \n",
+			code: exitOK, stdout: "a:1 AND b:2\nc:3\n",
+		},
+		{
+			name: "test_fmt_prefix", args: []string{"fmt", "-syntax", "prefix", "+a:1 -b:2"},
+			code: exitOK, stdout: "+a:1 -b:2\n",
+		},
+		{
+			name: "test_fmt_standard", args: []string{"fmt", "-syntax", "standard", "a:1"},
+			code: exitInvalid, stderr: "formatting isn't supported by standard syntax",
+		},
+		{
+			name: "test_validate", args: []string{"validate"}, stdin: "a:1\nb:(1\nc:[1 TO 2]\n",
+			code: exitInvalid, stdout: "a:1: ok\nc:[1 TO 2]: ok\n", stderr: "b:(1: ",
+		},
+		{
+			name: "test_no_convert", args: []string{"convert", "-to", "es", "a:1"},
+			code: exitUsage, stderr: `unknown command "convert"`,
+		},
+		{
+			name: "test_tokens", args: []string{"tokens", `a:"x y"`},
+			code:   exitOK,
+			stdout: "1:1\tIDENT\t\"a\"\n1:2\tCOLON\t\":\"\n1:3\tQUOTE\t\"\\\"\"\n1:4\tIDENT\t\"x\"\n1:5\tWHITESPACE\t\" \"\n1:6\tIDENT\t\"y\"\n1:7\tQUOTE\t\"\\\"\"\n",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+			var code = run(tt.args, strings.NewReader(tt.stdin), stdout, stderr)
+			assert.Equal(t, tt.code, code)
+			assert.True(t, strings.HasPrefix(stdout.String(), tt.stdout), stdout.String())
+			assert.Contains(t, stderr.String(), tt.stderr)
+			if tt.stderr == "" {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "lucene")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	var file = filepath.Join(dir, "queries.txt")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("a:1 or b:2\r\nc:3\n"), 0644))
+
+	var stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	assert.Equal(t, exitOK, run([]string{"fmt", "-file", file}, strings.NewReader("x:1"), stdout, stderr))
+	assert.Equal(t, "a:1 OR b:2\nc:3\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, exitUsage, run([]string{"fmt", "-file", filepath.Join(dir, "missing.txt")}, strings.NewReader(""), stdout, stderr))
+	assert.Empty(t, stdout.String())
+}
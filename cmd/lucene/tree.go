@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// writeTree: write ast as indented tree, only exported and non-zero fields of nodes are written
+func writeTree(w io.Writer, node interface{}) {
+	writeNode(w, "", reflect.ValueOf(node), 0)
+}
+
+func writeNode(w io.Writer, name string, v reflect.Value, depth int) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	var indent = strings.Repeat("  ", depth)
+	if name != "" {
+		name += ": "
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		fmt.Fprintf(w, "%s%s%s\n", indent, name, v.Type().Name())
+		for i := 0; i < v.NumField(); i++ {
+			var f = v.Type().Field(i)
+			if f.PkgPath != "" || isZero(v.Field(i)) {
+				continue
+			}
+			writeNode(w, f.Name, v.Field(i), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeNode(w, fmt.Sprintf("%s[%d]", strings.TrimSuffix(name, ": "), i), v.Index(i), depth)
+		}
+	case reflect.String:
+		fmt.Fprintf(w, "%s%s%q\n", indent, name, v.String())
+	default:
+		fmt.Fprintf(w, "%s%s%v\n", indent, name, v.Interface())
+	}
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -11,4 +11,5 @@ const (
 	NOT_QUERY
 	FIELD_QUERY
 	PAREN_QUERY
+	EXISTS_QUERY
 )
@@ -0,0 +1,269 @@
+package lucene_parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/regex"
+	"github.com/zhuliquan/lucene_parser/term"
+	"github.com/zhuliquan/lucene_parser/wildcard"
+)
+
+type CostIssueType uint32
+
+const (
+	LEADING_WILDCARD_COST_ISSUE CostIssueType = iota // wildcard term begins with `*` or `?` (i.e. `*foo*`), all terms of field are scanned
+	UNBOUNDED_REGEXP_COST_ISSUE                      // regexp without literal prefix or with too many unbounded parts (i.e. `/.*.*.*a/`)
+	HIGH_FUZZINESS_COST_ISSUE                        // edit distance or slop is greater than limit (i.e. `foo~5`, `"foo bar"~100`)
+	DEEP_NESTING_COST_ISSUE                          // parenthesis (paren query and term group) is nested too deeply
+	TOO_MANY_CLAUSES_COST_ISSUE                      // number of term clauses is greater than limit
+	LARGE_TERM_GROUP_COST_ISSUE                      // number of terms in term group (i.e. `x:(1 OR 2 OR ...)`) is greater than limit
+	TOO_EXPENSIVE_COST_ISSUE                         // estimated cost is greater than limit
+)
+
+var ErrQueryTooExpensive = fmt.Errorf("query is too expensive")
+
+// CostLimits: limits of AnalyzeCost, zero means no limit
+type CostLimits struct {
+	AllowLeadingWildcard bool    // leading wildcard isn't reported if it's true
+	AllowUnboundedRegexp bool    // regexp without literal prefix isn't reported if it's true
+	MaxRegexpUnbounded   int     // maximum number of unbounded parts (i.e. `.*`, `a+`, `@`) of one regexp
+	MaxEditDistance      int     // maximum edit distance of fuzzy term, AUTO fuzziness is resolved with term.DefaultAutoConfig
+	MaxSlop              int     // maximum slop of phrase term
+	MaxDepth             int     // maximum nesting of paren queries and term groups
+	MaxClauses           int     // maximum number of term clauses, each term (including term of term group) is one clause
+	MaxTermGroupSize     int     // maximum number of terms in one term group
+	MaxCost              float64 // maximum estimated cost of query
+}
+
+// DefaultCostLimits: limits which reject queries known to be expensive for lucene / ES,
+// MaxClauses is same as default `indices.query.bool.max_clause_count` of ES.
+var DefaultCostLimits = CostLimits{
+	MaxRegexpUnbounded: 2,
+	MaxEditDistance:    term.MaxEditDistance,
+	MaxSlop:            50,
+	MaxDepth:           20,
+	MaxClauses:         1024,
+	MaxTermGroupSize:   256,
+	MaxCost:            10000,
+}
+
+// estimated costs of terms, they are relative to cost of exact term
+const (
+	termCost            = 1.0
+	rangeCost           = 5.0
+	prefixWildcardCost  = 10.0
+	wildcardCost        = 20.0
+	leadingWildcardCost = 100.0
+	regexpCost          = 20.0
+	unboundedRegexpCost = 100.0
+	fuzzyCost           = 10.0
+)
+
+// CostIssue: expensive part of query, offset is relative to q.String()
+type CostIssue struct {
+	Type    CostIssueType
+	Term    string
+	Offset  int
+	Message string
+}
+
+// CostReport: result of AnalyzeCost, Cost is estimated relative cost of query (exact term costs 1).
+type CostReport struct {
+	Cost    float64
+	Depth   int
+	Clauses int
+	Issues  []CostIssue
+}
+
+// Err: return ErrQueryTooExpensive with messages of issues, it returns nil if there isn't any issue
+func (r *CostReport) Err() error {
+	if r == nil || len(r.Issues) == 0 {
+		return nil
+	}
+	var sl = make([]string, 0, len(r.Issues))
+	for _, x := range r.Issues {
+		sl = append(sl, x.Message)
+	}
+	return fmt.Errorf("%w: %s", ErrQueryTooExpensive, strings.Join(sl, "; "))
+}
+
+// AnalyzeCost: estimate cost of query and report parts which exceed limits, query isn't rejected
+func AnalyzeCost(q *Lucene, limits CostLimits) *CostReport {
+	var a = &costAnalyzer{limits: limits, report: &CostReport{Issues: []CostIssue{}}}
+	Inspect(q, a.visit)
+	var r = a.report
+	if limits.MaxClauses > 0 && r.Clauses > limits.MaxClauses {
+		a.issue(TOO_MANY_CLAUSES_COST_ISSUE, "", 0, "%d clauses exceed limit %d", r.Clauses, limits.MaxClauses)
+	}
+	if limits.MaxCost > 0 && r.Cost > limits.MaxCost {
+		a.issue(TOO_EXPENSIVE_COST_ISSUE, "", 0, "cost %.0f exceeds limit %.0f", r.Cost, limits.MaxCost)
+	}
+	return r
+}
+
+// CheckCost: analyze cost of query like AnalyzeCost, and reject query with ErrQueryTooExpensive if any limit is exceeded
+func CheckCost(q *Lucene, limits CostLimits) (*CostReport, error) {
+	var r = AnalyzeCost(q, limits)
+	return r, r.Err()
+}
+
+type costAnalyzer struct {
+	limits CostLimits
+	report *CostReport
+	stack  []interface{}
+	depth  int
+	deep   bool
+	// size and offset of current term group, term group can't be nested
+	groupSize   int
+	groupOffset int
+}
+
+func (a *costAnalyzer) issue(typ CostIssueType, t string, offset int, format string, args ...interface{}) {
+	var msg = fmt.Sprintf(format, args...)
+	if t != "" {
+		msg = fmt.Sprintf("%s at %d: %s", t, offset, msg)
+	}
+	a.report.Issues = append(a.report.Issues, CostIssue{Type: typ, Term: t, Offset: offset, Message: msg})
+}
+
+func nesting(node interface{}) bool {
+	switch node.(type) {
+	case *ParenQuery, *term.TermGroup, *term.ParenTermGroup:
+		return true
+	}
+	return false
+}
+
+func (a *costAnalyzer) visit(node interface{}, offset int) bool {
+	if node == nil {
+		a.leave(a.stack[len(a.stack)-1])
+		a.stack = a.stack[:len(a.stack)-1]
+		return false
+	}
+	var parent interface{}
+	if len(a.stack) != 0 {
+		parent = a.stack[len(a.stack)-1]
+	}
+	a.stack = append(a.stack, node)
+	if nesting(node) {
+		if a.depth++; a.depth > a.report.Depth {
+			a.report.Depth = a.depth
+		}
+		if a.limits.MaxDepth > 0 && a.depth > a.limits.MaxDepth && !a.deep {
+			// only the first one is reported
+			a.deep = true
+			a.issue(DEEP_NESTING_COST_ISSUE, "", offset, "nesting at %d exceeds limit %d", offset, a.limits.MaxDepth)
+		}
+	}
+	switch x := node.(type) {
+	case *term.TermGroup:
+		a.groupSize, a.groupOffset = 0, offset
+	case *term.FieldTermGroup:
+		a.groupSize++
+	case *term.FuzzyTerm:
+		a.fuzzyTerm(x, offset)
+	case *term.SingleTerm:
+		a.report.Clauses++
+		if f, ok := parent.(*term.FuzzyTerm); ok && a.fuzzy(f) {
+			// cost of fuzzy term is added by fuzzyTerm
+			break
+		}
+		a.singleTerm(x, offset)
+	case *term.PhraseTerm:
+		a.report.Clauses++
+		a.report.Cost += termCost
+	case *term.RegexpTerm:
+		a.report.Clauses++
+		a.regexpTerm(x, offset)
+	case *term.RangeTerm, *term.SRangeTerm, *term.DRangeTerm:
+		a.report.Clauses++
+		a.report.Cost += rangeCost
+	}
+	return true
+}
+
+func (a *costAnalyzer) leave(node interface{}) {
+	if nesting(node) {
+		a.depth--
+	}
+	if _, ok := node.(*term.TermGroup); ok && a.limits.MaxTermGroupSize > 0 && a.groupSize > a.limits.MaxTermGroupSize {
+		a.issue(LARGE_TERM_GROUP_COST_ISSUE, "", a.groupOffset, "term group at %d has %d terms, which exceeds limit %d",
+			a.groupOffset, a.groupSize, a.limits.MaxTermGroupSize)
+	}
+}
+
+// fuzzy: check whether single term has fuzziness
+func (a *costAnalyzer) fuzzy(t *term.FuzzyTerm) bool {
+	return t.SingleTerm != nil && len(t.FuzzySymbol) != 0
+}
+
+func (a *costAnalyzer) fuzzyTerm(t *term.FuzzyTerm, offset int) {
+	if t.PhraseTerm != nil {
+		var slop, err = t.Slop()
+		if err == nil && a.limits.MaxSlop > 0 && slop > a.limits.MaxSlop {
+			a.issue(HIGH_FUZZINESS_COST_ISSUE, t.String(), offset, "slop %d exceeds limit %d", slop, a.limits.MaxSlop)
+		}
+		return
+	} else if !a.fuzzy(t) {
+		return
+	}
+	var edits, err = term.ResolveFuzziness(&term.Term{FuzzyTerm: t}, term.DefaultAutoConfig)
+	if err != nil {
+		// edit distance which is greater than term.MaxEditDistance
+		a.issue(HIGH_FUZZINESS_COST_ISSUE, t.String(), offset, "%v", err)
+		a.report.Cost += fuzzyCost * float64(term.MaxEditDistance)
+		return
+	} else if a.limits.MaxEditDistance > 0 && int(edits) > a.limits.MaxEditDistance {
+		a.issue(HIGH_FUZZINESS_COST_ISSUE, t.String(), offset, "edit distance %d exceeds limit %d", int(edits), a.limits.MaxEditDistance)
+	}
+	if edits == 0 {
+		a.report.Cost += termCost
+	} else {
+		a.report.Cost += fuzzyCost * float64(edits)
+	}
+}
+
+func (a *costAnalyzer) singleTerm(t *term.SingleTerm, offset int) {
+	if t.IsExists() {
+		a.report.Cost += termCost
+		return
+	}
+	var p = wildcard.CompileTerm(t)
+	var raw = t.String()
+	switch {
+	case p.Type() == wildcard.EXACT_PATTERN:
+		a.report.Cost += termCost
+	case raw[0] == '*' || raw[0] == '?':
+		a.report.Cost += leadingWildcardCost
+		if !a.limits.AllowLeadingWildcard {
+			a.issue(LEADING_WILDCARD_COST_ISSUE, raw, offset, "leading wildcard scans all terms")
+		}
+	case p.Type() == wildcard.PREFIX_PATTERN:
+		a.report.Cost += prefixWildcardCost
+	default:
+		a.report.Cost += wildcardCost
+	}
+}
+
+func (a *costAnalyzer) regexpTerm(t *term.RegexpTerm, offset int) {
+	var r, err = regex.ParseTerm(t)
+	if err != nil {
+		// invalid regexp is rejected by search engine, it isn't a problem of cost
+		a.report.Cost += regexpCost
+		return
+	}
+	var unbounded = r.Unbounded()
+	if unbounded == 0 || r.LiteralPrefix() != "" {
+		a.report.Cost += regexpCost
+	} else {
+		a.report.Cost += unboundedRegexpCost
+		if !a.limits.AllowUnboundedRegexp {
+			a.issue(UNBOUNDED_REGEXP_COST_ISSUE, t.String(), offset, "unbounded regexp without literal prefix scans all terms")
+			return
+		}
+	}
+	if a.limits.MaxRegexpUnbounded > 0 && unbounded > a.limits.MaxRegexpUnbounded {
+		a.issue(UNBOUNDED_REGEXP_COST_ISSUE, t.String(), offset, "%d unbounded parts exceed limit %d", unbounded, a.limits.MaxRegexpUnbounded)
+	}
+}
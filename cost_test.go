@@ -0,0 +1,106 @@
+package lucene_parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeCost(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		limits  CostLimits
+		cost    float64
+		depth   int
+		clauses int
+		issues  []CostIssue
+	}
+	var group = "x:(" + strings.TrimPrefix(strings.Repeat(" OR 1", 5), " OR ") + ")"
+	for _, tt := range []testCase{
+		{
+			name: "test_cheap", input: `x:1 AND y:"foo bar" AND z:[1 TO 2]`, limits: DefaultCostLimits,
+			cost: 7, clauses: 3, issues: []CostIssue{},
+		},
+		{
+			name: "test_wildcard", input: `x:foo* OR x:f?o* OR x:* OR x:*foo*`, limits: DefaultCostLimits,
+			cost: 131, clauses: 4,
+			issues: []CostIssue{
+				{Type: LEADING_WILDCARD_COST_ISSUE, Term: `*foo*`, Offset: 29, Message: "*foo* at 29: leading wildcard scans all terms"},
+			},
+		},
+		{
+			name: "test_allow_leading_wildcard", input: `x:*foo*`, limits: CostLimits{AllowLeadingWildcard: true},
+			cost: 100, clauses: 1, issues: []CostIssue{},
+		},
+		{
+			name: "test_regexp", input: `x:/ab.*/ OR x:/.*.*.*a/ OR x:/ab.*c.*d.*/`, limits: DefaultCostLimits,
+			cost: 140, clauses: 3,
+			issues: []CostIssue{
+				{Type: UNBOUNDED_REGEXP_COST_ISSUE, Term: `/.*.*.*a/`, Offset: 14, Message: "/.*.*.*a/ at 14: unbounded regexp without literal prefix scans all terms"},
+				{Type: UNBOUNDED_REGEXP_COST_ISSUE, Term: `/ab.*c.*d.*/`, Offset: 29, Message: "/ab.*c.*d.*/ at 29: 3 unbounded parts exceed limit 2"},
+			},
+		},
+		{
+			name: "test_fuzziness", input: `x:foo~1 OR x:foo~5 OR x:"foo bar"~100 OR x:foobar~`, limits: DefaultCostLimits,
+			cost: 51, clauses: 4,
+			issues: []CostIssue{
+				{Type: HIGH_FUZZINESS_COST_ISSUE, Term: `foo~5`, Offset: 13, Message: "foo~5 at 13: edit distance must be 0 ~ 2 or similarity between 0 and 1: ~5"},
+				{Type: HIGH_FUZZINESS_COST_ISSUE, Term: `"foo bar"~100`, Offset: 24, Message: `"foo bar"~100 at 24: slop 100 exceeds limit 50`},
+			},
+		},
+		{
+			name: "test_max_edit_distance", input: `x:foobar~`, limits: CostLimits{MaxEditDistance: 1},
+			cost: 20, clauses: 1,
+			issues: []CostIssue{
+				{Type: HIGH_FUZZINESS_COST_ISSUE, Term: `foobar~`, Offset: 2, Message: "foobar~ at 2: edit distance 2 exceeds limit 1"},
+			},
+		},
+		{
+			name: "test_depth", input: `x:1 AND (y:1 OR (z:(1 OR (2 AND 3))))`, limits: CostLimits{MaxDepth: 3},
+			cost: 5, depth: 4, clauses: 5,
+			issues: []CostIssue{
+				{Type: DEEP_NESTING_COST_ISSUE, Offset: 28, Message: "nesting at 28 exceeds limit 3"},
+			},
+		},
+		{
+			name: "test_term_group", input: group, limits: CostLimits{MaxTermGroupSize: 4, MaxClauses: 4, MaxCost: 4},
+			cost: 5, depth: 1, clauses: 5,
+			issues: []CostIssue{
+				{Type: LARGE_TERM_GROUP_COST_ISSUE, Offset: 2, Message: "term group at 2 has 5 terms, which exceeds limit 4"},
+				{Type: TOO_MANY_CLAUSES_COST_ISSUE, Message: "5 clauses exceed limit 4"},
+				{Type: TOO_EXPENSIVE_COST_ISSUE, Message: "cost 5 exceeds limit 4"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var r = AnalyzeCost(q, tt.limits)
+			assert.Equal(t, tt.cost, r.Cost)
+			assert.Equal(t, tt.depth, r.Depth)
+			assert.Equal(t, tt.clauses, r.Clauses)
+			assert.Equal(t, tt.issues, r.Issues)
+			for _, x := range r.Issues {
+				if x.Term != "" {
+					assert.Equal(t, x.Term, q.String()[x.Offset:x.Offset+len(x.Term)])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCost(t *testing.T) {
+	q, err := ParseLucene(`x:*foo AND y:1`)
+	assert.Nil(t, err)
+	r, err := CheckCost(q, DefaultCostLimits)
+	assert.True(t, errors.Is(err, ErrQueryTooExpensive))
+	assert.Equal(t, "query is too expensive: *foo at 2: leading wildcard scans all terms", err.Error())
+	assert.Equal(t, float64(101), r.Cost)
+
+	r, err = CheckCost(q, CostLimits{AllowLeadingWildcard: true})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(101), r.Cost)
+}
@@ -0,0 +1,749 @@
+package lucene_parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+	op "github.com/zhuliquan/lucene_parser/operator"
+	tm "github.com/zhuliquan/lucene_parser/term"
+	tk "github.com/zhuliquan/lucene_parser/token"
+)
+
+var symbols = tk.Lexer.Symbols()
+
+var (
+	whitespaceSymbol = symbols["WHITESPACE"]
+	numberSymbol     = symbols["NUMBER"]
+	dotSymbol        = symbols["DOT"]
+	quoteSymbol      = symbols["QUOTE"]
+	slashSymbol      = symbols["SLASH"]
+	reverseSymbol    = symbols["REVERSE"]
+	colonSymbol      = symbols["COLON"]
+	compareSymbol    = symbols["COMPARE"]
+	fuzzySymbol      = symbols["FUZZY"]
+	boostSymbol      = symbols["BOOST"]
+	lparenSymbol     = symbols["LPAREN"]
+	rparenSymbol     = symbols["RPAREN"]
+	andSymbol        = symbols["AND"]
+	sorSymbol        = symbols["SOR"]
+	notSymbol        = symbols["NOT"]
+)
+
+// sets of tokens which are captured by repetition of grammar, i.e. `@(IDENT|ESCAPE|NUMBER)+`
+var (
+	fieldChars      = symbolSet("IDENT", "ESCAPE", "MINUS", "NUMBER", "DOT")
+	termBeginChars  = symbolSet("IDENT", "ESCAPE", "NUMBER", "WILDCARD", "MINUS", "PLUS")
+	termChars       = symbolSet("IDENT", "ESCAPE", "NUMBER", "DOT", "WILDCARD", "MINUS", "PLUS", "SOR", "SLASH")
+	rangeValueChars = symbolSet("IDENT", "ESCAPE", "NUMBER", "DOT", "PLUS", "MINUS", "SOR", "SLASH", "COLON")
+	leftBrackets    = symbolSet("LBRACE", "LBRACK")
+	rightBrackets   = symbolSet("RBRACK", "RBRACE")
+)
+
+// symbolSet: bit set of symbols, symbols of lexer are small negative runes (EOF is -1)
+func symbolSet(names ...string) uint64 {
+	var res uint64
+	for _, name := range names {
+		res |= 1 << uint(-symbols[name])
+	}
+	return res
+}
+
+// parseDescent: parse query by hand-written recursive descent parser. grammar is the same as struct tags and Parse
+// methods (i.e. SingleTerm.Parse) of ast, and ordered choices are tried with backtracking like participle, so that
+// ast is the same as ast parsed by LuceneParser unless options of parser change grammar. error is reported at
+// farthest token which parser failed to match.
+// limits of tokens and depth are enforced while tokenizing and parsing, and parsing is stopped as soon as ctx is done.
+func (p *Parser) parseDescent(ctx context.Context, queryString string) (*Lucene, error) {
+	var tokens, err = tk.TokenizeContext(ctx, queryString, p.limits.MaxTokens)
+	if err != nil {
+		return nil, err
+	}
+	var d = &descent{tokens: tokens, config: p, ctx: ctx}
+	var q = d.lucene()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if q != nil && d.peek().EOF() {
+		return q, nil
+	}
+	d.miss()
+	return nil, participle.UnexpectedTokenError{Unexpected: d.tokens[d.farthest]}
+}
+
+// descent: state of recursive descent parser, every method returns nil (or false) and restores pos if it doesn't match
+type descent struct {
+	tokens   []lexer.Token // last token is EOF
+	pos      int
+	farthest int     // farthest position of token which doesn't match
+	config   *Parser // options of grammar, it's read only
+	ctx      context.Context
+	steps    int   // count of rules which are tried, ctx is checked periodically
+	depth    int   // nesting of parentheses
+	err      error // error which aborts parsing
+}
+
+// step: check ctx periodically, parsing is aborted if ctx is done
+func (p *descent) step() {
+	if p.steps++; p.steps%256 == 0 {
+		if err := p.ctx.Err(); err != nil {
+			p.abort(err)
+		}
+	}
+}
+
+// enter: enter parentheses which have been matched, parsing is aborted with ErrTooDeep if nesting exceeds limit.
+// leave must be called if it returns true.
+func (p *descent) enter() bool {
+	if p.depth++; p.config.limits.MaxDepth > 0 && p.depth > p.config.limits.MaxDepth {
+		p.abort(fmt.Errorf("%w: depth exceeds %d at %s", ErrTooDeep, p.config.limits.MaxDepth, p.tokens[p.pos-1].Pos))
+		p.depth--
+		return false
+	}
+	return true
+}
+
+func (p *descent) leave() {
+	p.depth--
+}
+
+// abort: stop parsing with err, all tokens are replaced by EOF, so that every rule fails and parser returns at once
+func (p *descent) abort(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+	var eof = p.tokens[len(p.tokens)-1]
+	for i := range p.tokens {
+		p.tokens[i] = eof
+	}
+}
+
+func (p *descent) peek() lexer.Token {
+	return p.tokens[p.pos]
+}
+
+func (p *descent) miss() {
+	if p.pos > p.farthest {
+		p.farthest = p.pos
+	}
+}
+
+// match: match token of symbol
+func (p *descent) match(symbol rune) (string, bool) {
+	if t := p.tokens[p.pos]; t.Type == symbol {
+		p.pos++
+		return t.Value, true
+	}
+	p.miss()
+	return "", false
+}
+
+// matchSet: match token of any symbol of set
+func (p *descent) matchSet(set uint64) (string, bool) {
+	var t, ok = p.matchToken(set)
+	return t.Value, ok
+}
+
+// matchToken: match token of any symbol of set like matchSet, and return token with its type
+func (p *descent) matchToken(set uint64) (lexer.Token, bool) {
+	if t := p.tokens[p.pos]; t.Type < 0 && t.Type > -64 && set&(1<<uint(-t.Type)) != 0 {
+		p.pos++
+		return t, true
+	}
+	p.miss()
+	return lexer.Token{}, false
+}
+
+// keyword: match token whose value is one of words, it's like literal of grammar (i.e. 'AND' | 'and')
+func (p *descent) keyword(words ...string) (string, bool) {
+	var t = p.tokens[p.pos]
+	for _, w := range words {
+		if t.Value == w {
+			p.pos++
+			return t.Value, true
+		}
+	}
+	p.miss()
+	return "", false
+}
+
+// word: match token whose value is one of words of operator, words are compared case-insensitively unless
+// parser is case-sensitive
+func (p *descent) word(words []string) (string, bool) {
+	var t = p.tokens[p.pos]
+	for _, w := range words {
+		if t.Value == w || (!p.config.caseSensitive && strings.EqualFold(t.Value, w)) {
+			p.pos++
+			return t.Value, true
+		}
+	}
+	p.miss()
+	return "", false
+}
+
+// isKeyword: check whether term is bare word of operator (i.e. `AND`), it can't be term of default field and
+// term of group which is joined by default operator
+func (p *descent) isKeyword(t *tm.SingleTerm) bool {
+	if t == nil || len(t.Chars) != 0 {
+		return false
+	}
+	var k = p.config.keywords
+	for _, words := range [][]string{k.And, k.Or, k.Not} {
+		for _, w := range words {
+			if t.Begin.Value == w || (!p.config.caseSensitive && strings.EqualFold(t.Begin.Value, w)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// skip: skip tokens of symbol and return count of skipped tokens, it's like `WHITESPACE*`
+func (p *descent) skip(symbol rune) int {
+	var n = 0
+	for ; p.tokens[p.pos].Type == symbol; n++ {
+		p.pos++
+	}
+	return n
+}
+
+// chars: values of tokens before end, escaped end is included, it's like `( REVERSE QUOTE | !QUOTE )*`
+func (p *descent) chars(end rune) []string {
+	var res []string
+	for t := p.peek(); !t.EOF() && t.Type != end; t = p.peek() {
+		p.pos++
+		res = append(res, t.Value)
+		if n := p.peek(); t.Type == reverseSymbol && n.Type == end {
+			p.pos++
+			res = append(res, n.Value)
+		}
+	}
+	return res
+}
+
+// symbol: `WHITESPACE* X X WHITESPACE*` or `WHITESPACE+ ( words ) WHITESPACE+`, i.e. `&&` and `AND`
+func (p *descent) symbol(double rune, words []string) (string, bool) {
+	var mark = p.pos
+	p.skip(whitespaceSymbol)
+	if a, ok := p.match(double); ok {
+		if b, ok := p.match(double); ok {
+			p.skip(whitespaceSymbol)
+			return a + b, true
+		}
+	}
+	p.pos = mark
+	if p.skip(whitespaceSymbol) > 0 {
+		if s, ok := p.word(words); ok && p.skip(whitespaceSymbol) > 0 {
+			return s, true
+		}
+		p.miss()
+	}
+	p.pos = mark
+	return "", false
+}
+
+func (p *descent) andSymbol() *op.AndSymbol {
+	if s, ok := p.symbol(andSymbol, p.config.keywords.And); ok {
+		return &op.AndSymbol{Symbol: s}
+	}
+	return nil
+}
+
+func (p *descent) orSymbol() *op.OrSymbol {
+	if s, ok := p.symbol(sorSymbol, p.config.keywords.Or); ok {
+		return &op.OrSymbol{Symbol: s}
+	}
+	return nil
+}
+
+func (p *descent) notSymbol() *op.NotSymbol {
+	var mark = p.pos
+	if s, ok := p.match(notSymbol); ok {
+		p.skip(whitespaceSymbol)
+		return &op.NotSymbol{Symbol: s}
+	}
+	if s, ok := p.word(p.config.keywords.Not); ok {
+		if p.skip(whitespaceSymbol) > 0 {
+			return &op.NotSymbol{Symbol: s}
+		}
+		p.miss()
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) lucene() *Lucene {
+	var q = &Lucene{OrQuery: p.orQuery()}
+	if q.OrQuery == nil {
+		return nil
+	}
+	for x := p.osQuery(); x != nil; x = p.osQuery() {
+		q.OSQuery = append(q.OSQuery, x)
+	}
+	return q
+}
+
+func (p *descent) orQuery() *OrQuery {
+	var q = &OrQuery{AndQuery: p.andQuery()}
+	if q.AndQuery == nil {
+		return nil
+	}
+	for x := p.anSQuery(); x != nil; x = p.anSQuery() {
+		q.AnSQuery = append(q.AnSQuery, x)
+	}
+	return q
+}
+
+func (p *descent) osQuery() *OSQuery {
+	var mark = p.pos
+	if s := p.orSymbol(); s != nil {
+		if q := p.orQuery(); q != nil {
+			return &OSQuery{OrSymbol: s, OrQuery: q}
+		}
+	} else if p.implicit(OR_OPERATOR) {
+		if q := p.orQuery(); q != nil {
+			return &OSQuery{OrSymbol: &op.OrSymbol{Symbol: "OR"}, OrQuery: q}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) andQuery() *AndQuery {
+	p.step()
+	var mark = p.pos
+	var q = &AndQuery{NotSymbol: p.notSymbol()}
+	if q.ParenQuery = p.parenQuery(); q.ParenQuery != nil {
+		return q
+	}
+	if q.FieldQuery = p.fieldQuery(); q.FieldQuery != nil {
+		return q
+	}
+	if q.FieldQuery = p.defaultFieldQuery(); q.FieldQuery != nil {
+		return q
+	}
+	p.pos = mark
+	return nil
+}
+
+// defaultFieldQuery: bare term of default field (i.e. `foo` and `"foo bar"`), words of operators aren't bare terms
+func (p *descent) defaultFieldQuery() *FieldQuery {
+	if p.config.defaultField == nil {
+		return nil
+	}
+	var mark = p.pos
+	if t := p.term(); t != nil && (t.FuzzyTerm == nil || !p.isKeyword(t.FuzzyTerm.SingleTerm)) {
+		return &FieldQuery{Field: p.config.defaultField.Clone(), Term: t}
+	}
+	p.pos = mark
+	return nil
+}
+
+// implicit: match whitespaces which separate clauses if they are joined by operator by default
+func (p *descent) implicit(operator Operator) bool {
+	return p.config.defaultOperator == operator && p.skip(whitespaceSymbol) > 0
+}
+
+func (p *descent) anSQuery() *AnSQuery {
+	var mark = p.pos
+	var q = &AnSQuery{AndSymbol: p.andSymbol()}
+	if q.AndSymbol == nil {
+		if p.skip(whitespaceSymbol) > 0 {
+			q.NotSymbol = p.notSymbol()
+		}
+		if q.NotSymbol == nil && p.config.defaultOperator == AND_OPERATOR && p.pos > mark {
+			q.AndSymbol = &op.AndSymbol{Symbol: "AND"}
+		} else if q.NotSymbol == nil {
+			p.pos = mark
+			return nil
+		}
+	}
+	if q.AndQuery = p.andQuery(); q.AndQuery != nil {
+		return q
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) parenQuery() *ParenQuery {
+	var mark = p.pos
+	if _, ok := p.match(lparenSymbol); ok && p.enter() {
+		defer p.leave()
+		p.skip(whitespaceSymbol)
+		if q := p.lucene(); q != nil {
+			p.skip(whitespaceSymbol)
+			if _, ok := p.match(rparenSymbol); ok {
+				return &ParenQuery{SubQuery: q}
+			}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) fieldQuery() *FieldQuery {
+	var mark = p.pos
+	if f := p.field(); f != nil {
+		if _, ok := p.match(colonSymbol); ok {
+			if t := p.term(); t != nil {
+				return &FieldQuery{Field: f, Term: t}
+			}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) field() *tm.Field {
+	var values []string
+	for {
+		if s, ok := p.matchSet(fieldChars); ok {
+			values = append(values, s)
+		} else if s, ok := p.keyword("*"); ok {
+			values = append(values, s)
+		} else {
+			break
+		}
+	}
+	if len(values) != 0 {
+		return &tm.Field{Value: values}
+	}
+
+	var mark = p.pos
+	if _, ok := p.match(lparenSymbol); ok && p.enter() {
+		defer p.leave()
+		p.skip(whitespaceSymbol)
+		if f := p.field(); f != nil {
+			var group = []*tm.Field{f}
+			for {
+				var next = p.pos
+				if _, ok := p.symbol(sorSymbol, p.config.keywords.Or); ok {
+					if f := p.field(); f != nil {
+						group = append(group, f)
+						continue
+					}
+				}
+				p.pos = next
+				break
+			}
+			p.skip(whitespaceSymbol)
+			if _, ok := p.match(rparenSymbol); ok {
+				return &tm.Field{Group: group}
+			}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) term() *tm.Term {
+	if x := p.regexpTerm(); x != nil {
+		return &tm.Term{RegexpTerm: x}
+	} else if x := p.fuzzyTerm(); x != nil {
+		return &tm.Term{FuzzyTerm: x}
+	} else if x := p.rangeTerm(); x != nil {
+		return &tm.Term{RangeTerm: x}
+	} else if x := p.termGroup(); x != nil {
+		return &tm.Term{TermGroup: x}
+	}
+	return nil
+}
+
+func (p *descent) regexpTerm() *tm.RegexpTerm {
+	var mark = p.pos
+	if _, ok := p.match(slashSymbol); ok {
+		if chars := p.chars(slashSymbol); len(chars) != 0 {
+			if _, ok := p.match(slashSymbol); ok {
+				return &tm.RegexpTerm{Chars: chars}
+			}
+		} else {
+			p.miss()
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) fuzzyTerm() *tm.FuzzyTerm {
+	var t = &tm.FuzzyTerm{SingleTerm: p.singleTerm()}
+	if t.SingleTerm == nil {
+		if t.PhraseTerm = p.phraseTerm(); t.PhraseTerm == nil {
+			return nil
+		}
+	}
+	if s, ok := p.fuzzySymbol(); ok {
+		t.FuzzySymbol = s
+	} else if s, ok := p.boostSymbol(); ok {
+		t.BoostSymbol = s
+	}
+	return t
+}
+
+// fuzzySymbol: `FUZZY (NUMBER (DOT NUMBER)? | 'AUTO' (COLON NUMBER ',' NUMBER)?)?`
+func (p *descent) fuzzySymbol() (string, bool) {
+	var s, ok = p.match(fuzzySymbol)
+	if !ok {
+		return "", false
+	}
+	if n, ok := p.match(numberSymbol); ok {
+		return s + n + p.fraction(), true
+	}
+	if a, ok := p.keyword("AUTO"); ok {
+		s += a
+		var mark = p.pos
+		if c, ok := p.match(colonSymbol); ok {
+			if l, ok := p.match(numberSymbol); ok {
+				if m, ok := p.keyword(","); ok {
+					if r, ok := p.match(numberSymbol); ok {
+						return s + c + l + m + r, true
+					}
+				}
+			}
+		}
+		p.pos = mark
+	}
+	return s, true
+}
+
+// boostSymbol: `BOOST NUMBER? (DOT NUMBER)?`
+func (p *descent) boostSymbol() (string, bool) {
+	var s, ok = p.match(boostSymbol)
+	if !ok {
+		return "", false
+	}
+	if n, ok := p.match(numberSymbol); ok {
+		s += n
+	}
+	return s + p.fraction(), true
+}
+
+// fraction: `(DOT NUMBER)?`
+func (p *descent) fraction() string {
+	var mark = p.pos
+	if d, ok := p.match(dotSymbol); ok {
+		if n, ok := p.match(numberSymbol); ok {
+			return d + n
+		}
+	}
+	p.pos = mark
+	return ""
+}
+
+func (p *descent) singleTerm() *tm.SingleTerm {
+	var begin, ok = p.matchToken(termBeginChars)
+	if !ok {
+		return nil
+	}
+	var t = &tm.SingleTerm{Begin: tk.NewChar(begin)}
+	for c, ok := p.matchToken(termChars); ok; c, ok = p.matchToken(termChars) {
+		t.Chars = append(t.Chars, tk.NewChar(c))
+	}
+	return t
+}
+
+func (p *descent) phraseTerm() *tm.PhraseTerm {
+	if chars, ok := p.quoted(); ok {
+		return &tm.PhraseTerm{Chars: chars}
+	}
+	return nil
+}
+
+// quoted: `QUOTE ( REVERSE QUOTE | !QUOTE )* QUOTE`
+func (p *descent) quoted() ([]string, bool) {
+	var mark = p.pos
+	if _, ok := p.match(quoteSymbol); ok {
+		var chars = p.chars(quoteSymbol)
+		if _, ok := p.match(quoteSymbol); ok {
+			return chars, true
+		}
+	}
+	p.pos = mark
+	return nil, false
+}
+
+func (p *descent) rangeTerm() *tm.RangeTerm {
+	var t = &tm.RangeTerm{SRangeTerm: p.sRangeTerm()}
+	if t.SRangeTerm == nil {
+		if t.DRangeTerm = p.dRangeTerm(); t.DRangeTerm == nil {
+			return nil
+		}
+	}
+	t.BoostSymbol, _ = p.boostSymbol()
+	return t
+}
+
+func (p *descent) sRangeTerm() *tm.SRangeTerm {
+	var mark = p.pos
+	if s, ok := p.match(compareSymbol); ok {
+		if v := p.rangeValue(); v != nil {
+			return &tm.SRangeTerm{Symbol: s, Value: v}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) dRangeTerm() *tm.DRangeTerm {
+	var mark = p.pos
+	if l, ok := p.matchSet(leftBrackets); ok {
+		p.skip(whitespaceSymbol)
+		if lv := p.rangeValue(); lv != nil && p.skip(whitespaceSymbol) > 0 {
+			if _, ok := p.word(p.config.keywords.To); ok && p.skip(whitespaceSymbol) > 0 {
+				if rv := p.rangeValue(); rv != nil {
+					p.skip(whitespaceSymbol)
+					if r, ok := p.matchSet(rightBrackets); ok {
+						return &tm.DRangeTerm{LBRACKET: l, LValue: lv, RValue: rv, RBRACKET: r}
+					}
+				}
+			}
+		}
+		p.miss()
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) rangeValue() *tm.RangeValue {
+	if s, ok := p.keyword("*"); ok {
+		return &tm.RangeValue{InfinityVal: s}
+	}
+	if chars, ok := p.quoted(); ok {
+		return &tm.RangeValue{PhraseValue: chars}
+	}
+	var values []string
+	for s, ok := p.matchSet(rangeValueChars); ok; s, ok = p.matchSet(rangeValueChars) {
+		values = append(values, s)
+	}
+	if len(values) != 0 {
+		return &tm.RangeValue{SingleValue: values}
+	}
+	return nil
+}
+
+func (p *descent) termGroup() *tm.TermGroup {
+	var mark = p.pos
+	if _, ok := p.match(lparenSymbol); ok && p.enter() {
+		defer p.leave()
+		p.skip(whitespaceSymbol)
+		if g := p.logicTermGroup(); g != nil {
+			p.skip(whitespaceSymbol)
+			if _, ok := p.match(rparenSymbol); ok {
+				var t = &tm.TermGroup{LogicTermGroup: g}
+				t.BoostSymbol, _ = p.boostSymbol()
+				return t
+			}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) logicTermGroup() *tm.LogicTermGroup {
+	var g = &tm.LogicTermGroup{OrTermGroup: p.orTermGroup()}
+	if g.OrTermGroup == nil {
+		return nil
+	}
+	for x := p.osTermGroup(); x != nil; x = p.osTermGroup() {
+		g.OSTermGroup = append(g.OSTermGroup, x)
+	}
+	return g
+}
+
+func (p *descent) orTermGroup() *tm.OrTermGroup {
+	var g = &tm.OrTermGroup{AndTermGroup: p.andTermGroup()}
+	if g.AndTermGroup == nil {
+		return nil
+	}
+	for x := p.anSTermGroup(); x != nil; x = p.anSTermGroup() {
+		g.AnSTermGroup = append(g.AnSTermGroup, x)
+	}
+	return g
+}
+
+func (p *descent) osTermGroup() *tm.OSTermGroup {
+	var mark = p.pos
+	if s := p.orSymbol(); s != nil {
+		if g := p.orTermGroup(); g != nil {
+			return &tm.OSTermGroup{OrSymbol: s, OrTermGroup: g}
+		}
+	} else if p.implicit(OR_OPERATOR) {
+		if g := p.orTermGroup(); g != nil && !p.bareKeyword(g.AndTermGroup) {
+			return &tm.OSTermGroup{OrSymbol: &op.OrSymbol{Symbol: "OR"}, OrTermGroup: g}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) andTermGroup() *tm.AndTermGroup {
+	p.step()
+	var mark = p.pos
+	var g = &tm.AndTermGroup{NotSymbol: p.notSymbol()}
+	if g.ParenTermGroup = p.parenTermGroup(); g.ParenTermGroup != nil {
+		return g
+	}
+	if g.FieldTermGroup = p.fieldTermGroup(); g.FieldTermGroup != nil {
+		return g
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) anSTermGroup() *tm.AnSTermGroup {
+	var mark = p.pos
+	var g = &tm.AnSTermGroup{AndSymbol: p.andSymbol()}
+	var implicit bool
+	if g.AndSymbol == nil {
+		if p.skip(whitespaceSymbol) > 0 {
+			g.NotSymbol = p.notSymbol()
+		}
+		if g.NotSymbol == nil && p.config.defaultOperator == AND_OPERATOR && p.pos > mark {
+			g.AndSymbol, implicit = &op.AndSymbol{Symbol: "AND"}, true
+		} else if g.NotSymbol == nil {
+			p.pos = mark
+			return nil
+		}
+	}
+	if g.AndTermGroup = p.andTermGroup(); g.AndTermGroup != nil && !(implicit && p.bareKeyword(g.AndTermGroup)) {
+		return g
+	}
+	p.pos = mark
+	return nil
+}
+
+// bareKeyword: check whether group is bare word of operator, i.e. `OR` of `x:(foo OR)`, it isn't term which is
+// joined by default operator
+func (p *descent) bareKeyword(g *tm.AndTermGroup) bool {
+	return g.NotSymbol == nil && g.FieldTermGroup != nil && p.isKeyword(g.FieldTermGroup.SingleTerm)
+}
+
+func (p *descent) parenTermGroup() *tm.ParenTermGroup {
+	var mark = p.pos
+	if _, ok := p.match(lparenSymbol); ok && p.enter() {
+		defer p.leave()
+		p.skip(whitespaceSymbol)
+		if g := p.logicTermGroup(); g != nil {
+			p.skip(whitespaceSymbol)
+			if _, ok := p.match(rparenSymbol); ok {
+				return &tm.ParenTermGroup{SubTermGroup: g}
+			}
+		}
+	}
+	p.pos = mark
+	return nil
+}
+
+func (p *descent) fieldTermGroup() *tm.FieldTermGroup {
+	if x := p.singleTerm(); x != nil {
+		return &tm.FieldTermGroup{SingleTerm: x}
+	} else if x := p.phraseTerm(); x != nil {
+		return &tm.FieldTermGroup{PhraseTerm: x}
+	} else if x := p.sRangeTerm(); x != nil {
+		return &tm.FieldTermGroup{SRangeTerm: x}
+	} else if x := p.dRangeTerm(); x != nil {
+		return &tm.FieldTermGroup{DRangeTerm: x}
+	}
+	return nil
+}
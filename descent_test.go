@@ -0,0 +1,114 @@
+package lucene_parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+)
+
+// testInputs: string literals of all tests of module, they are queries, terms, fields and so on
+func testInputs(t testing.TB) []string {
+	var res = []string{}
+	var seen = map[string]bool{}
+	var err = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if x, ok := n.(*ast.BasicLit); ok && x.Kind == token.STRING {
+				if s, err := strconv.Unquote(x.Value); err == nil && !seen[s] {
+					seen[s] = true
+					res = append(res, s)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func assertSameParse(t *testing.T, input string) {
+	want, wantErr := ParseLucene(input)
+	got, err := ParseLuceneWithEngine(input, DESCENT_ENGINE)
+	if !assert.Equal(t, want, got, "%q", input) || !assert.Equal(t, wantErr != nil, err != nil, "%q: %v, %v", input, wantErr, err) {
+		return
+	}
+	// position of error may be different, descent parser reports farthest token which doesn't match
+	if err != nil {
+		assert.Implements(t, (*participle.Error)(nil), err)
+	}
+}
+
+func TestDescentParser(t *testing.T) {
+	var inputs = testInputs(t)
+	assert.True(t, len(inputs) > 1000)
+	for _, input := range inputs {
+		assertSameParse(t, input)
+		// terms of tests of term package are parsed as term of field query
+		assertSameParse(t, "x:"+input)
+	}
+}
+
+func TestDescentParserPrefix(t *testing.T) {
+	for _, input := range testInputs(t) {
+		// incomplete queries, i.e. queries which are being typed
+		for i := range input {
+			assertSameParse(t, input[:i])
+		}
+	}
+}
+
+func TestParseLuceneWithEngine(t *testing.T) {
+	for _, engine := range []Engine{PARTICIPLE_ENGINE, DESCENT_ENGINE} {
+		q, err := ParseLuceneWithEngine(`x:1 AND y:2`, engine)
+		assert.Nil(t, err)
+		assert.Equal(t, `x:1 AND y:2`, q.String())
+
+		q, err = ParseLuceneWithEngine(`x:1 = 2`, engine)
+		assert.Nil(t, q)
+		assert.NotNil(t, err)
+	}
+	q, err := ParseLuceneWithEngine(`x:1`, Engine(100))
+	assert.Nil(t, q)
+	assert.NotNil(t, err)
+}
+
+var benchmarkQueries = []string{
+	`x:1`,
+	`status:active AND age:[18 TO 65} AND NOT name:"john \"doe\""~2`,
+	`(title OR body):(quick AND brown OR fox*)^2 AND date:>=2020-01-01 || url:/https?:\/\/.*/ AND !tag:(a OR b OR c)`,
+}
+
+func BenchmarkParseLucene(b *testing.B) {
+	for _, engine := range []struct {
+		name   string
+		engine Engine
+	}{{"participle", PARTICIPLE_ENGINE}, {"descent", DESCENT_ENGINE}} {
+		for i, query := range benchmarkQueries {
+			b.Run(engine.name+"_"+strconv.Itoa(i), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					if _, err := ParseLuceneWithEngine(query, engine.engine); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
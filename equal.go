@@ -0,0 +1,104 @@
+package lucene_parser
+
+import (
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Equal: check whether two ast nodes are structurally equal. a and b must be pointers to the same type of node,
+// nodes of term package are compared by term.Equal. different spellings of bool operator (i.e. `AND` / `and` / `&&`) are regarded as equal.
+func Equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *Lucene:
+		y, ok := b.(*Lucene)
+		return ok && x.Equal(y)
+	case *OrQuery:
+		y, ok := b.(*OrQuery)
+		return ok && x.Equal(y)
+	case *OSQuery:
+		y, ok := b.(*OSQuery)
+		return ok && x.Equal(y)
+	case *AndQuery:
+		y, ok := b.(*AndQuery)
+		return ok && x.Equal(y)
+	case *AnSQuery:
+		y, ok := b.(*AnSQuery)
+		return ok && x.Equal(y)
+	case *ParenQuery:
+		y, ok := b.(*ParenQuery)
+		return ok && x.Equal(y)
+	case *FieldQuery:
+		y, ok := b.(*FieldQuery)
+		return ok && x.Equal(y)
+	default:
+		return term.Equal(a, b)
+	}
+}
+
+func (q *Lucene) Equal(other *Lucene) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	if !q.OrQuery.Equal(other.OrQuery) || len(q.OSQuery) != len(other.OSQuery) {
+		return false
+	}
+	for i := range q.OSQuery {
+		if !q.OSQuery[i].Equal(other.OSQuery[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *OrQuery) Equal(other *OrQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	if !q.AndQuery.Equal(other.AndQuery) || len(q.AnSQuery) != len(other.AnSQuery) {
+		return false
+	}
+	for i := range q.AnSQuery {
+		if !q.AnSQuery[i].Equal(other.AnSQuery[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *OSQuery) Equal(other *OSQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.OrSymbol.Equal(other.OrSymbol) && q.OrQuery.Equal(other.OrQuery)
+}
+
+func (q *AndQuery) Equal(other *AndQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.NotSymbol.Equal(other.NotSymbol) &&
+		q.ParenQuery.Equal(other.ParenQuery) &&
+		q.FieldQuery.Equal(other.FieldQuery)
+}
+
+func (q *AnSQuery) Equal(other *AnSQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.AndSymbol.Equal(other.AndSymbol) &&
+		q.NotSymbol.Equal(other.NotSymbol) &&
+		q.AndQuery.Equal(other.AndQuery)
+}
+
+func (q *ParenQuery) Equal(other *ParenQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.SubQuery.Equal(other.SubQuery)
+}
+
+func (q *FieldQuery) Equal(other *FieldQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.Field.Equal(other.Field) && q.Term.Equal(other.Term)
+}
@@ -0,0 +1,72 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestEqual(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		other string
+		want  bool
+	}
+	var testCases = []testCase{
+		{
+			name:  "test_same_query",
+			input: `x:1 AND y:(foo OR "bar") OR z:[1 TO 2]^2`,
+			other: `x:1 AND y:(foo OR "bar") OR z:[1 TO 2]^2`,
+			want:  true,
+		},
+		{
+			name:  "test_operator_spelling",
+			input: `x:1 AND y:2 OR !z:3`,
+			other: `x:1 && y:2 || NOT z:3`,
+			want:  true,
+		},
+		{
+			name:  "test_operand_order",
+			input: `x:1 AND y:2`,
+			other: `y:2 AND x:1`,
+			want:  false,
+		},
+		{
+			name:  "test_different_boost",
+			input: `x:1^2`,
+			other: `x:1^3`,
+			want:  false,
+		},
+		{
+			name:  "test_different_range",
+			input: `x:>1`,
+			other: `x:>=1`,
+			want:  false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			q1, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			q2, err := ParseLucene(tt.other)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, Equal(q1, q2))
+			assert.Equal(t, tt.want, q1.Equal(q2))
+		})
+	}
+
+	t.Run("test_nil", func(t *testing.T) {
+		var q *Lucene
+		assert.True(t, q.Equal(nil))
+		assert.False(t, q.Equal(&Lucene{}))
+		assert.True(t, Equal(q, q))
+	})
+
+	t.Run("test_different_type", func(t *testing.T) {
+		assert.False(t, Equal(&OrQuery{}, &AndQuery{}))
+		assert.True(t, Equal(&term.Field{Value: []string{"x"}}, &term.Field{Value: []string{"x"}}))
+		assert.False(t, Equal(&term.Field{Value: []string{"x"}}, &FieldQuery{}))
+	})
+}
@@ -0,0 +1,114 @@
+package lucene_parser
+
+import (
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// ExistsQuery: query of checking whether field exists, it's written as `_exists_:x` or `x:*`,
+// and `_missing_:x` is exists query with Missing (i.e. `NOT _exists_:x`).
+type ExistsQuery struct {
+	Field   *term.Field
+	Missing bool
+}
+
+func (q *ExistsQuery) GetQueryType() QueryType {
+	return EXISTS_QUERY
+}
+
+func (q *ExistsQuery) String() string {
+	if q == nil || q.Field == nil {
+		return ""
+	} else if q.Missing {
+		return term.MissingField + ":" + q.Field.String()
+	} else {
+		return term.ExistsField + ":" + q.Field.String()
+	}
+}
+
+// Exists: recognize exists query from field query, it returns nil if field query isn't exists query.
+// term of `_exists_` / `_missing_` must be name of field which is written as single term, phrase term
+// or group of one term (i.e. `_exists_:user.email`, `_exists_:"user.email"` or `_exists_:(user.email)`).
+func (q *FieldQuery) Exists() *ExistsQuery {
+	if q == nil || q.Field == nil || q.Term == nil {
+		return nil
+	} else if q.Field.IsExists() || q.Field.IsMissing() {
+		if f, _ := existsTarget(q.Term); f != nil {
+			return &ExistsQuery{Field: f, Missing: q.Field.IsMissing()}
+		}
+		return nil
+	} else if q.Term.IsExists() {
+		return &ExistsQuery{Field: q.Field.Clone()}
+	}
+	return nil
+}
+
+// existsTarget: field which is checked by term of `_exists_` / `_missing_` and byte offset of it in term.String()
+func existsTarget(t *term.Term) (*term.Field, int) {
+	if t.FuzzyTerm != nil && len(t.FuzzyTerm.FuzzySymbol) == 0 {
+		return targetField(t.FuzzyTerm.SingleTerm, t.FuzzyTerm.PhraseTerm)
+	} else if t.TermGroup != nil {
+		if f, offset := groupTarget(t.TermGroup.LogicTermGroup); f != nil {
+			return f, offset + len("( ")
+		}
+	}
+	return nil, 0
+}
+
+// groupTarget: target of group which has only one term without NOT, i.e. `(x)` or `((x))`
+func groupTarget(g *term.LogicTermGroup) (*term.Field, int) {
+	if g == nil || g.OrTermGroup == nil || len(g.OSTermGroup) != 0 || len(g.OrTermGroup.AnSTermGroup) != 0 {
+		return nil, 0
+	}
+	var x = g.OrTermGroup.AndTermGroup
+	if x == nil || x.NotSymbol != nil {
+		return nil, 0
+	} else if x.ParenTermGroup != nil {
+		if f, offset := groupTarget(x.ParenTermGroup.SubTermGroup); f != nil {
+			return f, offset + len("( ")
+		}
+	} else if x.FieldTermGroup != nil {
+		return targetField(x.FieldTermGroup.SingleTerm, x.FieldTermGroup.PhraseTerm)
+	}
+	return nil, 0
+}
+
+// targetField: single term is name of field as it's written in query, phrase term is unescaped name of field
+func targetField(s *term.SingleTerm, p *term.PhraseTerm) (*term.Field, int) {
+	if s != nil && !s.IsExists() {
+		var f = &term.Field{Value: []string{s.Begin.Value}}
+		for _, c := range s.Chars {
+			f.Value = append(f.Value, c.Value)
+		}
+		return f, 0
+	} else if p != nil && len(p.Chars) != 0 {
+		return term.NewField(term.Unescape(strings.Join(p.Chars, ""))), len(`"`)
+	}
+	return nil, 0
+}
+
+// queryField: return field which is queried by field query, it's value of term for `_exists_:x` / `_missing_:x`
+func queryField(q *FieldQuery) *term.Field {
+	if q.Field.IsExists() || q.Field.IsMissing() {
+		if ex := q.Exists(); ex != nil {
+			return ex.Field
+		}
+	}
+	return q.Field
+}
+
+// withField: copy field query and replace field which is queried by field query with name of field,
+// term of exists query is replaced with single term of name and boost of term is kept.
+func withField(q *FieldQuery, name string) *FieldQuery {
+	if queryField(q) != q.Field {
+		var t = &term.FuzzyTerm{SingleTerm: term.NewSingleTerm(term.NewField(name).String())}
+		if q.Term.FuzzyTerm != nil {
+			t.BoostSymbol = q.Term.FuzzyTerm.BoostSymbol
+		} else if q.Term.TermGroup != nil {
+			t.BoostSymbol = q.Term.TermGroup.BoostSymbol
+		}
+		return &FieldQuery{Field: q.Field.Clone(), Term: &term.Term{FuzzyTerm: t}}
+	}
+	return &FieldQuery{Field: term.NewField(name), Term: q.Term.Clone()}
+}
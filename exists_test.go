@@ -0,0 +1,98 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExists(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  string
+	}
+	for _, tt := range []testCase{
+		{name: "test_exists", input: `_exists_:user.email`, want: `_exists_:user.email`},
+		{name: "test_missing", input: `_missing_:a\:b`, want: `_missing_:a\:b`},
+		{name: "test_star", input: `user.email:*`, want: `_exists_:user.email`},
+		{name: "test_star_boost", input: `user.email:*^2`, want: `_exists_:user.email`},
+		{name: "test_wildcard", input: `user.email:foo*`, want: ``},
+		{name: "test_exists_star", input: `_exists_:*`, want: ``},
+		{name: "test_exists_fuzzy", input: `_exists_:x~`, want: ``},
+		{name: "test_exists_phrase", input: `_exists_:"a.b"`, want: `_exists_:a.b`},
+		{name: "test_exists_phrase_escape", input: `_missing_:"a:b"`, want: `_missing_:a\:b`},
+		{name: "test_exists_empty_phrase", input: `_exists_:""`, want: ``},
+		{name: "test_exists_group", input: `_exists_:(a.b)`, want: `_exists_:a.b`},
+		{name: "test_exists_paren_group", input: `_exists_:(("a.b"))^2`, want: `_exists_:a.b`},
+		{name: "test_exists_group_or", input: `_exists_:(a OR b)`, want: ``},
+		{name: "test_exists_group_not", input: `_exists_:(NOT a)`, want: ``},
+		{name: "test_field", input: `x:1`, want: ``},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var ex = q.OrQuery.AndQuery.FieldQuery.Exists()
+			assert.Equal(t, tt.want, ex.String())
+			if ex != nil {
+				assert.Equal(t, EXISTS_QUERY, ex.GetQueryType())
+			}
+		})
+	}
+	var q *FieldQuery
+	assert.Nil(t, q.Exists())
+}
+
+func TestExistsField(t *testing.T) {
+	q, err := ParseLucene(`_exists_:internal.id OR NOT _missing_:x OR y:*`)
+	assert.Nil(t, err)
+	var refs = Fields(q)
+	assert.Equal(t, []FieldRef{
+		{Field: "internal.id", Offset: 9},
+		{Field: "x", Offset: 38, UnderNot: true},
+		{Field: "y", Offset: 43},
+	}, refs)
+	var s = q.String()
+	for _, x := range refs {
+		assert.Equal(t, x.Field, s[x.Offset:x.Offset+len(x.Field)])
+	}
+
+	// existence of field can't be leaked
+	res, _, err := (&Policy{Deny: []string{"internal.*"}, Mode: STRIP_POLICY_MODE}).Apply(q)
+	assert.Nil(t, err)
+	assert.Equal(t, `NOT _missing_:x OR y:*`, res.String())
+
+	res = ResolveAlias(q, AliasMap(map[string][]string{
+		"internal.id": {"id"},
+		"x":           {"a", "b"},
+		"y":           {"c", "d"},
+	}))
+	assert.Equal(t, `_exists_:id OR NOT ( _missing_:a AND _missing_:b ) OR ( c:* OR d:* )`, res.String())
+
+	q, err = ParseLucene(`_exists_:"internal.id" OR _missing_:(( x ))^2`)
+	assert.Nil(t, err)
+	refs = Fields(q)
+	assert.Equal(t, []FieldRef{{Field: "internal.id", Offset: 10}, {Field: "x", Offset: 40}}, refs)
+	s = q.String()
+	for _, x := range refs {
+		assert.Equal(t, x.Field, s[x.Offset:x.Offset+len(x.Field)])
+	}
+	res = ResolveAlias(q, AliasMap(map[string][]string{"x": {"a", "b"}}))
+	assert.Equal(t, `_exists_:"internal.id" OR ( _missing_:a^2 AND _missing_:b^2 )`, res.String())
+
+	q, err = ParseLucene(`_exists_:http.*`)
+	assert.Nil(t, err)
+	res = ExpandFields(q, []string{"http.request", "http.response"})
+	assert.Equal(t, `( _exists_:http.request OR _exists_:http.response )`, res.String())
+}
+
+func TestExistsFingerprint(t *testing.T) {
+	var parse = func(s string) *Lucene {
+		q, err := ParseLucene(s)
+		assert.Nil(t, err)
+		return q
+	}
+	assert.Equal(t, Fingerprint(parse(`x:*`)), Fingerprint(parse(`_exists_:x`)))
+	assert.Equal(t, Fingerprint(parse(`_missing_:x`)), Fingerprint(parse(`NOT x:*`)))
+	assert.NotEqual(t, Fingerprint(parse(`x:*^2`)), Fingerprint(parse(`_exists_:x`)))
+}
@@ -0,0 +1,230 @@
+package lucene_parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Fingerprint: hash canonical form of lucene query to a stable key, queries which are only different in
+// order of operands of AND / OR, spelling of bool operator, redundant paren and default boost
+// (i.e. `x:1 AND y:2` and `y:2 && (x:1^1)`) have same fingerprint. literals are kept as they are written,
+// because `zip:01234` and `zip:1234` are different values of keyword field.
+func Fingerprint(q *Lucene) string {
+	return fingerprinter{}.fingerprint(q)
+}
+
+// NumericFingerprint: Fingerprint which also writes numeric literals in shortest form, i.e. `x:01`, `x:+1` and
+// `x:1.0` have same fingerprint as `x:1`. it's only correct if all fields of query are numeric.
+func NumericFingerprint(q *Lucene) string {
+	return fingerprinter{numeric: true}.fingerprint(q)
+}
+
+// fingerprinter: options of canonical form
+type fingerprinter struct {
+	numeric bool // write numeric literals in shortest form
+}
+
+func (fp fingerprinter) fingerprint(q *Lucene) string {
+	if q == nil || q.OrQuery == nil {
+		return ""
+	}
+	var sum = sha256.Sum256([]byte(fp.canonicalLucene(q).String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonical: canonical form of query, leaf is field query and others are bool operator
+type canonical struct {
+	op    string
+	items []*canonical
+	leaf  string
+}
+
+func (c *canonical) String() string {
+	if len(c.op) == 0 {
+		return strconv.Quote(c.leaf)
+	} else {
+		var sl = make([]string, 0, len(c.items))
+		for _, x := range c.items {
+			sl = append(sl, x.String())
+		}
+		return c.op + "(" + strings.Join(sl, ",") + ")"
+	}
+}
+
+// newCanonicalNary: flatten nested operands with same operator and sort operands, because AND / OR are commutative
+func newCanonicalNary(op string, items []*canonical) *canonical {
+	var res = &canonical{op: op}
+	for _, x := range items {
+		if x == nil {
+			continue
+		} else if x.op == op {
+			res.items = append(res.items, x.items...)
+		} else {
+			res.items = append(res.items, x)
+		}
+	}
+	if len(res.items) == 0 {
+		return nil
+	} else if len(res.items) == 1 {
+		return res.items[0]
+	}
+	sort.Slice(res.items, func(i, j int) bool {
+		return res.items[i].String() < res.items[j].String()
+	})
+	return res
+}
+
+func newCanonicalNot(c *canonical) *canonical {
+	if c == nil {
+		return nil
+	} else if c.op == "NOT" {
+		return c.items[0]
+	} else {
+		return &canonical{op: "NOT", items: []*canonical{c}}
+	}
+}
+
+func (fp fingerprinter) canonicalLucene(q *Lucene) *canonical {
+	if q == nil {
+		return nil
+	}
+	var items = []*canonical{fp.canonicalOrQuery(q.OrQuery)}
+	for _, x := range q.OSQuery {
+		if x != nil {
+			items = append(items, fp.canonicalOrQuery(x.OrQuery))
+		}
+	}
+	return newCanonicalNary("OR", items)
+}
+
+func (fp fingerprinter) canonicalOrQuery(q *OrQuery) *canonical {
+	if q == nil {
+		return nil
+	}
+	var items = []*canonical{fp.canonicalAndQuery(q.AndQuery)}
+	for _, x := range q.AnSQuery {
+		if x == nil {
+			continue
+		} else if x.AndSymbol == nil && x.NotSymbol != nil {
+			items = append(items, newCanonicalNot(fp.canonicalAndQuery(x.AndQuery)))
+		} else {
+			items = append(items, fp.canonicalAndQuery(x.AndQuery))
+		}
+	}
+	return newCanonicalNary("AND", items)
+}
+
+func (fp fingerprinter) canonicalAndQuery(q *AndQuery) *canonical {
+	if q == nil {
+		return nil
+	}
+	var res *canonical
+	if q.ParenQuery != nil {
+		res = fp.canonicalLucene(q.ParenQuery.SubQuery)
+	} else if q.FieldQuery != nil {
+		res = fp.canonicalFieldQuery(q.FieldQuery)
+	}
+	if q.NotSymbol != nil {
+		res = newCanonicalNot(res)
+	}
+	return res
+}
+
+func (fp fingerprinter) canonicalFieldQuery(q *FieldQuery) *canonical {
+	if q == nil || q.Field == nil || q.Term == nil {
+		return nil
+	} else if q.Term.TermGroup != nil {
+		return fp.canonicalLucene(TermGroupToLucene(q.Field, q.Term.TermGroup))
+	} else if ex := q.Exists(); ex != nil && q.Term.Boost() == term.DefaultBoost {
+		// `x:*` is same as `_exists_:x`, and `_missing_:x` is same as `NOT _exists_:x`
+		var res = &canonical{leaf: (&ExistsQuery{Field: ex.Field}).String()}
+		if ex.Missing {
+			res = newCanonicalNot(res)
+		}
+		return res
+	} else {
+		return &canonical{leaf: q.Field.String() + ":" + fp.canonicalTerm(q.Term)}
+	}
+}
+
+func (fp fingerprinter) canonicalTerm(t *term.Term) string {
+	var res string
+	if t.FuzzyTerm != nil {
+		if t.FuzzyTerm.SingleTerm != nil {
+			res = fp.canonicalLiteral(t.FuzzyTerm.SingleTerm.String())
+		} else {
+			res = t.FuzzyTerm.PhraseTerm.String()
+		}
+		if fuzziness := t.Fuzziness(); fuzziness == term.AutoFuzzy {
+			// `~`, `~AUTO` and `~AUTO:3,6` are same
+			if c, ok := t.FuzzyTerm.AutoConfig(); ok && c != term.DefaultAutoConfig {
+				res += "~AUTO:" + strconv.Itoa(c.Low) + "," + strconv.Itoa(c.High)
+			} else {
+				res += "~"
+			}
+		} else if fuzziness != term.NoFuzzy {
+			res += "~" + strconv.FormatFloat(fuzziness.Float(), 'f', -1, 64)
+		}
+	} else if t.RegexpTerm != nil {
+		res = t.RegexpTerm.String()
+	} else if bound := t.GetBound(); bound != nil {
+		var l, r = "{", "}"
+		if bound.LeftInclude {
+			l = "["
+		}
+		if bound.RightInclude {
+			r = "]"
+		}
+		res = l + fp.canonicalRangeValue(bound.LeftValue) + " TO " + fp.canonicalRangeValue(bound.RightValue) + r
+	}
+	if boost := t.Boost(); boost != term.DefaultBoost {
+		res += "^" + strconv.FormatFloat(boost.Float(), 'f', -1, 64)
+	}
+	return res
+}
+
+func (fp fingerprinter) canonicalRangeValue(v *term.RangeValue) string {
+	if v == nil {
+		return ""
+	} else if len(v.SingleValue) != 0 {
+		return fp.canonicalLiteral(v.String())
+	} else {
+		return v.String()
+	}
+}
+
+var numericLiteral = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// canonicalLiteral: numeric literal is written in shortest form if numeric is set,
+// for instance "01" / "+1" / "1.0" are written as "1"
+func (fp fingerprinter) canonicalLiteral(s string) string {
+	if !fp.numeric || !numericLiteral.MatchString(s) {
+		return s
+	}
+	var sign = ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	} else if s[0] == '+' {
+		s = s[1:]
+	}
+	var integer, decimal = s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, decimal = s[:i], strings.TrimRight(s[i+1:], "0")
+	}
+	if integer = strings.TrimLeft(integer, "0"); len(integer) == 0 {
+		integer = "0"
+	}
+	if integer == "0" && len(decimal) == 0 {
+		return "0"
+	} else if len(decimal) == 0 {
+		return sign + integer
+	} else {
+		return sign + integer + "." + decimal
+	}
+}
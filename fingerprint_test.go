@@ -0,0 +1,139 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		other   string
+		same    bool
+		numeric bool // use NumericFingerprint
+	}
+	var testCases = []testCase{
+		{
+			name:  "test_commutative_and",
+			input: `x:1 AND y:2`,
+			other: `y:2 && x:1`,
+			same:  true,
+		},
+		{
+			name:  "test_commutative_or",
+			input: `x:1 OR y:2 OR z:3`,
+			other: `z:3 || (y:2 or x:1)`,
+			same:  true,
+		},
+		{
+			name:  "test_not_symbol",
+			input: `x:1 NOT y:2`,
+			other: `!y:2 and x:1`,
+			same:  true,
+		},
+		{
+			name:  "test_auto_fuzzy",
+			input: `x:foo~ AND y:bar~AUTO`,
+			other: `x:foo~AUTO:3,6 AND y:bar~`,
+			same:  true,
+		},
+		{
+			name:  "test_auto_fuzzy_config",
+			input: `x:foo~AUTO:1,2`,
+			other: `x:foo~`,
+			same:  false,
+		},
+		{
+			name:  "test_double_not",
+			input: `NOT (NOT x:1)`,
+			other: `x:1`,
+			same:  true,
+		},
+		{
+			name:  "test_numeric_literal",
+			input: `x:01 AND y:[1.0 TO +2.50]`,
+			other: `x:1 AND y:[1 TO 2.5]`,
+			same:  false,
+		},
+		{
+			name:  "test_zip_code",
+			input: `zip:01234`,
+			other: `zip:1234`,
+			same:  false,
+		},
+		{
+			name:    "test_numeric_fingerprint",
+			input:   `x:01 AND y:[1.0 TO +2.50]`,
+			other:   `x:1 AND y:[1 TO 2.5]`,
+			same:    true,
+			numeric: true,
+		},
+		{
+			name:    "test_numeric_fingerprint_value",
+			input:   `x:1.5`,
+			other:   `x:15`,
+			same:    false,
+			numeric: true,
+		},
+		{
+			name:  "test_default_boost",
+			input: `x:1^1 AND y:2^`,
+			other: `x:1 AND y:2`,
+			same:  true,
+		},
+		{
+			name:  "test_side_range",
+			input: `x:>=1 AND y:<2`,
+			other: `x:[1 TO *] AND y:[* TO 2}`,
+			same:  true,
+		},
+		{
+			name:  "test_term_group",
+			input: `x:(1 OR 2)^2`,
+			other: `x:2^2 OR x:1^2.0`,
+			same:  true,
+		},
+		{
+			name:  "test_different_precedence",
+			input: `x:1 AND y:2 OR z:3`,
+			other: `x:1 AND (y:2 OR z:3)`,
+			same:  false,
+		},
+		{
+			name:  "test_different_boost",
+			input: `x:1^2`,
+			other: `x:1^3`,
+			same:  false,
+		},
+		{
+			name:  "test_different_fuzziness",
+			input: `x:foo~`,
+			other: `x:foo~1`,
+			same:  false,
+		},
+		{
+			name:  "test_different_value",
+			input: `x:1`,
+			other: `x:"1"`,
+			same:  false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			q1, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			q2, err := ParseLucene(tt.other)
+			assert.Nil(t, err)
+			var fingerprint = Fingerprint
+			if tt.numeric {
+				fingerprint = NumericFingerprint
+			}
+			assert.Len(t, fingerprint(q1), 64)
+			assert.Equal(t, tt.same, fingerprint(q1) == fingerprint(q2))
+		})
+	}
+	assert.Equal(t, "", Fingerprint(nil))
+	assert.Equal(t, "", NumericFingerprint(nil))
+}
@@ -0,0 +1,204 @@
+// Package fuzzy: Levenshtein automaton of fuzzy term (i.e. `name:jhon~1`), it's used to check whether candidate string
+// matches fuzzy term and to enumerate matched terms from sorted term dictionary.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Options: options of fuzzy matching like fuzzy query of ES
+type Options struct {
+	PrefixLength   int             // number of beginning runes which must be matched exactly
+	Transpositions bool            // regard transposition of two adjacent runes (i.e. `ab` => `ba`) as one edit (Damerau-Levenshtein)
+	Auto           term.AutoConfig // parameters of AUTO fuzziness, term.DefaultAutoConfig is used if it's zero
+}
+
+// Automaton: Levenshtein automaton which accepts strings whose edit distance to term isn't greater than max edits,
+// state of automaton is a row of dynamic programming matrix, so it's cheap to step one rune.
+type Automaton struct {
+	prefix         []rune
+	pattern        []rune
+	maxEdits       int
+	transpositions bool
+}
+
+// state: after consuming some runes, row[i] is edit distance between consumed runes (after prefix) and pattern[:i],
+// prev is row before consuming last rune, it's used for transposition.
+type state struct {
+	depth int
+	row   []int
+	prev  []int
+	last  rune
+	dead  bool
+}
+
+// New: make automaton of unescaped term with max edits
+func New(s string, maxEdits int, opts Options) *Automaton {
+	var runes = []rune(s)
+	var n = opts.PrefixLength
+	if n < 0 {
+		n = 0
+	} else if n > len(runes) {
+		n = len(runes)
+	}
+	if maxEdits < 0 {
+		maxEdits = 0
+	}
+	return &Automaton{
+		prefix:         runes[:n],
+		pattern:        runes[n:],
+		maxEdits:       maxEdits,
+		transpositions: opts.Transpositions,
+	}
+}
+
+// Compile: make automaton of single term of fuzzy term, max edits is resolved by term.ResolveFuzziness,
+// term without fuzzy modifier is matched exactly.
+func Compile(t *term.Term, opts Options) (*Automaton, error) {
+	if t == nil || t.FuzzyTerm == nil || t.FuzzyTerm.SingleTerm == nil {
+		return nil, term.ErrNotSingleTerm
+	}
+	var auto = opts.Auto
+	if auto == (term.AutoConfig{}) {
+		auto = term.DefaultAutoConfig
+	}
+	var maxEdits, err = term.ResolveFuzziness(t, auto)
+	if err != nil {
+		return nil, err
+	}
+	return New(term.Unescape(t.FuzzyTerm.SingleTerm.String()), int(maxEdits), opts), nil
+}
+
+// MaxEdits: return maximum edit distance of automaton
+func (a *Automaton) MaxEdits() int {
+	return a.maxEdits
+}
+
+// Match: check whether edit distance between s and term isn't greater than max edits
+func (a *Automaton) Match(s string) bool {
+	var _, ok = a.Distance(s)
+	return ok
+}
+
+// Distance: return edit distance between s and term, it returns false if distance is greater than max edits
+func (a *Automaton) Distance(s string) (int, bool) {
+	var st = a.start()
+	for _, c := range s {
+		if st = a.step(st, c); !a.canMatch(st) {
+			return 0, false
+		}
+	}
+	return st.row[len(a.pattern)], a.isMatch(st)
+}
+
+// Enumerate: return terms of dictionary (must be sorted in ascending order) which are matched by automaton.
+// states of common prefix of adjacent terms are reused, and terms which have prefix which can't be matched are skipped.
+func (a *Automaton) Enumerate(dict []string) []string {
+	var res = []string{}
+	var stack = []*state{a.start()}
+	var path = []rune{}
+	for i := 0; i < len(dict); {
+		var runes = []rune(dict[i])
+		var l = commonPrefix(path, runes)
+		stack, path = stack[:l+1], path[:l]
+		var dead = false
+		for _, c := range runes[l:] {
+			var st = a.step(stack[len(stack)-1], c)
+			stack, path = append(stack, st), append(path, c)
+			if !a.canMatch(st) {
+				dead = true
+				break
+			}
+		}
+		if dead {
+			// terms which have same prefix are adjacent in sorted dictionary
+			var prefix = string(path)
+			i += sort.Search(len(dict)-i, func(j int) bool {
+				return !strings.HasPrefix(dict[i+j], prefix)
+			})
+			continue
+		}
+		if a.isMatch(stack[len(stack)-1]) {
+			res = append(res, dict[i])
+		}
+		i++
+	}
+	return res
+}
+
+func commonPrefix(a, b []rune) int {
+	var i = 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func (a *Automaton) start() *state {
+	var row = make([]int, len(a.pattern)+1)
+	for i := range row {
+		row[i] = a.limit(i)
+	}
+	return &state{row: row}
+}
+
+// limit: distance which is greater than max edits is regarded as max edits + 1, so that row is bounded
+func (a *Automaton) limit(d int) int {
+	if d > a.maxEdits+1 {
+		return a.maxEdits + 1
+	}
+	return d
+}
+
+func (a *Automaton) step(st *state, c rune) *state {
+	if st.dead {
+		return st
+	} else if st.depth < len(a.prefix) {
+		if c != a.prefix[st.depth] {
+			return &state{depth: st.depth + 1, dead: true}
+		}
+		return &state{depth: st.depth + 1, row: st.row}
+	}
+	var row = make([]int, len(st.row))
+	row[0] = a.limit(st.row[0] + 1)
+	for i := 1; i < len(row); i++ {
+		var cost = 1
+		if a.pattern[i-1] == c {
+			cost = 0
+		}
+		var d = st.row[i-1] + cost
+		if x := st.row[i] + 1; x < d {
+			d = x
+		}
+		if x := row[i-1] + 1; x < d {
+			d = x
+		}
+		if a.transpositions && st.prev != nil && i > 1 && a.pattern[i-1] == st.last && a.pattern[i-2] == c {
+			if x := st.prev[i-2] + 1; x < d {
+				d = x
+			}
+		}
+		row[i] = a.limit(d)
+	}
+	return &state{depth: st.depth + 1, row: row, prev: st.row, last: c}
+}
+
+// canMatch: check whether there are strings which have consumed runes as prefix and are matched
+func (a *Automaton) canMatch(st *state) bool {
+	if st.dead {
+		return false
+	}
+	for _, d := range st.row {
+		if d <= a.maxEdits {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Automaton) isMatch(st *state) bool {
+	return !st.dead && st.depth >= len(a.prefix) && st.row[len(a.pattern)] <= a.maxEdits
+}
@@ -0,0 +1,167 @@
+package fuzzy
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+// distance: edit distance computed by full matrix, transposition is optimal string alignment
+func distance(a, b []rune, transpositions bool) int {
+	var d = make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func min(x int, y ...int) int {
+	for _, v := range y {
+		if v < x {
+			x = v
+		}
+	}
+	return x
+}
+
+func randString(r *rand.Rand, n int) string {
+	var alphabet = []rune("abc中")
+	var res = make([]rune, r.Intn(n+1))
+	for i := range res {
+		res[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(res)
+}
+
+func TestAutomatonDistance(t *testing.T) {
+	var r = rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		var s, c = randString(r, 6), randString(r, 6)
+		var maxEdits = r.Intn(3)
+		var opts = Options{Transpositions: r.Intn(2) == 0, PrefixLength: r.Intn(3)}
+		var a = New(s, maxEdits, opts)
+
+		var n = min(opts.PrefixLength, len([]rune(s)))
+		var sr, cr = []rune(s), []rune(c)
+		var want = -1
+		if len(cr) >= n && string(cr[:n]) == string(sr[:n]) {
+			if d := distance(sr[n:], cr[n:], opts.Transpositions); d <= maxEdits {
+				want = d
+			}
+		}
+		d, ok := a.Distance(c)
+		assert.Equal(t, want >= 0, ok, "%q %q %+v %d", s, c, opts, maxEdits)
+		if ok {
+			assert.Equal(t, want, d, "%q %q %+v %d", s, c, opts, maxEdits)
+		}
+	}
+}
+
+func TestAutomatonMatch(t *testing.T) {
+	type testCase struct {
+		name     string
+		term     string
+		maxEdits int
+		opts     Options
+		input    string
+		want     bool
+	}
+	for _, tt := range []testCase{
+		{name: "test_exact", term: "john", maxEdits: 0, input: "john", want: true},
+		{name: "test_substitute", term: "john", maxEdits: 1, input: "jahn", want: true},
+		{name: "test_insert", term: "john", maxEdits: 1, input: "johns", want: true},
+		{name: "test_delete", term: "john", maxEdits: 1, input: "jon", want: true},
+		{name: "test_transposition", term: "john", maxEdits: 1, input: "jhon", want: false},
+		{name: "test_damerau_transposition", term: "john", maxEdits: 1, opts: Options{Transpositions: true}, input: "jhon", want: true},
+		{name: "test_too_far", term: "john", maxEdits: 1, input: "joan1", want: false},
+		{name: "test_prefix", term: "john", maxEdits: 1, opts: Options{PrefixLength: 1}, input: "bohn", want: false},
+		{name: "test_prefix_match", term: "john", maxEdits: 1, opts: Options{PrefixLength: 2}, input: "joan", want: true},
+		{name: "test_rune", term: "中文", maxEdits: 1, input: "中午", want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, New(tt.term, tt.maxEdits, tt.opts).Match(tt.input))
+		})
+	}
+}
+
+func TestAutomatonEnumerate(t *testing.T) {
+	var r = rand.New(rand.NewSource(2))
+	var dict = []string{}
+	for i := 0; i < 3000; i++ {
+		dict = append(dict, randString(r, 7))
+	}
+	sort.Strings(dict)
+	for i := 0; i < 50; i++ {
+		var a = New(randString(r, 5), r.Intn(3), Options{Transpositions: r.Intn(2) == 0, PrefixLength: r.Intn(2)})
+		var want = []string{}
+		for _, x := range dict {
+			if a.Match(x) {
+				want = append(want, x)
+			}
+		}
+		assert.Equal(t, want, a.Enumerate(dict))
+	}
+	assert.Equal(t, []string{"jhon", "john", "jon"}, New("john", 1, Options{Transpositions: true}).Enumerate(
+		[]string{"jane", "jhon", "joan1", "john", "jon", "mary"},
+	))
+}
+
+func TestCompile(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&term.Term{},
+		participle.Lexer(token.Lexer),
+	)
+	type testCase struct {
+		name     string
+		input    string
+		maxEdits int
+		err      error
+	}
+	for _, tt := range []testCase{
+		{name: "test_no_fuzzy", input: `john`, maxEdits: 0},
+		{name: "test_fuzzy", input: `jhon~1`, maxEdits: 1},
+		{name: "test_auto", input: `jhon~`, maxEdits: 1},
+		{name: "test_auto_config", input: `jhon~AUTO:1,2`, maxEdits: 2},
+		{name: "test_phrase", input: `"jhon"~1`, err: term.ErrNotSingleTerm},
+		{name: "test_invalid", input: `jhon~3`, err: term.ErrInvalidEditDistance},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &term.Term{}
+			assert.Nil(t, termParser.ParseString(tt.input, out))
+			a, err := Compile(out, Options{})
+			assert.True(t, errors.Is(err, tt.err), "%v", err)
+			if err == nil {
+				assert.Equal(t, tt.maxEdits, a.MaxEdits())
+			}
+		})
+	}
+
+	var out = &term.Term{}
+	assert.Nil(t, termParser.ParseString(`j\*hn~`, out))
+	a, err := Compile(out, Options{Auto: term.AutoConfig{Low: 1, High: 10}})
+	assert.Nil(t, err)
+	assert.True(t, a.Match("j*hm"))
+	assert.False(t, a.Match("jahm"))
+}
@@ -0,0 +1,242 @@
+// Package highlight: classify ranges of lucene query for syntax highlighting, and render highlighted query for terminals
+// (ANSI escape codes) and HTML. spans are derived from rules of `token` lexer plus parser context, i.e. `AND` in phrase
+// isn't operator and text from the token which can't be parsed is error.
+package highlight
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+type SpanKind uint32
+
+const (
+	FIELD_SPAN         SpanKind = iota // field name, i.e. `status` of `status:active`
+	OPERATOR_SPAN                      // bool and prefix operators (`AND`, `||`, `!`, `+`, `-`), `TO` and compare of single range (`>=`)
+	TERM_SPAN                          // single term (with wildcards) and range value which isn't phrase
+	PHRASE_SPAN                        // phrase with quotes, i.e. `"foo bar"`
+	REGEXP_SPAN                        // regexp with slashes, i.e. `/fo+/`
+	RANGE_BRACKET_SPAN                 // `[`, `]`, `{` and `}` of range
+	MODIFIER_SPAN                      // boost and fuzzy modifier with value, i.e. `^2` and `~AUTO`
+	ESCAPE_SPAN                        // escaped chars out of phrase and regexp, i.e. `\:`
+	ERROR_SPAN                         // from the token which can't be parsed to the end of query
+)
+
+var spanKindNames = map[SpanKind]string{
+	FIELD_SPAN:         "field",
+	OPERATOR_SPAN:      "operator",
+	TERM_SPAN:          "term",
+	PHRASE_SPAN:        "phrase",
+	REGEXP_SPAN:        "regexp",
+	RANGE_BRACKET_SPAN: "range-bracket",
+	MODIFIER_SPAN:      "modifier",
+	ESCAPE_SPAN:        "escape",
+	ERROR_SPAN:         "error",
+}
+
+func (k SpanKind) String() string {
+	if name, ok := spanKindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Span: classified range query[Start:End], whitespaces, parentheses and colons aren't covered by spans
+type Span struct {
+	Kind  SpanKind
+	Start int
+	End   int
+}
+
+var symbols = lexer.SymbolsByRune(token.Lexer)
+
+// Highlight: classify ranges of query, spans are ordered and don't overlap
+func Highlight(query string) []Span {
+	var h = &highlighter{query: query}
+	if lex, err := token.Lexer.Lex(strings.NewReader(query)); err == nil {
+		for {
+			var t, err = lex.Next()
+			if err != nil || t.EOF() {
+				break
+			}
+			h.tokens = append(h.tokens, t)
+		}
+	}
+	h.scan()
+	return h.fail(errorOffset(query))
+}
+
+// errorOffset: offset of token which can't be parsed, it's -1 if query can be parsed or error is at the end of query
+func errorOffset(query string) (offset int) {
+	defer func() {
+		if r := recover(); r != nil {
+			offset = -1
+		}
+	}()
+	var err = lucene_parser.LuceneParser.ParseString(query, &lucene_parser.Lucene{})
+	if x, ok := err.(participle.Error); ok && x.Token().Pos.Offset < len(query) {
+		return x.Token().Pos.Offset
+	}
+	return -1
+}
+
+type highlighter struct {
+	query  string
+	tokens []lexer.Token
+	spans  []Span
+	depth  int // depth of range brackets
+}
+
+func (h *highlighter) name(i int) string {
+	if i < 0 || i >= len(h.tokens) {
+		return ""
+	}
+	return symbols[h.tokens[i].Type]
+}
+
+func (h *highlighter) start(i int) int {
+	return h.tokens[i].Pos.Offset
+}
+
+func (h *highlighter) end(i int) int {
+	return h.tokens[i].Pos.Offset + len(h.tokens[i].Value)
+}
+
+// emit: add span, it's merged with previous adjacent span of same kind
+func (h *highlighter) emit(kind SpanKind, start, end int) {
+	if n := len(h.spans); n != 0 && h.spans[n-1].Kind == kind && h.spans[n-1].End == start && kind != OPERATOR_SPAN {
+		h.spans[n-1].End = end
+		return
+	}
+	h.spans = append(h.spans, Span{Kind: kind, Start: start, End: end})
+}
+
+// boundary: token i begins a clause, that is, it's the first token or it follows whitespace or opening paren
+func (h *highlighter) boundary(i int) bool {
+	switch h.name(i - 1) {
+	case "", "WHITESPACE", "EOL", "LPAREN":
+		return true
+	}
+	return false
+}
+
+// termChar: token i can be part of field or single term
+func (h *highlighter) termChar(i int) bool {
+	switch h.name(i) {
+	case "IDENT", "ESCAPE", "DOT", "NUMBER", "MINUS", "PLUS", "WILDCARD", "SOR", "SLASH":
+		return true
+	}
+	return false
+}
+
+// closing: index of token which closes phrase or regexp, escaped quote and slash are skipped
+func (h *highlighter) closing(i int) int {
+	for j := i + 1; j < len(h.tokens); j++ {
+		if h.name(j) == h.name(i) && h.name(j-1) != "REVERSE" {
+			return j
+		}
+	}
+	return len(h.tokens) - 1
+}
+
+func (h *highlighter) scan() {
+	for i := 0; i < len(h.tokens); i++ {
+		var name = h.name(i)
+		switch {
+		case name == "QUOTE":
+			var j = h.closing(i)
+			h.emit(PHRASE_SPAN, h.start(i), h.end(j))
+			i = j
+		case name == "SLASH" && (h.boundary(i) || h.name(i-1) == "COLON"):
+			var j = h.closing(i)
+			h.emit(REGEXP_SPAN, h.start(i), h.end(j))
+			i = j
+		case name == "LBRACK" || name == "LBRACE" || name == "RBRACK" || name == "RBRACE":
+			if name == "LBRACK" || name == "LBRACE" {
+				h.depth++
+			} else if h.depth > 0 {
+				h.depth--
+			}
+			h.emit(RANGE_BRACKET_SPAN, h.start(i), h.end(i))
+		case name == "FUZZY" || name == "BOOST":
+			i = h.modifier(i)
+		case name == "COMPARE":
+			h.emit(OPERATOR_SPAN, h.start(i), h.end(i))
+		case name == "NOT":
+			h.emit(OPERATOR_SPAN, h.start(i), h.end(i))
+		case (name == "AND" || name == "SOR") && h.name(i+1) == name:
+			h.emit(OPERATOR_SPAN, h.start(i), h.end(i+1))
+			i++
+		case (name == "PLUS" || name == "MINUS") && h.boundary(i) && h.depth == 0 && h.termChar(i+1):
+			h.emit(OPERATOR_SPAN, h.start(i), h.end(i))
+		case name == "IDENT" && h.keyword(i):
+			h.emit(OPERATOR_SPAN, h.start(i), h.end(i))
+		case h.termChar(i):
+			i = h.term(i)
+		}
+	}
+}
+
+// keyword: IDENT token is bool operator or `TO` of range
+func (h *highlighter) keyword(i int) bool {
+	var word = strings.ToUpper(h.tokens[i].Value)
+	if h.depth > 0 {
+		return word == "TO" && h.name(i-1) == "WHITESPACE" && h.name(i+1) == "WHITESPACE"
+	}
+	return (word == "AND" || word == "OR" || word == "NOT") && h.boundary(i) && h.name(i+1) == "WHITESPACE"
+}
+
+// term: emit field or term from token i, and return index of its last token
+func (h *highlighter) term(i int) int {
+	var j = i
+	for h.termChar(j + 1) {
+		j++
+	}
+	var kind = TERM_SPAN
+	if h.depth == 0 && h.name(j+1) == "COLON" {
+		kind = FIELD_SPAN
+	}
+	for k := i; k <= j; k++ {
+		if h.name(k) == "ESCAPE" {
+			h.emit(ESCAPE_SPAN, h.start(k), h.end(k))
+		} else {
+			h.emit(kind, h.start(k), h.end(k))
+		}
+	}
+	return j
+}
+
+// modifier: emit boost or fuzzy modifier with its value (i.e. `~AUTO:3,6`), and return index of its last token
+func (h *highlighter) modifier(i int) int {
+	var j = i
+	for {
+		switch h.name(j + 1) {
+		case "NUMBER", "DOT", "IDENT", "COLON":
+			j++
+			continue
+		}
+		break
+	}
+	h.emit(MODIFIER_SPAN, h.start(i), h.end(j))
+	return j
+}
+
+// fail: replace spans from offset with error span
+func (h *highlighter) fail(offset int) []Span {
+	var res = make([]Span, 0, len(h.spans)+1)
+	if offset < 0 {
+		return append(res, h.spans...)
+	}
+	for _, s := range h.spans {
+		if s.End <= offset {
+			res = append(res, s)
+		} else if s.Start < offset {
+			res = append(res, Span{Kind: s.Kind, Start: s.Start, End: offset})
+		}
+	}
+	return append(res, Span{Kind: ERROR_SPAN, Start: offset, End: len(h.query)})
+}
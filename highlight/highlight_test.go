@@ -0,0 +1,90 @@
+package highlight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type span struct {
+	kind SpanKind
+	text string
+}
+
+func TestHighlight(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  []span
+	}
+	var testCases = []testCase{
+		{
+			name:  "test_field_term",
+			input: `user.name:foo* AND x:-1`,
+			want:  []span{{FIELD_SPAN, "user.name"}, {TERM_SPAN, "foo*"}, {OPERATOR_SPAN, "AND"}, {FIELD_SPAN, "x"}, {TERM_SPAN, "-1"}},
+		},
+		{
+			name:  "test_operator_in_phrase",
+			input: `x:"a AND b" || y:/a OR b/ && !z:a/b`,
+			want: []span{{FIELD_SPAN, "x"}, {PHRASE_SPAN, `"a AND b"`}, {OPERATOR_SPAN, "||"}, {FIELD_SPAN, "y"}, {REGEXP_SPAN, "/a OR b/"},
+				{OPERATOR_SPAN, "&&"}, {OPERATOR_SPAN, "!"}, {FIELD_SPAN, "z"}, {TERM_SPAN, "a/b"}},
+		},
+		{
+			name:  "test_escaped_quote",
+			input: `x:"a \" AND b" and not y:1`,
+			want:  []span{{FIELD_SPAN, "x"}, {PHRASE_SPAN, `"a \" AND b"`}, {OPERATOR_SPAN, "and"}, {OPERATOR_SPAN, "not"}, {FIELD_SPAN, "y"}, {TERM_SPAN, "1"}},
+		},
+		{
+			name:  "test_range",
+			input: `x:[1 TO 2020-01-01} AND y:{* TO 5] AND z:>=10`,
+			want: []span{{FIELD_SPAN, "x"}, {RANGE_BRACKET_SPAN, "["}, {TERM_SPAN, "1"}, {OPERATOR_SPAN, "TO"}, {TERM_SPAN, "2020-01-01"}, {RANGE_BRACKET_SPAN, "}"},
+				{OPERATOR_SPAN, "AND"}, {FIELD_SPAN, "y"}, {RANGE_BRACKET_SPAN, "{"}, {TERM_SPAN, "*"}, {OPERATOR_SPAN, "TO"}, {TERM_SPAN, "5"}, {RANGE_BRACKET_SPAN, "]"},
+				{OPERATOR_SPAN, "AND"}, {FIELD_SPAN, "z"}, {OPERATOR_SPAN, ">="}, {TERM_SPAN, "10"}},
+		},
+		{
+			name:  "test_modifier_escape",
+			input: `x\:y:foo\:bar~AUTO:3,6 OR y:(a OR "b c")^1.5 OR z:"b c"~2`,
+			want: []span{{FIELD_SPAN, "x"}, {ESCAPE_SPAN, `\:`}, {FIELD_SPAN, "y"}, {TERM_SPAN, "foo"}, {ESCAPE_SPAN, `\:`}, {TERM_SPAN, "bar"}, {MODIFIER_SPAN, "~AUTO:3,6"},
+				{OPERATOR_SPAN, "OR"}, {FIELD_SPAN, "y"}, {TERM_SPAN, "a"}, {OPERATOR_SPAN, "OR"}, {PHRASE_SPAN, `"b c"`}, {MODIFIER_SPAN, "^1.5"},
+				{OPERATOR_SPAN, "OR"}, {FIELD_SPAN, "z"}, {PHRASE_SPAN, `"b c"`}, {MODIFIER_SPAN, "~2"}},
+		},
+		{
+			name:  "test_error",
+			input: `x:1 AND y:2) OR z:3`,
+			want:  []span{{FIELD_SPAN, "x"}, {TERM_SPAN, "1"}, {OPERATOR_SPAN, "AND"}, {FIELD_SPAN, "y"}, {TERM_SPAN, "2"}, {ERROR_SPAN, ") OR z:3"}},
+		},
+		{
+			name:  "test_error_in_span",
+			input: `x:foo~2^3`,
+			want:  []span{{FIELD_SPAN, "x"}, {TERM_SPAN, "foo"}, {MODIFIER_SPAN, "~2"}, {ERROR_SPAN, "^3"}},
+		},
+		{
+			name:  "test_incomplete",
+			input: `x:(1 OR 2`,
+			want:  []span{{FIELD_SPAN, "x"}, {TERM_SPAN, "1"}, {OPERATOR_SPAN, "OR"}, {TERM_SPAN, "2"}},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var got = []span{}
+			for _, s := range Highlight(tt.input) {
+				got = append(got, span{s.Kind, tt.input[s.Start:s.End]})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	var q = `x:"<a>" AND y:[1 TO 2] ^`
+	var spans = Highlight(q)
+	assert.Equal(t, `<span class="lucene-field">x</span>:<span class="lucene-phrase">&#34;&lt;a&gt;&#34;</span> `+
+		`<span class="lucene-operator">AND</span> <span class="lucene-field">y</span>:<span class="lucene-range-bracket">[</span>`+
+		`<span class="lucene-term">1</span> <span class="lucene-operator">TO</span> <span class="lucene-term">2</span>`+
+		`<span class="lucene-range-bracket">]</span> <span class="lucene-error">^</span>`, HTML(q, spans))
+	assert.Equal(t, "\x1b[36mx\x1b[0m:\x1b[32m\"<a>\"\x1b[0m \x1b[1;35mAND\x1b[0m \x1b[36my\x1b[0m:\x1b[1;34m[\x1b[0m1 \x1b[1;35mTO\x1b[0m 2\x1b[1;34m]\x1b[0m \x1b[4;31m^\x1b[0m",
+		ANSI(q, spans))
+	assert.Equal(t, "\x1b[7mx\x1b[0m:\"<a>\" AND \x1b[7my\x1b[0m:[1 TO 2] ^", ANSIWithColors(q, spans, map[SpanKind]string{FIELD_SPAN: "7"}))
+	// invalid spans are skipped
+	assert.Equal(t, "abc", ANSI("abc", []Span{{Kind: FIELD_SPAN, Start: 2, End: 5}, {Kind: FIELD_SPAN, Start: 1, End: 1}}))
+}
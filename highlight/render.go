@@ -0,0 +1,73 @@
+package highlight
+
+import (
+	"html"
+	"strings"
+)
+
+// DefaultANSIColors: SGR parameters of span kinds which are used by ANSI, terms are not colored
+var DefaultANSIColors = map[SpanKind]string{
+	FIELD_SPAN:         "36",   // cyan
+	OPERATOR_SPAN:      "1;35", // bold magenta
+	PHRASE_SPAN:        "32",   // green
+	REGEXP_SPAN:        "33",   // yellow
+	RANGE_BRACKET_SPAN: "1;34", // bold blue
+	MODIFIER_SPAN:      "34",   // blue
+	ESCAPE_SPAN:        "35",   // magenta
+	ERROR_SPAN:         "4;31", // underlined red
+}
+
+// HTMLClassPrefix: prefix of class of span element, class is prefix with name of span kind, i.e. `lucene-field`
+const HTMLClassPrefix = "lucene-"
+
+// ANSI: render highlighted query with DefaultANSIColors for terminals
+func ANSI(query string, spans []Span) string {
+	return ANSIWithColors(query, spans, DefaultANSIColors)
+}
+
+// ANSIWithColors: render highlighted query with SGR parameters of span kinds, span kind without color isn't highlighted
+func ANSIWithColors(query string, spans []Span, colors map[SpanKind]string) string {
+	return render(query, spans, func(sb *strings.Builder, s *Span, text string) {
+		if s == nil {
+			sb.WriteString(text)
+		} else if c, ok := colors[s.Kind]; ok {
+			sb.WriteString("\x1b[" + c + "m" + text + "\x1b[0m")
+		} else {
+			sb.WriteString(text)
+		}
+	})
+}
+
+// HTML: render highlighted query as escaped HTML, every span is `<span class="lucene-{kind}">` element
+func HTML(query string, spans []Span) string {
+	return render(query, spans, func(sb *strings.Builder, s *Span, text string) {
+		if s == nil {
+			sb.WriteString(html.EscapeString(text))
+			return
+		}
+		sb.WriteString(`<span class="` + HTMLClassPrefix + s.Kind.String() + `">`)
+		sb.WriteString(html.EscapeString(text))
+		sb.WriteString("</span>")
+	})
+}
+
+// render: write text of spans and text between spans (span is nil), invalid spans are skipped
+func render(query string, spans []Span, write func(sb *strings.Builder, s *Span, text string)) string {
+	var sb = strings.Builder{}
+	var offset = 0
+	for i := range spans {
+		var s = &spans[i]
+		if s.Start < offset || s.End > len(query) || s.Start >= s.End {
+			continue
+		}
+		if s.Start > offset {
+			write(&sb, nil, query[offset:s.Start])
+		}
+		write(&sb, s, query[s.Start:s.End])
+		offset = s.End
+	}
+	if offset < len(query) {
+		write(&sb, nil, query[offset:])
+	}
+	return sb.String()
+}
@@ -0,0 +1,229 @@
+package lucene_parser
+
+import (
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Inspect: traverse query in depth-first order like ast.Inspect of go. f is invoked with node and byte offset of node
+// in q.String() (ast doesn't keep position of origin query string, so offsets are relative to formatted query).
+// if f returns true, Inspect invokes f recursively for each of the non-nil children of node, followed by a call of f(nil, -1).
+// visited nodes are queries of this package, operators and terms (range term, single range term and double range term are leaves).
+func Inspect(q *Lucene, f func(node interface{}, offset int) bool) {
+	var v = &inspector{f: f}
+	v.lucene(q, 0)
+}
+
+type inspector struct {
+	f func(node interface{}, offset int) bool
+}
+
+// enter: invoke f with node, and return true if children of node should be visited
+func (v *inspector) enter(node interface{}, offset int) bool {
+	return v.f(node, offset)
+}
+
+func (v *inspector) leave() {
+	v.f(nil, -1)
+}
+
+func (v *inspector) lucene(q *Lucene, offset int) {
+	if q == nil || q.OrQuery == nil || !v.enter(q, offset) {
+		return
+	}
+	v.orQuery(q.OrQuery, offset)
+	offset += len(q.OrQuery.String())
+	for _, x := range q.OSQuery {
+		v.osQuery(x, offset)
+		offset += len(x.String())
+	}
+	v.leave()
+}
+
+func (v *inspector) orQuery(q *OrQuery, offset int) {
+	if q == nil || q.AndQuery == nil || !v.enter(q, offset) {
+		return
+	}
+	v.andQuery(q.AndQuery, offset)
+	offset += len(q.AndQuery.String())
+	for _, x := range q.AnSQuery {
+		v.ansQuery(x, offset)
+		offset += len(x.String())
+	}
+	v.leave()
+}
+
+func (v *inspector) osQuery(q *OSQuery, offset int) {
+	if q == nil || q.OrQuery == nil || !v.enter(q, offset) {
+		return
+	}
+	v.symbol(q.OrSymbol, q.OrSymbol != nil, offset)
+	v.orQuery(q.OrQuery, offset+len(q.OrSymbol.String()))
+	v.leave()
+}
+
+func (v *inspector) andQuery(q *AndQuery, offset int) {
+	if q == nil || (q.ParenQuery == nil && q.FieldQuery == nil) || !v.enter(q, offset) {
+		return
+	}
+	v.symbol(q.NotSymbol, q.NotSymbol != nil, offset)
+	offset += len(q.NotSymbol.String())
+	if q.ParenQuery != nil {
+		v.parenQuery(q.ParenQuery, offset)
+	} else {
+		v.fieldQuery(q.FieldQuery, offset)
+	}
+	v.leave()
+}
+
+func (v *inspector) ansQuery(q *AnSQuery, offset int) {
+	if q == nil || q.AndQuery == nil || !v.enter(q, offset) {
+		return
+	}
+	if q.AndSymbol != nil {
+		v.symbol(q.AndSymbol, true, offset)
+		offset += len(q.AndSymbol.String())
+	} else {
+		// " AND " is added in front of not symbol when formatting
+		offset += len(" AND ")
+		v.symbol(q.NotSymbol, q.NotSymbol != nil, offset)
+		offset += len(q.NotSymbol.String())
+	}
+	v.andQuery(q.AndQuery, offset)
+	v.leave()
+}
+
+func (v *inspector) parenQuery(q *ParenQuery, offset int) {
+	if q == nil || q.SubQuery == nil || !v.enter(q, offset) {
+		return
+	}
+	v.lucene(q.SubQuery, offset+len("( "))
+	v.leave()
+}
+
+func (v *inspector) fieldQuery(q *FieldQuery, offset int) {
+	if q == nil || q.Field == nil || q.Term == nil || !v.enter(q, offset) {
+		return
+	}
+	if v.enter(q.Field, offset) {
+		v.leave()
+	}
+	v.term(q.Term, offset+len(q.Field.String())+len(":"))
+	v.leave()
+}
+
+// symbol: operators are leaves
+func (v *inspector) symbol(node interface{}, present bool, offset int) {
+	if present && v.enter(node, offset) {
+		v.leave()
+	}
+}
+
+func (v *inspector) term(t *term.Term, offset int) {
+	if t == nil || !v.enter(t, offset) {
+		return
+	}
+	if t.RegexpTerm != nil {
+		v.leaf(t.RegexpTerm, offset)
+	} else if t.FuzzyTerm != nil {
+		v.fuzzyTerm(t.FuzzyTerm, offset)
+	} else if t.RangeTerm != nil {
+		v.leaf(t.RangeTerm, offset)
+	} else if t.TermGroup != nil {
+		v.termGroup(t.TermGroup, offset)
+	}
+	v.leave()
+}
+
+func (v *inspector) leaf(node interface{}, offset int) {
+	if v.enter(node, offset) {
+		v.leave()
+	}
+}
+
+func (v *inspector) fuzzyTerm(t *term.FuzzyTerm, offset int) {
+	if (t.SingleTerm == nil && t.PhraseTerm == nil) || !v.enter(t, offset) {
+		return
+	}
+	if t.SingleTerm != nil {
+		v.leaf(t.SingleTerm, offset)
+	} else {
+		v.leaf(t.PhraseTerm, offset)
+	}
+	v.leave()
+}
+
+func (v *inspector) termGroup(t *term.TermGroup, offset int) {
+	if t.LogicTermGroup == nil || !v.enter(t, offset) {
+		return
+	}
+	v.logicTermGroup(t.LogicTermGroup, offset+len("( "))
+	v.leave()
+}
+
+func (v *inspector) logicTermGroup(t *term.LogicTermGroup, offset int) {
+	if t == nil || t.OrTermGroup == nil || !v.enter(t, offset) {
+		return
+	}
+	v.orTermGroup(t.OrTermGroup, offset)
+	offset += len(t.OrTermGroup.String())
+	for _, x := range t.OSTermGroup {
+		if x != nil && x.OrTermGroup != nil && v.enter(x, offset) {
+			v.symbol(x.OrSymbol, x.OrSymbol != nil, offset)
+			v.orTermGroup(x.OrTermGroup, offset+len(x.OrSymbol.String()))
+			v.leave()
+		}
+		offset += len(x.String())
+	}
+	v.leave()
+}
+
+func (v *inspector) orTermGroup(t *term.OrTermGroup, offset int) {
+	if t == nil || t.AndTermGroup == nil || !v.enter(t, offset) {
+		return
+	}
+	v.andTermGroup(t.AndTermGroup, offset)
+	offset += len(t.AndTermGroup.String())
+	for _, x := range t.AnSTermGroup {
+		if x != nil && x.AndTermGroup != nil && v.enter(x, offset) {
+			var o = offset
+			if x.AndSymbol != nil {
+				v.symbol(x.AndSymbol, true, o)
+				o += len(x.AndSymbol.String())
+			} else {
+				o += len(" AND ")
+				v.symbol(x.NotSymbol, x.NotSymbol != nil, o)
+				o += len(x.NotSymbol.String())
+			}
+			v.andTermGroup(x.AndTermGroup, o)
+			v.leave()
+		}
+		offset += len(x.String())
+	}
+	v.leave()
+}
+
+func (v *inspector) andTermGroup(t *term.AndTermGroup, offset int) {
+	if t == nil || (t.ParenTermGroup == nil && t.FieldTermGroup == nil) || !v.enter(t, offset) {
+		return
+	}
+	v.symbol(t.NotSymbol, t.NotSymbol != nil, offset)
+	offset += len(t.NotSymbol.String())
+	if t.ParenTermGroup != nil {
+		if t.ParenTermGroup.SubTermGroup != nil && v.enter(t.ParenTermGroup, offset) {
+			v.logicTermGroup(t.ParenTermGroup.SubTermGroup, offset+len("( "))
+			v.leave()
+		}
+	} else if v.enter(t.FieldTermGroup, offset) {
+		if t.FieldTermGroup.SingleTerm != nil {
+			v.leaf(t.FieldTermGroup.SingleTerm, offset)
+		} else if t.FieldTermGroup.PhraseTerm != nil {
+			v.leaf(t.FieldTermGroup.PhraseTerm, offset)
+		} else if t.FieldTermGroup.SRangeTerm != nil {
+			v.leaf(t.FieldTermGroup.SRangeTerm, offset)
+		} else if t.FieldTermGroup.DRangeTerm != nil {
+			v.leaf(t.FieldTermGroup.DRangeTerm, offset)
+		}
+		v.leave()
+	}
+	v.leave()
+}
@@ -0,0 +1,58 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestInspect(t *testing.T) {
+	for _, input := range []string{
+		`x:1 AND NOT y:2 OR z:[1 TO 2]^2`,
+		`x:1 NOT y:>=2`,
+		`(x:foo~2 || y:"foo bar"^3) && !z:/\d+/`,
+		`x:(foo* OR (bar AND !"baz") NOT >1 OR [1 TO 2}) AND y:{* TO 10]`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			var s = q.String()
+			var depth = 0
+			Inspect(q, func(node interface{}, offset int) bool {
+				if node == nil {
+					depth--
+					return false
+				}
+				depth++
+				// every node is at the offset where its formatted string begins
+				if x, ok := node.(interface{ String() string }); ok {
+					var str = x.String()
+					if y, ok := node.(*term.SRangeTerm); ok {
+						str = y.String()
+					}
+					if len(str) != 0 && str != " AND " && str != " OR " {
+						assert.Equal(t, str, s[offset:offset+len(str)], "%T", node)
+					}
+				}
+				return true
+			})
+			assert.Equal(t, 0, depth)
+		})
+	}
+
+	t.Run("test_skip_children", func(t *testing.T) {
+		q, err := ParseLucene(`x:1 AND (y:2 OR z:3)`)
+		assert.Nil(t, err)
+		var fields = 0
+		Inspect(q, func(node interface{}, offset int) bool {
+			if _, ok := node.(*ParenQuery); ok {
+				return false
+			} else if _, ok := node.(*FieldQuery); ok {
+				fields++
+			}
+			return true
+		})
+		assert.Equal(t, 1, fields)
+	})
+}
@@ -0,0 +1,80 @@
+// Package codec implements helpers shared by json encoding of ast nodes in different packages.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalNode: marshal v as json object whose first key is "type", v must be a pointer to struct without
+// MarshalJSON method (i.e. pointer to an alias type of node), otherwise it will recurse endless.
+func MarshalNode(typ string, v interface{}) ([]byte, error) {
+	return marshalNode(typ, 0, v)
+}
+
+// MarshalVersionedNode: same as MarshalNode, but schema version is written behind "type"
+func MarshalVersionedNode(typ string, version int, v interface{}) ([]byte, error) {
+	return marshalNode(typ, version, v)
+}
+
+func marshalNode(typ string, version int, v interface{}) ([]byte, error) {
+	var body, err = json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// nil node is null, and fields of node are spliced behind "type", so that node must be json object
+	if body = bytes.TrimSpace(body); string(body) == "null" {
+		return body, nil
+	} else if len(body) < 2 || body[0] != '{' {
+		return nil, fmt.Errorf("node of type %q isn't json object: %s", typ, body)
+	}
+	var buf = bytes.NewBufferString(`{"type":`)
+	var name, _ = json.Marshal(typ)
+	buf.Write(name)
+	if version > 0 {
+		fmt.Fprintf(buf, `,"version":%d`, version)
+	}
+	if len(body) > 2 {
+		buf.WriteByte(',')
+		buf.Write(body[1:])
+	} else {
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
+type header struct {
+	Type    *string `json:"type"`
+	Version *int    `json:"version"`
+}
+
+// UnmarshalNode: check "type" of json object is typ, and unmarshal json object to v.
+// v must be a pointer to struct without UnmarshalJSON method, and it is reset to zero value before unmarshaling,
+// so that private caches of node are cleared.
+func UnmarshalNode(data []byte, typ string, v interface{}) error {
+	var h = header{}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	} else if h.Type == nil {
+		return fmt.Errorf("missing type of node, expect type: %q", typ)
+	} else if *h.Type != typ {
+		return fmt.Errorf("unexpected type of node: %q, expect type: %q", *h.Type, typ)
+	}
+	var rv = reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	return json.Unmarshal(data, v)
+}
+
+// UnmarshalVersionedNode: same as UnmarshalNode, but schema version must be not greater than version.
+// the json object without schema version is regarded as current version.
+func UnmarshalVersionedNode(data []byte, typ string, version int, v interface{}) error {
+	var h = header{}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	} else if h.Version != nil && (*h.Version <= 0 || *h.Version > version) {
+		return fmt.Errorf("unsupported schema version: %d, expect version: 1 ~ %d", *h.Version, version)
+	}
+	return UnmarshalNode(data, typ, v)
+}
@@ -0,0 +1,30 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalNode(t *testing.T) {
+	type node struct {
+		Value string `json:"value,omitempty"`
+	}
+	b, err := MarshalNode("node", &node{Value: "x"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"type":"node","value":"x"}`, string(b))
+
+	b, err = MarshalVersionedNode("node", 2, &node{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"type":"node","version":2}`, string(b))
+
+	b, err = MarshalVersionedNode("node", 2, (*node)(nil))
+	assert.Nil(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	// fields of node can't be spliced behind "type" if node isn't json object
+	_, err = MarshalNode("node", []string{"x"})
+	assert.NotNil(t, err)
+	_, err = MarshalNode("node", "x")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,160 @@
+package lucene_parser
+
+import (
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// FieldRef: reference of field in query. Offset is position in canonical form of query (q.String()) rather than
+// position in user's input, because ast doesn't keep positions of tokens, so it can't be used to highlight input
+// which is written differently (i.e. `x:1 && y:2`).
+type FieldRef struct {
+	Field    string // name of field, i.e. `x\:y` in query `x\:y:1`
+	Offset   int    // byte offset of field in q.String(), not in user's input
+	UnderNot bool   // field is referenced under NOT operator
+}
+
+// TermRef: literal value of term in query, every element of term group is regarded as a term.
+// like FieldRef, Offset is position in q.String() rather than position in user's input.
+type TermRef struct {
+	Field    string        // name of field which term belongs to
+	Kind     term.TermType // type of term, i.e. SINGLE_TERM_TYPE | WILDCARD_TERM_TYPE
+	Raw      string        // raw value as it is written in query, i.e. `foo\*bar`, `"foo bar"`, `/\d+/`, `[ 1 TO 2 }`
+	Value    string        // unescaped value, i.e. `foo*bar`, `foo bar`, `\d+`, value of range term is empty
+	Bound    *term.Bound   // bound of range term, it's nil for other terms
+	Offset   int           // byte offset of raw value in q.String(), not in user's input
+	UnderNot bool          // term is under NOT operator
+}
+
+// Fields: return fields which are referenced by query in order of appearance,
+// field of exists query `_exists_:x` / `_missing_:x` is x
+func Fields(q *Lucene) []FieldRef {
+	return filterFields(q, nil)
+}
+
+// filterFields: return fields which satisfy filter, all fields are returned if filter is nil
+func filterFields(q *Lucene, filter func(f *term.Field) bool) []FieldRef {
+	var res = []FieldRef{}
+	var w = &inventoryWalker{}
+	Inspect(q, func(node interface{}, offset int) bool {
+		if !w.visit(node) {
+			return false
+		}
+		if x, ok := node.(*term.Field); ok {
+			// field of `_exists_:x` / `_missing_:x` is value of term
+			var fq = w.stack[len(w.stack)-2].(*FieldQuery)
+			if f := queryField(fq); f != x {
+				var _, target = existsTarget(fq.Term)
+				offset, x = offset+len(x.String())+len(":")+target, f
+			}
+			if filter == nil || filter(x) {
+				res = append(res, FieldRef{Field: x.String(), Offset: offset, UnderNot: w.underNot()})
+			}
+		}
+		return true
+	})
+	return res
+}
+
+// Terms: return literal values which are referenced by query in order of appearance,
+// elements of term group are regarded as terms of field which is in front of term group.
+func Terms(q *Lucene) []TermRef {
+	var res = []TermRef{}
+	var w = &inventoryWalker{}
+	Inspect(q, func(node interface{}, offset int) bool {
+		if !w.visit(node) {
+			return false
+		}
+		var ref = TermRef{Field: w.field, Offset: offset, UnderNot: w.underNot()}
+		switch x := node.(type) {
+		case *term.SingleTerm:
+			ref.Kind, ref.Raw, ref.Value = x.GetTermType(), x.String(), term.Unescape(x.String())
+		case *term.PhraseTerm:
+			ref.Kind, ref.Raw, ref.Value = x.GetTermType(), x.String(), term.Unescape(strings.Join(x.Chars, ""))
+		case *term.RegexpTerm:
+			// escape char is meaningful in regexp, so regexp isn't unescaped
+			ref.Kind, ref.Raw, ref.Value = x.GetTermType(), x.String(), strings.Join(x.Chars, "")
+		case *term.RangeTerm:
+			ref.Kind, ref.Raw, ref.Bound = term.RANGE_TERM_TYPE, x.String(), x.GetBound()
+			ref.Raw = ref.Raw[:len(ref.Raw)-len(x.BoostSymbol)]
+		case *term.SRangeTerm:
+			ref.Kind, ref.Raw, ref.Bound = x.GetTermType(), x.String(), x.GetBound()
+		case *term.DRangeTerm:
+			ref.Kind, ref.Raw, ref.Bound = x.GetTermType(), x.String(), x.GetBound()
+		default:
+			return true
+		}
+		ref.Kind |= w.modifier
+		res = append(res, ref)
+		return true
+	})
+	return res
+}
+
+// inventoryWalker: keep stack of visited nodes to know field of term and whether node is under NOT operator
+type inventoryWalker struct {
+	stack    []interface{}
+	nots     []bool
+	field    string
+	modifier term.TermType
+}
+
+func (w *inventoryWalker) underNot() bool {
+	for _, x := range w.nots {
+		if x {
+			return true
+		}
+	}
+	return false
+}
+
+// visit: record node on stack, it returns false if node is nil (leaving node)
+func (w *inventoryWalker) visit(node interface{}) bool {
+	if node == nil {
+		w.stack, w.nots = w.stack[:len(w.stack)-1], w.nots[:len(w.nots)-1]
+		w.resetContext()
+		return false
+	}
+	var not = false
+	switch x := node.(type) {
+	case *AndQuery:
+		not = x.NotSymbol != nil
+	case *AnSQuery:
+		not = x.AndSymbol == nil && x.NotSymbol != nil
+	case *term.AndTermGroup:
+		not = x.NotSymbol != nil
+	case *term.AnSTermGroup:
+		not = x.AndSymbol == nil && x.NotSymbol != nil
+	}
+	w.stack, w.nots = append(w.stack, node), append(w.nots, not)
+	w.resetContext()
+	return true
+}
+
+// resetContext: find field and modifiers (boost / fuzzy) of the nearest field query and term
+func (w *inventoryWalker) resetContext() {
+	w.field, w.modifier = "", 0
+	for _, x := range w.stack {
+		switch n := x.(type) {
+		case *FieldQuery:
+			w.field = n.Field.String()
+			w.modifier = 0
+		case *term.FuzzyTerm:
+			if len(n.BoostSymbol) != 0 {
+				w.modifier |= term.BOOST_TERM_TYPE
+			}
+			if len(n.FuzzySymbol) != 0 {
+				w.modifier |= term.FUZZY_TERM_TYPE
+			}
+		case *term.RangeTerm:
+			if len(n.BoostSymbol) != 0 {
+				w.modifier |= term.BOOST_TERM_TYPE
+			}
+		case *term.TermGroup:
+			if len(n.BoostSymbol) != 0 {
+				w.modifier |= term.BOOST_TERM_TYPE
+			}
+		}
+	}
+}
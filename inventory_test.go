@@ -0,0 +1,115 @@
+package lucene_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestFields(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  []FieldRef
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_simple",
+			input: `x:1 AND y:2`,
+			want: []FieldRef{
+				{Field: "x", Offset: 0},
+				{Field: "y", Offset: 8},
+			},
+		},
+		{
+			name:  "test_not",
+			input: `x:1 NOT y:2 OR !(z:3 AND w:4)`,
+			want: []FieldRef{
+				{Field: "x", Offset: 0},
+				{Field: "y", Offset: 12, UnderNot: true},
+				{Field: "z", Offset: 25, UnderNot: true},
+				{Field: "w", Offset: 33, UnderNot: true},
+			},
+		},
+		{
+			name:  "test_escape_field",
+			input: `x\:y:(1 OR 2)`,
+			want: []FieldRef{
+				{Field: `x\:y`, Offset: 0},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var res = Fields(q)
+			assert.Equal(t, tt.want, res)
+			var s = q.String()
+			for _, x := range res {
+				assert.Equal(t, x.Field, s[x.Offset:x.Offset+len(x.Field)])
+			}
+		})
+	}
+	assert.Empty(t, Fields(nil))
+}
+
+func TestTerms(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  []TermRef
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_fuzzy_term",
+			input: `x:foo\*bar* AND y:"foo \"bar\""~2`,
+			want: []TermRef{
+				{Field: "x", Kind: term.SINGLE_TERM_TYPE | term.WILDCARD_TERM_TYPE, Raw: `foo\*bar*`, Value: `foo*bar*`, Offset: 2},
+				{Field: "y", Kind: term.PHRASE_TERM_TYPE | term.FUZZY_TERM_TYPE, Raw: `"foo \"bar\""`, Value: `foo "bar"`, Offset: 18},
+			},
+		},
+		{
+			name:  "test_regexp_and_range",
+			input: `x:/\d+/ OR NOT y:[1 TO 2}^2`,
+			want: []TermRef{
+				{Field: "x", Kind: term.REGEXP_TERM_TYPE, Raw: `/\d+/`, Value: `\d+`, Offset: 2},
+				{
+					Field: "y", Kind: term.RANGE_TERM_TYPE | term.BOOST_TERM_TYPE, Raw: `[ 1 TO 2 }`, Offset: 17, UnderNot: true,
+					Bound: &term.Bound{
+						LeftValue:   &term.RangeValue{SingleValue: []string{"1"}},
+						RightValue:  &term.RangeValue{SingleValue: []string{"2"}, SideFlag: true},
+						LeftInclude: true,
+					},
+				},
+			},
+		},
+		{
+			name:  "test_term_group",
+			input: `x:(foo OR NOT "bar" OR >1)^2`,
+			want: []TermRef{
+				{Field: "x", Kind: term.SINGLE_TERM_TYPE | term.BOOST_TERM_TYPE, Raw: `foo`, Value: `foo`, Offset: 4},
+				{Field: "x", Kind: term.PHRASE_TERM_TYPE | term.BOOST_TERM_TYPE, Raw: `"bar"`, Value: `bar`, Offset: 15, UnderNot: true},
+				{
+					Field: "x", Kind: term.RANGE_TERM_TYPE | term.BOOST_TERM_TYPE, Raw: `{ 1 TO * }`, Offset: 24,
+					Bound: &term.Bound{
+						LeftValue:  &term.RangeValue{SingleValue: []string{"1"}},
+						RightValue: &term.RangeValue{InfinityVal: "*", SideFlag: true},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var res = Terms(q)
+			assert.Equal(t, tt.want, res)
+			var s = q.String()
+			for _, x := range res {
+				assert.Equal(t, x.Raw, s[x.Offset:x.Offset+len(x.Raw)])
+			}
+		})
+	}
+	assert.Empty(t, Terms(nil))
+}
@@ -0,0 +1,79 @@
+package lucene_parser
+
+import (
+	"github.com/zhuliquan/lucene_parser/internal/codec"
+)
+
+// JSONSchemaVersion: version of json schema of ast, it's written in json object of lucene query as "version",
+// and json object whose version is greater than JSONSchemaVersion can't be decoded.
+const JSONSchemaVersion = 1
+
+func (q *Lucene) MarshalJSON() ([]byte, error) {
+	type alias Lucene
+	return codec.MarshalVersionedNode("lucene", JSONSchemaVersion, (*alias)(q))
+}
+
+func (q *Lucene) UnmarshalJSON(data []byte) error {
+	type alias Lucene
+	return codec.UnmarshalVersionedNode(data, "lucene", JSONSchemaVersion, (*alias)(q))
+}
+
+func (q *OrQuery) MarshalJSON() ([]byte, error) {
+	type alias OrQuery
+	return codec.MarshalNode("or_query", (*alias)(q))
+}
+
+func (q *OrQuery) UnmarshalJSON(data []byte) error {
+	type alias OrQuery
+	return codec.UnmarshalNode(data, "or_query", (*alias)(q))
+}
+
+func (q *OSQuery) MarshalJSON() ([]byte, error) {
+	type alias OSQuery
+	return codec.MarshalNode("or_sym_query", (*alias)(q))
+}
+
+func (q *OSQuery) UnmarshalJSON(data []byte) error {
+	type alias OSQuery
+	return codec.UnmarshalNode(data, "or_sym_query", (*alias)(q))
+}
+
+func (q *AndQuery) MarshalJSON() ([]byte, error) {
+	type alias AndQuery
+	return codec.MarshalNode("and_query", (*alias)(q))
+}
+
+func (q *AndQuery) UnmarshalJSON(data []byte) error {
+	type alias AndQuery
+	return codec.UnmarshalNode(data, "and_query", (*alias)(q))
+}
+
+func (q *AnSQuery) MarshalJSON() ([]byte, error) {
+	type alias AnSQuery
+	return codec.MarshalNode("and_sym_query", (*alias)(q))
+}
+
+func (q *AnSQuery) UnmarshalJSON(data []byte) error {
+	type alias AnSQuery
+	return codec.UnmarshalNode(data, "and_sym_query", (*alias)(q))
+}
+
+func (q *ParenQuery) MarshalJSON() ([]byte, error) {
+	type alias ParenQuery
+	return codec.MarshalNode("paren_query", (*alias)(q))
+}
+
+func (q *ParenQuery) UnmarshalJSON(data []byte) error {
+	type alias ParenQuery
+	return codec.UnmarshalNode(data, "paren_query", (*alias)(q))
+}
+
+func (q *FieldQuery) MarshalJSON() ([]byte, error) {
+	type alias FieldQuery
+	return codec.MarshalNode("field_query", (*alias)(q))
+}
+
+func (q *FieldQuery) UnmarshalJSON(data []byte) error {
+	type alias FieldQuery
+	return codec.UnmarshalNode(data, "field_query", (*alias)(q))
+}
@@ -0,0 +1,124 @@
+package lucene_parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/operator"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, input := range []string{
+		`x:1`,
+		`x:1 AND NOT y:2 OR z:[1 TO 2]^2`,
+		`x:1 NOT y:>=2`,
+		`(x:foo~2 || y:"foo bar"^3) && !z:/\d+/`,
+		`x:(foo* OR (bar AND !"baz")) AND y:{* TO 10]`,
+		`x\:y:1\+1 OR z:<=2^0.5`,
+		`(title OR body.*):foo AND http.*:error`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			b, err := json.Marshal(q)
+			assert.Nil(t, err)
+			var out = &Lucene{}
+			assert.Nil(t, json.Unmarshal(b, out))
+			assert.Equal(t, q, out)
+			assert.Equal(t, q.String(), out.String())
+		})
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var q = &Lucene{
+		OrQuery: &OrQuery{
+			AndQuery: &AndQuery{
+				NotSymbol: &operator.NotSymbol{Symbol: "!"},
+				FieldQuery: &FieldQuery{
+					Field: &term.Field{Value: []string{"x"}},
+					Term:  &term.Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: term.NewSingleTerm("1")}},
+				},
+			},
+		},
+	}
+	b, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "lucene",
+		"version": 1,
+		"or_query": {
+			"type": "or_query",
+			"and_query": {
+				"type": "and_query",
+				"not_symbol": {"type": "not_symbol", "symbol": "!"},
+				"field_query": {
+					"type": "field_query",
+					"field": {"type": "field", "value": ["x"]},
+					"term": {
+						"type": "term",
+						"fuzzy_term": {
+							"type": "fuzzy_term",
+							"single_term": {"type": "single_term", "begin": "1"}
+						}
+					}
+				}
+			}
+		}
+	}`, string(b))
+}
+
+func TestJSONError(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+	}
+	for _, tt := range []testCase{
+		{
+			name:  "test_missing_type",
+			input: `{"version":1}`,
+		},
+		{
+			name:  "test_wrong_type",
+			input: `{"type":"or_query"}`,
+		},
+		{
+			name:  "test_future_version",
+			input: `{"type":"lucene","version":2}`,
+		},
+		{
+			name:  "test_wrong_nested_type",
+			input: `{"type":"lucene","or_query":{"type":"and_query"}}`,
+		},
+		{
+			name:  "test_invalid_json",
+			input: `{"type":"lucene"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &Lucene{}
+			assert.NotNil(t, json.Unmarshal([]byte(tt.input), out))
+		})
+	}
+
+	t.Run("test_missing_version", func(t *testing.T) {
+		var out = &Lucene{}
+		assert.Nil(t, json.Unmarshal([]byte(`{"type":"lucene"}`), out))
+		assert.Equal(t, &Lucene{}, out)
+	})
+}
+
+func TestJSONNil(t *testing.T) {
+	// nil node is encoded as null like nil pointer of any other type
+	for _, node := range []json.Marshaler{(*Lucene)(nil), (*OrQuery)(nil), (*FieldQuery)(nil), (*term.Term)(nil)} {
+		b, err := node.MarshalJSON()
+		assert.Nil(t, err)
+		assert.Equal(t, `null`, string(b))
+		assert.True(t, json.Valid(b))
+	}
+	b, err := json.Marshal(&FieldQuery{Field: &term.Field{Value: []string{"x"}}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type":"field_query","field":{"type":"field","value":["x"]}}`, string(b))
+}
@@ -0,0 +1,90 @@
+package lucene_parser
+
+import (
+	"fmt"
+	"strings"
+
+	tk "github.com/zhuliquan/lucene_parser/token"
+)
+
+var (
+	ErrTooDeep       = fmt.Errorf("query is nested too deeply")
+	ErrTooManyTokens = tk.ErrTooManyTokens
+	ErrQueryTooLong  = fmt.Errorf("query is too long")
+)
+
+// ParseLimits: limits of query which are checked by scanning tokens before parsing, so that hostile query
+// (i.e. thousands of nested parentheses) is rejected before exhausting stack of recursive parser. zero means no limit.
+type ParseLimits struct {
+	MaxDepth  int // maximum nesting of parentheses (paren query, term group and field group)
+	MaxTokens int // maximum number of tokens, whitespaces aren't counted
+	MaxLength int // maximum length of query in bytes
+}
+
+// DefaultParseLimits: limits which are enough for queries written by human
+var DefaultParseLimits = ParseLimits{MaxDepth: 32, MaxTokens: 4096, MaxLength: 64 << 10}
+
+// Check: scan tokens of query and check limits, ErrQueryTooLong, ErrTooDeep or ErrTooManyTokens is returned if query
+// exceeds limits. parentheses in phrase term and regexp term aren't counted, slash starts regexp term only if it opens
+// value of term (i.e. `x:/a/` but not `x:a/b`). query which can't be tokenized is left to parser.
+func (l ParseLimits) Check(query string) error {
+	if l.MaxLength > 0 && len(query) > l.MaxLength {
+		return fmt.Errorf("%w: %d bytes exceeds %d", ErrQueryTooLong, len(query), l.MaxLength)
+	}
+	if l.MaxDepth <= 0 && l.MaxTokens <= 0 {
+		return nil
+	}
+	var lex, err = tk.Lexer.Lex(strings.NewReader(query))
+	if err != nil {
+		return nil
+	}
+	var symbols = tk.Lexer.Symbols()
+	var (
+		depth, tokens               int
+		inPhrase, inRegexp, escaped bool
+		prev                        = symbols["WHITESPACE"] // beginning of query is regarded as whitespace
+	)
+	for {
+		var t, err = lex.Next()
+		if err != nil || t.EOF() {
+			return nil
+		}
+		if t.Type != symbols["WHITESPACE"] && t.Type != symbols["EOL"] {
+			if tokens++; l.MaxTokens > 0 && tokens > l.MaxTokens {
+				return fmt.Errorf("%w: more than %d tokens", ErrTooManyTokens, l.MaxTokens)
+			}
+		}
+		switch {
+		case t.Type == symbols["QUOTE"] && !inRegexp && !escaped:
+			inPhrase = !inPhrase
+		case t.Type == symbols["SLASH"] && !inPhrase && (inRegexp || regexpStarts[prev]):
+			inRegexp = !inRegexp
+		case t.Type == symbols["LPAREN"] && !inPhrase && !inRegexp:
+			if depth++; l.MaxDepth > 0 && depth > l.MaxDepth {
+				return fmt.Errorf("%w: depth exceeds %d at %s", ErrTooDeep, l.MaxDepth, t.Pos)
+			}
+		case t.Type == symbols["RPAREN"] && !inPhrase && !inRegexp && depth > 0:
+			depth--
+		}
+		escaped = t.Type == symbols["REVERSE"]
+		prev = t.Type
+	}
+}
+
+// regexpStarts: types of tokens which slash behind of them starts regexp term, slash behind of others
+// (i.e. ident and number) is char of single term.
+var regexpStarts = func() map[rune]bool {
+	var res = map[rune]bool{}
+	for _, name := range []string{"WHITESPACE", "EOL", "COLON", "LPAREN", "PLUS", "MINUS", "NOT", "AND", "SOR"} {
+		res[tk.Lexer.Symbols()[name]] = true
+	}
+	return res
+}()
+
+// ParseLuceneWithLimits: parse query like ParseLucene after checking limits
+func ParseLuceneWithLimits(queryString string, limits ParseLimits) (*Lucene, error) {
+	if err := limits.Check(queryString); err != nil {
+		return nil, err
+	}
+	return ParseLucene(queryString)
+}
@@ -0,0 +1,124 @@
+package lucene_parser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+	type testCase struct {
+		name   string
+		input  string
+		limits ParseLimits
+		err    error
+	}
+	var deep = strings.Repeat("(", 10000) + "x:1" + strings.Repeat(")", 10000)
+	var long = "x:1" + strings.Repeat(" OR x:1", 2000)
+	var slashDeep = "x:a/b OR " + strings.Repeat("(", 200) + "y:1" + strings.Repeat(")", 200)
+	for _, tt := range []testCase{
+		{name: "test_no_limit", input: `x:1 AND ((y:2))`},
+		{name: "test_depth", input: `x:1 AND ((y:2))`, limits: ParseLimits{MaxDepth: 2}},
+		{name: "test_too_deep", input: `x:1 AND (((y:2)))`, limits: ParseLimits{MaxDepth: 2}, err: ErrTooDeep},
+		{name: "test_term_group_depth", input: `x:(1 OR (2 AND 3))`, limits: ParseLimits{MaxDepth: 1}, err: ErrTooDeep},
+		{name: "test_paren_in_phrase", input: `x:"((( \")))" AND y:/(((a)))/`, limits: ParseLimits{MaxDepth: 1}},
+		{name: "test_hostile_depth", input: deep, limits: DefaultParseLimits, err: ErrTooDeep},
+		{name: "test_slash_in_term", input: `x:a/b AND ((y:2))`, limits: ParseLimits{MaxDepth: 2}},
+		{name: "test_slash_in_term_too_deep", input: slashDeep, limits: DefaultParseLimits, err: ErrTooDeep},
+		{name: "test_regexp_in_paren", input: `(x:/(((b)))/ OR z:1) AND -y:/((c))/`, limits: ParseLimits{MaxDepth: 1}},
+		{name: "test_tokens", input: `x:1 AND y:2`, limits: ParseLimits{MaxTokens: 7}},
+		{name: "test_too_many_tokens", input: `x:1 AND y:2`, limits: ParseLimits{MaxTokens: 6}, err: ErrTooManyTokens},
+		{name: "test_hostile_tokens", input: long, limits: DefaultParseLimits, err: ErrTooManyTokens},
+		{name: "test_length", input: `x:1 AND y:2`, limits: ParseLimits{MaxLength: 11}},
+		{name: "test_too_long", input: `x:1 AND y:2`, limits: ParseLimits{MaxLength: 10}, err: ErrQueryTooLong},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLuceneWithLimits(tt.input, tt.limits)
+			if tt.err != nil {
+				assert.Nil(t, q)
+				assert.True(t, errors.Is(err, tt.err), "%v", err)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, q)
+			}
+		})
+	}
+}
+
+func TestParseLuceneContext(t *testing.T) {
+	type testCase struct {
+		name  string
+		ctx   func() (context.Context, context.CancelFunc)
+		input string
+		err   error
+	}
+	var background = func() (context.Context, context.CancelFunc) {
+		return context.WithCancel(context.Background())
+	}
+	for _, tt := range []testCase{
+		{name: "test_ok", ctx: background, input: `x:1 AND ( y:2 OR z:( a OR b ) )`},
+		{name: "test_canceled", ctx: func() (context.Context, context.CancelFunc) {
+			var ctx, cancel = context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}, input: `x:1`, err: context.Canceled},
+		{name: "test_deadline", ctx: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), -time.Second)
+		}, input: `x:1`, err: context.DeadlineExceeded},
+		{name: "test_too_long", ctx: background, input: "x:" + strings.Repeat("a", 1<<16), err: ErrQueryTooLong},
+		{name: "test_too_many_tokens", ctx: background, input: "x:1" + strings.Repeat(" OR x:1", 2000), err: ErrTooManyTokens},
+		{name: "test_too_deep", ctx: background, input: strings.Repeat("(", 33) + "x:1" + strings.Repeat(")", 33), err: ErrTooDeep},
+		{name: "test_too_deep_unbalanced", ctx: background, input: strings.Repeat("(", 100), err: ErrTooDeep},
+		{name: "test_too_deep_term_group", ctx: background, input: "x:" + strings.Repeat("(a OR ", 33) + "b" + strings.Repeat(")", 33), err: ErrTooDeep},
+		{name: "test_syntax_error", ctx: background, input: `x:1 AND`, err: errors.New("")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx, cancel = tt.ctx()
+			defer cancel()
+			q, err := ParseLuceneContext(ctx, tt.input)
+			if tt.err != nil {
+				assert.Nil(t, q)
+				assert.NotNil(t, err)
+				if tt.err.Error() != "" {
+					assert.True(t, errors.Is(err, tt.err), "%v", err)
+				}
+			} else if assert.Nil(t, err) {
+				assert.Equal(t, tt.input, q.String())
+			}
+		})
+	}
+}
+
+// countdownContext: context which is canceled after Err is called n times
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestParseContextInterrupted(t *testing.T) {
+	var parser = NewParser(WithEngine(DESCENT_ENGINE))
+	var query = "x:1" + strings.Repeat(" OR x:(a AND b)", 2000)
+	// count calls of Err, then cancel context at every call, i.e. while tokenizing and parsing
+	var ctx = &countdownContext{Context: context.Background(), n: 1 << 30}
+	q, err := parser.ParseContext(ctx, query)
+	assert.Nil(t, err)
+	assert.NotNil(t, q)
+	var calls = 1<<30 - ctx.n
+	assert.True(t, calls > 20, "%d", calls)
+	for n := 0; n < calls; n++ {
+		q, err := parser.ParseContext(&countdownContext{Context: context.Background(), n: n}, query)
+		assert.Nil(t, q)
+		assert.True(t, errors.Is(err, context.Canceled), "%d: %v", n, err)
+	}
+}
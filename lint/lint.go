@@ -0,0 +1,119 @@
+// Package lint: linter of lucene query, rules report positioned warnings (i.e. lowercase operator, ambiguous precedence)
+// and most of them suggest autofix which edits ast of query.
+package lint
+
+import (
+	"sort"
+
+	"github.com/zhuliquan/lucene_parser"
+)
+
+// maxFixes: maximum number of fixes which are applied by Fix, it prevents endless loop of conflicting fixes
+const maxFixes = 1000
+
+// Warning: warning of rule, Offset and End are byte offsets of warned part in formatted query (q.String())
+type Warning struct {
+	Rule    string
+	Offset  int
+	End     int
+	Message string
+	Fix     *Fix
+}
+
+// Fix: autofix suggestion of warning, Edit modifies ast of linted query in place
+type Fix struct {
+	Message string
+	Edit    func()
+}
+
+// Rule: lint rule, rules are configured by fields of rule struct
+type Rule interface {
+	Name() string
+	Check(q *lucene_parser.Lucene) []Warning
+}
+
+// DefaultRules: return all rules with default configuration
+func DefaultRules() []Rule {
+	return []Rule{
+		&LowercaseOperator{},
+		&AmbiguousPrecedence{},
+		&RedundantParens{},
+		&LeadingWildcard{},
+		&NotBoost{},
+		&SingleWordPhrase{},
+		&EmptyRange{},
+		&RangeTypeMismatch{},
+	}
+}
+
+// Disable: return rules except rules of names
+func Disable(rules []Rule, names ...string) []Rule {
+	var res = []Rule{}
+	for _, r := range rules {
+		var disabled = false
+		for _, name := range names {
+			disabled = disabled || r.Name() == name
+		}
+		if !disabled {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// Run: check query with rules (DefaultRules is used if no rule is given), and return warnings sorted by offset
+func Run(q *lucene_parser.Lucene, rules ...Rule) []Warning {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	var res = []Warning{}
+	for _, r := range rules {
+		res = append(res, r.Check(q)...)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Offset < res[j].Offset
+	})
+	return res
+}
+
+// AutoFix: apply autofixes of warnings one by one to copy of query (query is linted again after each fix, because
+// offsets and nodes are changed by fix), and return fixed query and rest warnings which can't be fixed.
+func AutoFix(q *lucene_parser.Lucene, rules ...Rule) (*lucene_parser.Lucene, []Warning) {
+	var res = q.Clone()
+	for i := 0; i < maxFixes; i++ {
+		var fixed = false
+		for _, w := range Run(res, rules...) {
+			if w.Fix != nil {
+				w.Fix.Edit()
+				fixed = true
+				break
+			}
+		}
+		if !fixed {
+			break
+		}
+	}
+	return res, Run(res, rules...)
+}
+
+// walk: traverse query by lucene_parser.Inspect, parents of node are given from root to direct parent
+func walk(q *lucene_parser.Lucene, f func(node interface{}, offset int, parents []interface{})) {
+	var stack = []interface{}{}
+	lucene_parser.Inspect(q, func(node interface{}, offset int) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		f(node, offset, stack)
+		stack = append(stack, node)
+		return true
+	})
+}
+
+// parent: return the nth parent of node (n = 1 is direct parent)
+func parent(parents []interface{}, n int) interface{} {
+	if len(parents) < n {
+		return nil
+	}
+	return parents[len(parents)-n]
+}
@@ -0,0 +1,140 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser"
+)
+
+type warning struct {
+	rule string
+	text string
+}
+
+func TestRules(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		rule  Rule
+		want  []warning
+		fixed string
+	}
+	var testCases = []testCase{
+		{
+			name: "test_lowercase_operator", input: `x:1 and y:(2 or 3) not z:1 AND w:1`, rule: &LowercaseOperator{},
+			want:  []warning{{"lowercase-operator", "AND"}, {"lowercase-operator", "OR"}, {"lowercase-operator", "NOT"}},
+			fixed: `x:1 AND y:( 2 OR 3 ) AND NOT z:1 AND w:1`,
+		},
+		{
+			name: "test_lowercase_operator_ignore_not", input: `x:1 or not y:1`, rule: &LowercaseOperator{IgnoreNot: true},
+			want:  []warning{{"lowercase-operator", "OR"}},
+			fixed: `x:1 OR NOT y:1`,
+		},
+		{
+			name: "test_ambiguous_precedence", input: `a:1 OR b:2 AND c:3 OR d:4`, rule: &AmbiguousPrecedence{},
+			want:  []warning{{"ambiguous-precedence", "a:1 OR b:2 AND c:3 OR d:4"}},
+			fixed: `a:1 OR ( b:2 AND c:3 ) OR d:4`,
+		},
+		{
+			name: "test_ambiguous_precedence_term_group", input: `a:(1 AND 2 OR 3)`, rule: &AmbiguousPrecedence{},
+			want:  []warning{{"ambiguous-precedence", "1 AND 2 OR 3"}},
+			fixed: `a:( ( 1 AND 2 ) OR 3 )`,
+		},
+		{
+			name: "test_no_ambiguous_precedence", input: `a:1 OR (b:2 AND c:3)`, rule: &AmbiguousPrecedence{},
+			want: []warning{}, fixed: `a:1 OR ( b:2 AND c:3 )`,
+		},
+		{
+			name: "test_redundant_parens_single", input: `a:1 AND (b:2) AND NOT (c:3) AND NOT (NOT d:4)`, rule: &RedundantParens{},
+			want:  []warning{{"redundant-parens", "( b:2 )"}, {"redundant-parens", "( c:3 )"}},
+			fixed: `a:1 AND b:2 AND NOT c:3 AND NOT ( NOT d:4 )`,
+		},
+		{
+			name: "test_redundant_parens_and", input: `(a:1 AND b:2) AND c:3 AND (d:4 AND e:5) AND NOT (f:6 AND g:7)`, rule: &RedundantParens{},
+			want:  []warning{{"redundant-parens", "( a:1 AND b:2 )"}, {"redundant-parens", "( d:4 AND e:5 )"}},
+			fixed: `a:1 AND b:2 AND c:3 AND d:4 AND e:5 AND NOT ( f:6 AND g:7 )`,
+		},
+		{
+			name: "test_redundant_parens_or", input: `(a:1 OR b:2) OR c:3 OR (d:4 OR e:5) OR (f:6 OR g:7) AND h:8`, rule: &RedundantParens{},
+			want:  []warning{{"redundant-parens", "( a:1 OR b:2 )"}, {"redundant-parens", "( d:4 OR e:5 )"}},
+			fixed: `a:1 OR b:2 OR c:3 OR d:4 OR e:5 OR ( f:6 OR g:7 ) AND h:8`,
+		},
+		{
+			name: "test_redundant_parens_top_level", input: `((a:1 OR b:2))`, rule: &RedundantParens{},
+			want:  []warning{{"redundant-parens", "( ( a:1 OR b:2 ) )"}, {"redundant-parens", "( a:1 OR b:2 )"}},
+			fixed: `a:1 OR b:2`,
+		},
+		{
+			name: "test_keep_top_level", input: `(a:1 OR b:2)`, rule: &RedundantParens{KeepTopLevel: true},
+			want: []warning{}, fixed: `( a:1 OR b:2 )`,
+		},
+		{
+			name: "test_leading_wildcard", input: `a:*foo AND b:?oo AND c:foo* AND d:* AND e:(x OR *y)`, rule: &LeadingWildcard{},
+			want:  []warning{{"leading-wildcard", "*foo"}, {"leading-wildcard", "?oo"}, {"leading-wildcard", "*y"}},
+			fixed: `a:*foo AND b:?oo AND c:foo* AND d:* AND e:( x OR *y )`,
+		},
+		{
+			name: "test_boost_on_not", input: `a:1^2 AND NOT b:1^3 NOT c:[1 TO 2]^2 AND !d:(1 OR 2)^4`, rule: &NotBoost{},
+			want:  []warning{{"boost-on-not", "^3"}, {"boost-on-not", "^2"}, {"boost-on-not", "^4"}},
+			fixed: `a:1^2 AND NOT b:1 AND NOT c:[ 1 TO 2 ] AND NOT d:( 1 OR 2 )`,
+		},
+		{
+			name: "test_single_word_phrase", input: `a:"foo" AND b:"foo bar" AND c:"foo-bar" AND d:"foo"~2 AND e:("x" OR y) AND f:"and"`, rule: &SingleWordPhrase{},
+			want:  []warning{{"single-word-phrase", `"foo"`}, {"single-word-phrase", `"foo"`}, {"single-word-phrase", `"x"`}},
+			fixed: `a:foo AND b:"foo bar" AND c:"foo-bar" AND d:foo AND e:( x OR y ) AND f:"and"`,
+		},
+		{
+			name: "test_empty_range", input: `a:[5 TO 1] AND b:{1 TO 1] AND c:[a TO b] AND d:[2020-02-01 TO 2020-01-01} AND e:[1 TO 1]`, rule: &EmptyRange{},
+			want:  []warning{{"empty-range", "[ 5 TO 1 ]"}, {"empty-range", "{ 1 TO 1 ]"}, {"empty-range", "[ 2020-02-01 TO 2020-01-01 }"}},
+			fixed: `a:[ 1 TO 5 ] AND b:{ 1 TO 1 ] AND c:[ a TO b ] AND d:{ 2020-01-01 TO 2020-02-01 ] AND e:[ 1 TO 1 ]`,
+		},
+		{
+			name: "test_range_type_mismatch", input: `a:[1 TO abc] AND b:[2020-01-01 TO 5] AND c:[1 TO *] AND d:[now-1d TO 2020-01-01] AND e:(1 OR [a TO 2])`, rule: &RangeTypeMismatch{},
+			want:  []warning{{"range-type-mismatch", "[ 1 TO abc ]"}, {"range-type-mismatch", "[ 2020-01-01 TO 5 ]"}, {"range-type-mismatch", "[ a TO 2 ]"}},
+			fixed: `a:[ 1 TO abc ] AND b:[ 2020-01-01 TO 5 ] AND c:[ 1 TO * ] AND d:[ now-1d TO 2020-01-01 ] AND e:( 1 OR [ a TO 2 ] )`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := lucene_parser.ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var origin = q.String()
+			var ws = Run(q, tt.rule)
+			var got = []warning{}
+			for _, w := range ws {
+				got = append(got, warning{w.Rule, origin[w.Offset:w.End]})
+			}
+			assert.Equal(t, tt.want, got)
+
+			fixed, _ := AutoFix(q, tt.rule)
+			assert.Equal(t, tt.fixed, fixed.String())
+			assert.Equal(t, origin, q.String())
+			// fixed query can be parsed again
+			_, err = lucene_parser.ParseLucene(fixed.String())
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	q, err := lucene_parser.ParseLucene(`(a:*x or b:"y" AND c:[2 TO 1])`)
+	assert.Nil(t, err)
+	var rules = []string{}
+	for _, w := range Run(q) {
+		rules = append(rules, w.Rule)
+	}
+	assert.Equal(t, []string{"redundant-parens", "ambiguous-precedence", "leading-wildcard", "lowercase-operator", "single-word-phrase", "empty-range"}, rules)
+
+	rules = []string{}
+	for _, w := range Run(q, Disable(DefaultRules(), "redundant-parens", "empty-range")...) {
+		rules = append(rules, w.Rule)
+	}
+	assert.Equal(t, []string{"ambiguous-precedence", "leading-wildcard", "lowercase-operator", "single-word-phrase"}, rules)
+
+	fixed, rest := AutoFix(q)
+	assert.Equal(t, `a:*x OR ( b:y AND c:[ 1 TO 2 ] )`, fixed.String())
+	assert.Equal(t, 1, len(rest))
+	assert.Equal(t, "leading-wildcard", rest[0].Rule)
+	assert.Nil(t, rest[0].Fix)
+}
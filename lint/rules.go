@@ -0,0 +1,494 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zhuliquan/lucene_parser"
+	op "github.com/zhuliquan/lucene_parser/operator"
+	"github.com/zhuliquan/lucene_parser/term"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+// LowercaseOperator: lowercase `and` / `or` / `not` is parsed as operator, but it may be meant as word (i.e. `x:(rock and roll)`).
+// fix: write operator in uppercase.
+type LowercaseOperator struct {
+	IgnoreNot bool // lowercase `not` isn't reported if it's true
+}
+
+func (r *LowercaseOperator) Name() string {
+	return "lowercase-operator"
+}
+
+func (r *LowercaseOperator) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	var report = func(symbol *string, offset int) {
+		var upper = strings.ToUpper(*symbol)
+		res = append(res, Warning{
+			Rule:    r.Name(),
+			Offset:  offset,
+			End:     offset + len(*symbol),
+			Message: fmt.Sprintf("lowercase %q is used as operator %s, quote it if it's a word", *symbol, upper),
+			Fix:     &Fix{Message: "write " + upper, Edit: func() { *symbol = upper }},
+		})
+	}
+	walk(q, func(node interface{}, offset int, _ []interface{}) {
+		switch x := node.(type) {
+		case *op.AndSymbol:
+			if x.Symbol == "and" {
+				// formatted as " AND "
+				report(&x.Symbol, offset+1)
+			}
+		case *op.OrSymbol:
+			if x.Symbol == "or" {
+				report(&x.Symbol, offset+1)
+			}
+		case *op.NotSymbol:
+			if x.Symbol == "not" && !r.IgnoreNot {
+				report(&x.Symbol, offset)
+			}
+		}
+	})
+	return res
+}
+
+// AmbiguousPrecedence: `AND` binds tighter than `OR`, but it's easily misread without parentheses (i.e. `a:1 OR b:2 AND c:3`).
+// fix: wrap clauses joined by `AND` with parentheses (i.e. `a:1 OR ( b:2 AND c:3 )`).
+type AmbiguousPrecedence struct{}
+
+func (r *AmbiguousPrecedence) Name() string {
+	return "ambiguous-precedence"
+}
+
+func (r *AmbiguousPrecedence) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	var report = func(s string, offset int, edit func()) {
+		res = append(res, Warning{
+			Rule:    r.Name(),
+			Offset:  offset,
+			End:     offset + len(s),
+			Message: "AND and OR are mixed without parentheses, AND binds tighter than OR",
+			Fix:     &Fix{Message: "wrap AND clauses with parentheses", Edit: edit},
+		})
+	}
+	walk(q, func(node interface{}, offset int, _ []interface{}) {
+		switch x := node.(type) {
+		case *lucene_parser.Lucene:
+			if len(x.OSQuery) != 0 && (len(x.OrQuery.AnSQuery) != 0 || anyAnd(x)) {
+				report(x.String(), offset, func() {
+					x.OrQuery = wrapOrQuery(x.OrQuery)
+					for _, y := range x.OSQuery {
+						y.OrQuery = wrapOrQuery(y.OrQuery)
+					}
+				})
+			}
+		case *term.LogicTermGroup:
+			if len(x.OSTermGroup) != 0 && anyAndTermGroup(x) {
+				report(x.String(), offset, func() {
+					x.OrTermGroup = wrapOrTermGroup(x.OrTermGroup)
+					for _, y := range x.OSTermGroup {
+						y.OrTermGroup = wrapOrTermGroup(y.OrTermGroup)
+					}
+				})
+			}
+		}
+	})
+	return res
+}
+
+func anyAnd(q *lucene_parser.Lucene) bool {
+	for _, x := range q.OSQuery {
+		if len(x.OrQuery.AnSQuery) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func anyAndTermGroup(t *term.LogicTermGroup) bool {
+	if len(t.OrTermGroup.AnSTermGroup) != 0 {
+		return true
+	}
+	for _, x := range t.OSTermGroup {
+		if len(x.OrTermGroup.AnSTermGroup) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func wrapOrQuery(q *lucene_parser.OrQuery) *lucene_parser.OrQuery {
+	if len(q.AnSQuery) == 0 {
+		return q
+	}
+	return &lucene_parser.OrQuery{AndQuery: &lucene_parser.AndQuery{
+		ParenQuery: &lucene_parser.ParenQuery{SubQuery: &lucene_parser.Lucene{OrQuery: q}},
+	}}
+}
+
+func wrapOrTermGroup(t *term.OrTermGroup) *term.OrTermGroup {
+	if len(t.AnSTermGroup) == 0 {
+		return t
+	}
+	return &term.OrTermGroup{AndTermGroup: &term.AndTermGroup{
+		ParenTermGroup: &term.ParenTermGroup{SubTermGroup: &term.LogicTermGroup{OrTermGroup: t}},
+	}}
+}
+
+// RedundantParens: parentheses which don't change meaning of query, i.e. `(x:1)`, `a:1 AND (b:2 AND c:3)`,
+// `a:1 OR (b:2 OR c:3)` and parentheses around whole query. fix: remove parentheses.
+type RedundantParens struct {
+	KeepTopLevel bool // parentheses around whole query (i.e. `(a:1 OR b:2)`) aren't reported if it's true
+}
+
+func (r *RedundantParens) Name() string {
+	return "redundant-parens"
+}
+
+func (r *RedundantParens) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	walk(q, func(node interface{}, offset int, parents []interface{}) {
+		var x, ok = node.(*lucene_parser.ParenQuery)
+		if !ok || x.SubQuery == nil {
+			return
+		}
+		var andQuery = parent(parents, 1).(*lucene_parser.AndQuery)
+		var ansQuery, _ = parent(parents, 2).(*lucene_parser.AnSQuery)
+		var orQuery, _ = parent(parents, 2).(*lucene_parser.OrQuery)
+		var negated = andQuery.NotSymbol != nil || (ansQuery != nil && ansQuery.NotSymbol != nil)
+		if ansQuery != nil {
+			orQuery = parent(parents, 3).(*lucene_parser.OrQuery)
+		}
+		var sub = x.SubQuery
+		var edit func()
+		if len(sub.OSQuery) == 0 && len(sub.OrQuery.AnSQuery) == 0 {
+			// single clause, i.e. `(x:1)`, `NOT (x:1)`
+			var inner = sub.OrQuery.AndQuery
+			if !negated || inner.NotSymbol == nil {
+				edit = func() {
+					if andQuery.NotSymbol == nil {
+						andQuery.NotSymbol = inner.NotSymbol
+					}
+					andQuery.ParenQuery, andQuery.FieldQuery = inner.ParenQuery, inner.FieldQuery
+				}
+			}
+		} else if negated {
+			return
+		} else if len(parents) == 3 && len(orQuery.AnSQuery) == 0 && len(q.OSQuery) == 0 {
+			// whole query, parents are lucene, or query and and query
+			if !r.KeepTopLevel {
+				edit = func() { *q = *sub }
+			}
+		} else if len(sub.OSQuery) == 0 && len(orQuery.AnSQuery) != 0 {
+			// `a AND (b AND c)`
+			edit = func() { spliceAnd(orQuery, andQuery, sub.OrQuery) }
+		} else if len(sub.OSQuery) != 0 && len(orQuery.AnSQuery) == 0 {
+			// `a OR (b OR c)`
+			var lucene, _ = parent(parents, 3).(*lucene_parser.Lucene)
+			if osQuery, ok := parent(parents, 3).(*lucene_parser.OSQuery); ok {
+				lucene = parent(parents, 4).(*lucene_parser.Lucene)
+				edit = func() { spliceOr(lucene, osQuery.OrQuery, sub) }
+			} else {
+				edit = func() { spliceOr(lucene, lucene.OrQuery, sub) }
+			}
+		}
+		if edit != nil {
+			res = append(res, Warning{
+				Rule:    r.Name(),
+				Offset:  offset,
+				End:     offset + len(x.String()),
+				Message: "parentheses are redundant",
+				Fix:     &Fix{Message: "remove parentheses", Edit: edit},
+			})
+		}
+	})
+	return res
+}
+
+// spliceAnd: replace and query of or query with and queries of sub
+func spliceAnd(q *lucene_parser.OrQuery, target *lucene_parser.AndQuery, sub *lucene_parser.OrQuery) {
+	if q.AndQuery == target {
+		q.AndQuery = sub.AndQuery
+		q.AnSQuery = append(append([]*lucene_parser.AnSQuery{}, sub.AnSQuery...), q.AnSQuery...)
+		return
+	}
+	for i, x := range q.AnSQuery {
+		if x.AndQuery == target {
+			var rest = append([]*lucene_parser.AnSQuery{}, q.AnSQuery[i+1:]...)
+			x.AndQuery = sub.AndQuery
+			q.AnSQuery = append(append(q.AnSQuery[:i+1], sub.AnSQuery...), rest...)
+			return
+		}
+	}
+}
+
+// spliceOr: replace or query of lucene with or queries of sub
+func spliceOr(q *lucene_parser.Lucene, target *lucene_parser.OrQuery, sub *lucene_parser.Lucene) {
+	if q.OrQuery == target {
+		q.OrQuery = sub.OrQuery
+		q.OSQuery = append(append([]*lucene_parser.OSQuery{}, sub.OSQuery...), q.OSQuery...)
+		return
+	}
+	for i, x := range q.OSQuery {
+		if x.OrQuery == target {
+			var rest = append([]*lucene_parser.OSQuery{}, q.OSQuery[i+1:]...)
+			x.OrQuery = sub.OrQuery
+			q.OSQuery = append(append(q.OSQuery[:i+1], sub.OSQuery...), rest...)
+			return
+		}
+	}
+}
+
+// LeadingWildcard: wildcard term begins with `*` or `?` (i.e. `x:*foo`), which scans all terms of field. there isn't fix.
+type LeadingWildcard struct{}
+
+func (r *LeadingWildcard) Name() string {
+	return "leading-wildcard"
+}
+
+func (r *LeadingWildcard) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	walk(q, func(node interface{}, offset int, _ []interface{}) {
+		if x, ok := node.(*term.SingleTerm); ok && !x.IsExists() && x.Begin.Type == token.WILDCARD_TOKEN_TYPE {
+			res = append(res, Warning{
+				Rule:    r.Name(),
+				Offset:  offset,
+				End:     offset + len(x.String()),
+				Message: fmt.Sprintf("leading wildcard of %s scans all terms of field", x.String()),
+			})
+		}
+	})
+	return res
+}
+
+// NotBoost: boost of negated clause (i.e. `NOT x:1^2`) doesn't affect score. fix: remove boost.
+type NotBoost struct{}
+
+func (r *NotBoost) Name() string {
+	return "boost-on-not"
+}
+
+func (r *NotBoost) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	walk(q, func(node interface{}, offset int, parents []interface{}) {
+		var x, ok = node.(*lucene_parser.FieldQuery)
+		if !ok {
+			return
+		}
+		var andQuery = parent(parents, 1).(*lucene_parser.AndQuery)
+		var ansQuery, _ = parent(parents, 2).(*lucene_parser.AnSQuery)
+		if andQuery.NotSymbol == nil && (ansQuery == nil || ansQuery.NotSymbol == nil) {
+			return
+		}
+		var boost *string
+		if x.Term.FuzzyTerm != nil {
+			boost = &x.Term.FuzzyTerm.BoostSymbol
+		} else if x.Term.RangeTerm != nil {
+			boost = &x.Term.RangeTerm.BoostSymbol
+		} else if x.Term.TermGroup != nil {
+			boost = &x.Term.TermGroup.BoostSymbol
+		}
+		if boost == nil || *boost == "" {
+			return
+		}
+		var end = offset + len(x.String())
+		res = append(res, Warning{
+			Rule:    r.Name(),
+			Offset:  end - len(*boost),
+			End:     end,
+			Message: fmt.Sprintf("boost %s of negated clause doesn't affect score", *boost),
+			Fix:     &Fix{Message: "remove boost", Edit: func() { *boost = "" }},
+		})
+	})
+	return res
+}
+
+// SingleWordPhrase: phrase of one word (i.e. `x:"foo"`) is same as single term, and slop of it is meaningless.
+// word is letters and digits only, because other chars (i.e. `"foo-bar"`) may be split by analyzer,
+// and keywords (i.e. `"and"`, `"TO"`) aren't reported. fix: remove quotes.
+type SingleWordPhrase struct{}
+
+func (r *SingleWordPhrase) Name() string {
+	return "single-word-phrase"
+}
+
+func (r *SingleWordPhrase) Check(q *lucene_parser.Lucene) []Warning {
+	var res = []Warning{}
+	walk(q, func(node interface{}, offset int, parents []interface{}) {
+		var x, ok = node.(*term.PhraseTerm)
+		if !ok {
+			return
+		}
+		var word = strings.Join(x.Chars, "")
+		switch strings.ToUpper(word) {
+		case "AND", "OR", "NOT", "TO":
+			return
+		}
+		if word == "" || strings.IndexFunc(word, func(c rune) bool { return !unicode.IsLetter(c) && !unicode.IsDigit(c) }) >= 0 {
+			return
+		}
+		var edit func()
+		switch p := parent(parents, 1).(type) {
+		case *term.FuzzyTerm:
+			edit = func() { p.SingleTerm, p.PhraseTerm, p.FuzzySymbol = term.NewSingleTerm(word), nil, "" }
+		case *term.FieldTermGroup:
+			edit = func() { p.SingleTerm, p.PhraseTerm = term.NewSingleTerm(word), nil }
+		}
+		res = append(res, Warning{
+			Rule:    r.Name(),
+			Offset:  offset,
+			End:     offset + len(x.String()),
+			Message: fmt.Sprintf("phrase %s has only one word", x.String()),
+			Fix:     &Fix{Message: "remove quotes", Edit: edit},
+		})
+	})
+	return res
+}
+
+// valueKind: kind of range value
+type valueKind uint8
+
+const (
+	unknownKind valueKind = iota
+	numberKind
+	dateKind
+	stringKind
+)
+
+func (k valueKind) String() string {
+	return [...]string{"unknown", "number", "date", "string"}[k]
+}
+
+// dateLayouts: default layouts of date values
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02", "2006-01", "2006"}
+
+func rangeValue(v *term.RangeValue) string {
+	if len(v.PhraseValue) != 0 {
+		return strings.Join(v.PhraseValue, "")
+	}
+	return strings.Join(v.SingleValue, "")
+}
+
+func kindOf(v *term.RangeValue, layouts []string) valueKind {
+	if v == nil || v.IsInf(0) {
+		return unknownKind
+	}
+	var s = rangeValue(v)
+	if s == "now" || strings.HasPrefix(s, "now-") || strings.HasPrefix(s, "now+") || strings.HasPrefix(s, "now/") || strings.Contains(s, "||") {
+		// date math of ES, i.e. `now-1d/d`, `2020-01-01||+1M`
+		return dateKind
+	}
+	// year (i.e. `2006`) is regarded as number
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return numberKind
+	}
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return dateKind
+		}
+	}
+	return stringKind
+}
+
+// rangeTerms: visit double side range terms of query
+func rangeTerms(q *lucene_parser.Lucene, f func(t *term.DRangeTerm, offset int)) {
+	walk(q, func(node interface{}, offset int, _ []interface{}) {
+		switch x := node.(type) {
+		case *term.RangeTerm:
+			if x.DRangeTerm != nil {
+				f(x.DRangeTerm, offset)
+			}
+		case *term.DRangeTerm:
+			f(x, offset)
+		}
+	})
+}
+
+// EmptyRange: range term which can't match any value, i.e. `[5 TO 1]`, `{1 TO 1]`. bounds of different kinds
+// (see RangeTypeMismatch) and date math aren't compared. fix: swap reversed bounds.
+type EmptyRange struct {
+	DateLayouts []string // layouts of date values (time.Parse), default layouts are ISO 8601 dates
+}
+
+func (r *EmptyRange) Name() string {
+	return "empty-range"
+}
+
+func (r *EmptyRange) Check(q *lucene_parser.Lucene) []Warning {
+	var layouts = r.DateLayouts
+	if len(layouts) == 0 {
+		layouts = dateLayouts
+	}
+	var res = []Warning{}
+	rangeTerms(q, func(t *term.DRangeTerm, offset int) {
+		var lk, rk = kindOf(t.LValue, layouts), kindOf(t.RValue, layouts)
+		if lk == unknownKind || lk != rk {
+			return
+		}
+		var lv, rv = rangeValue(t.LValue), rangeValue(t.RValue)
+		var cmp = strings.Compare(lv, rv)
+		if lk == numberKind {
+			var a, _ = strconv.ParseFloat(lv, 64)
+			var b, _ = strconv.ParseFloat(rv, 64)
+			cmp = compareFloat(a, b)
+		} else if lk == dateKind && (strings.Contains(lv+rv, "now") || strings.Contains(lv+rv, "||")) {
+			return
+		}
+		var w = Warning{Rule: r.Name(), Offset: offset, End: offset + len(t.String())}
+		if cmp > 0 {
+			w.Message = fmt.Sprintf("lower bound %s is greater than upper bound %s", t.LValue, t.RValue)
+			w.Fix = &Fix{Message: "swap bounds", Edit: func() {
+				t.LValue, t.RValue = t.RValue, t.LValue
+				t.LBRACKET, t.RBRACKET = map[string]string{"}": "{", "]": "["}[t.RBRACKET], map[string]string{"{": "}", "[": "]"}[t.LBRACKET]
+			}}
+		} else if cmp == 0 && (t.LBRACKET == "{" || t.RBRACKET == "}") {
+			w.Message = fmt.Sprintf("range %s excludes its only value", t)
+		} else {
+			return
+		}
+		res = append(res, w)
+	})
+	return res
+}
+
+func compareFloat(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// RangeTypeMismatch: bounds of range look like values of different kinds (number, date and string), i.e. `[1 TO abc]`.
+// there isn't fix.
+type RangeTypeMismatch struct {
+	DateLayouts []string // layouts of date values (time.Parse), default layouts are ISO 8601 dates
+}
+
+func (r *RangeTypeMismatch) Name() string {
+	return "range-type-mismatch"
+}
+
+func (r *RangeTypeMismatch) Check(q *lucene_parser.Lucene) []Warning {
+	var layouts = r.DateLayouts
+	if len(layouts) == 0 {
+		layouts = dateLayouts
+	}
+	var res = []Warning{}
+	rangeTerms(q, func(t *term.DRangeTerm, offset int) {
+		var lk, rk = kindOf(t.LValue, layouts), kindOf(t.RValue, layouts)
+		if lk != unknownKind && rk != unknownKind && lk != rk {
+			res = append(res, Warning{
+				Rule:    r.Name(),
+				Offset:  offset,
+				End:     offset + len(t.String()),
+				Message: fmt.Sprintf("lower bound %s looks like %s, but upper bound %s looks like %s", t.LValue, lk, t.RValue, rk),
+			})
+		}
+	})
+	return res
+}
@@ -0,0 +1,269 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/zhuliquan/lucene_parser"
+	tk "github.com/zhuliquan/lucene_parser/token"
+)
+
+// maxAlignment: maximum size of table of aligning tokens, source map isn't built for larger queries
+const maxAlignment = 1 << 20
+
+// document: opened `.lucene` file, every non-blank line is one query
+type document struct {
+	uri     string
+	version int
+	text    string
+	lines   []string
+}
+
+func newDocument(uri string, version int, text string) *document {
+	var d = &document{uri: uri, version: version}
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.text = text
+	d.lines = strings.Split(text, "\n")
+	for i, line := range d.lines {
+		d.lines[i] = strings.TrimSuffix(line, "\r")
+	}
+}
+
+// apply: apply change of text, whole text is replaced if range of change is nil
+func (d *document) apply(c TextDocumentContentChangeEvent) {
+	if c.Range == nil {
+		d.setText(c.Text)
+		return
+	}
+	var start, end = d.offset(c.Range.Start), d.offset(c.Range.End)
+	if end < start {
+		start, end = end, start
+	}
+	d.setText(d.text[:start] + c.Text + d.text[end:])
+}
+
+// offset: byte offset of position in text
+func (d *document) offset(p Position) int {
+	var res = 0
+	for i := 0; i < p.Line && i < len(d.lines); i++ {
+		res += strings.IndexByte(d.text[res:], '\n') + 1
+	}
+	if p.Line >= len(d.lines) {
+		return len(d.text)
+	}
+	return res + byteOffset(d.lines[p.Line], p.Character)
+}
+
+// position: position of byte offset in line
+func (d *document) position(line, offset int) Position {
+	return Position{Line: line, Character: charOffset(d.lines[line], offset)}
+}
+
+func (d *document) span(line, offset, end int) Range {
+	return Range{Start: d.position(line, offset), End: d.position(line, end)}
+}
+
+// lineRange: range of whole line
+func (d *document) lineRange(line int) Range {
+	return d.span(line, 0, len(d.lines[line]))
+}
+
+// queries: parse all non-blank lines
+func (d *document) queries() []*query {
+	var res = []*query{}
+	for i := range d.lines {
+		if q := d.query(i); q != nil {
+			res = append(res, q)
+		}
+	}
+	return res
+}
+
+// query: parse query of line, it returns nil if line is blank
+func (d *document) query(line int) *query {
+	if line < 0 || line >= len(d.lines) {
+		return nil
+	}
+	var text = strings.TrimSpace(d.lines[line])
+	if text == "" {
+		return nil
+	}
+	var q = &query{line: line, start: strings.Index(d.lines[line], text), text: text}
+	q.lucene, q.err = lucene_parser.ParseLuceneWithLimits(text, lucene_parser.DefaultParseLimits)
+	return q
+}
+
+// query: query of line, start is byte offset of trimmed query in line
+type query struct {
+	line   int
+	start  int
+	text   string
+	lucene *lucene_parser.Lucene
+	err    error
+	smap   *sourceMap
+}
+
+// sourceMap: return map between formatted query and query text, it's built lazily
+func (q *query) sourceMap() *sourceMap {
+	if q.smap == nil {
+		q.smap = newSourceMap(q.text, q.lucene.String())
+	}
+	return q.smap
+}
+
+// charOffset: convert byte offset of line to offset of UTF-16 code units
+func charOffset(line string, offset int) int {
+	var res = 0
+	for i, r := range line {
+		if i >= offset {
+			break
+		}
+		res += utf16Len(r)
+	}
+	return res
+}
+
+// byteOffset: convert offset of UTF-16 code units to byte offset of line
+func byteOffset(line string, char int) int {
+	var n = 0
+	for i, r := range line {
+		if n >= char {
+			return i
+		}
+		n += utf16Len(r)
+	}
+	return len(line)
+}
+
+// utf16Len: number of UTF-16 code units of rune, rune out of BMP is encoded by surrogate pair
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// lexeme: token which isn't whitespace, key is normalized value which is used to align tokens
+type lexeme struct {
+	offset int
+	end    int
+	key    string
+}
+
+// lexemes: scan tokens of query, bool operators (`and`, `&&`, `!`) are normalized to upper case words
+func lexemes(s string) []lexeme {
+	var res = []lexeme{}
+	var lex, err = tk.Lexer.Lex(strings.NewReader(s))
+	if err != nil {
+		return res
+	}
+	for {
+		var t, err = lex.Next()
+		if err != nil || t.EOF() {
+			return res
+		}
+		if strings.TrimSpace(t.Value) == "" {
+			continue
+		}
+		var x = lexeme{offset: t.Pos.Offset, end: t.Pos.Offset + len(t.Value), key: t.Value}
+		if n := len(res); n != 0 && res[n-1].end == x.offset && (x.key == "&" && res[n-1].key == "&" || x.key == "|" && res[n-1].key == "|") {
+			res[n-1].end, res[n-1].key = x.end, res[n-1].key+x.key
+			x = res[n-1]
+			res = res[:n-1]
+		}
+		switch strings.ToUpper(x.key) {
+		case "AND", "&&":
+			x.key = "AND"
+		case "OR", "||":
+			x.key = "OR"
+		case "NOT", "!":
+			x.key = "NOT"
+		}
+		res = append(res, x)
+	}
+}
+
+// sourceMap: map between offsets of formatted query (offsets of lucene_parser.Inspect and lint) and offsets of query text.
+// ast doesn't keep positions, so tokens of both are aligned by longest common subsequence.
+type sourceMap struct {
+	src   []lexeme
+	dst   []lexeme
+	toSrc []int // index of aligned source lexeme of formatted lexeme, or -1
+	toDst []int // index of aligned formatted lexeme of source lexeme, or -1
+}
+
+func newSourceMap(source, formatted string) *sourceMap {
+	var m = &sourceMap{src: lexemes(source), dst: lexemes(formatted)}
+	var n, k = len(m.src), len(m.dst)
+	m.toSrc, m.toDst = make([]int, k), make([]int, n)
+	for i := range m.toSrc {
+		m.toSrc[i] = -1
+	}
+	for i := range m.toDst {
+		m.toDst[i] = -1
+	}
+	if (n+1)*(k+1) > maxAlignment {
+		return m
+	}
+	// lcs[i][j] is length of longest common subsequence of src[i:] and dst[j:]
+	var lcs = make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, k+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := k - 1; j >= 0; j-- {
+			if m.src[i].key == m.dst[j].key {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < n && j < k; {
+		if m.src[i].key == m.dst[j].key {
+			m.toDst[i], m.toSrc[j] = j, i
+			i, j = i+1, j+1
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return m
+}
+
+// source: convert span of formatted query to span of query text, ok is false if there isn't aligned token in span
+func (m *sourceMap) source(offset, end int) (int, int, bool) {
+	var start, stop, ok = 0, 0, false
+	for j, x := range m.dst {
+		if x.end <= offset || x.offset >= end || m.toSrc[j] < 0 {
+			continue
+		}
+		var y = m.src[m.toSrc[j]]
+		if !ok {
+			start, ok = y.offset, true
+		}
+		stop = y.end
+	}
+	return start, stop, ok
+}
+
+// formatted: convert offset of query text to offset of formatted query, ok is false if token at offset isn't aligned
+func (m *sourceMap) formatted(offset int) (int, bool) {
+	for i, x := range m.src {
+		if x.offset <= offset && offset < x.end && m.toDst[i] >= 0 {
+			var y = m.dst[m.toDst[i]]
+			if offset-x.offset >= y.end-y.offset {
+				// aligned tokens may have different length, i.e. `&&` and `AND`
+				return y.offset, true
+			}
+			return y.offset + offset - x.offset, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,262 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/participle"
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/highlight"
+	"github.com/zhuliquan/lucene_parser/lint"
+	"github.com/zhuliquan/lucene_parser/suggest"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// types of semantic tokens, they are indexes of tokenTypes
+var tokenTypes = []string{"property", "keyword", "variable", "string", "regexp", "operator", "modifier"}
+
+// semanticTokenTypes: semantic token types of highlight spans, error span isn't semantic token
+var semanticTokenTypes = map[highlight.SpanKind]int{
+	highlight.FIELD_SPAN:         0,
+	highlight.OPERATOR_SPAN:      1,
+	highlight.TERM_SPAN:          2,
+	highlight.PHRASE_SPAN:        3,
+	highlight.ESCAPE_SPAN:        3,
+	highlight.REGEXP_SPAN:        4,
+	highlight.RANGE_BRACKET_SPAN: 5,
+	highlight.MODIFIER_SPAN:      6,
+}
+
+const (
+	diagnosticSource = "lucene"
+	lintSource       = "lucene-lint"
+)
+
+// diagnostics: report parse errors, invalid terms and lint warnings of queries
+func diagnostics(d *document, rules []lint.Rule) []Diagnostic {
+	var res = []Diagnostic{}
+	for _, q := range d.queries() {
+		if q.err != nil {
+			res = append(res, Diagnostic{
+				Range: parseErrorRange(d, q), Severity: errorSeverity, Source: diagnosticSource, Message: parseErrorMessage(q.err),
+			})
+			continue
+		}
+		lucene_parser.Inspect(q.lucene, func(node interface{}, offset int) bool {
+			if x, ok := node.(*term.FuzzyTerm); ok {
+				if err := x.Validate(); err != nil {
+					res = append(res, Diagnostic{
+						Range:    sourceRange(d, q, offset, offset+len(x.String())),
+						Severity: errorSeverity, Source: diagnosticSource,
+						Message: fmt.Sprintf("invalid term %s: %v", x.String(), err),
+					})
+				}
+			}
+			return true
+		})
+		for _, w := range lint.Run(q.lucene, rules...) {
+			var msg = w.Message
+			if w.Fix != nil {
+				msg += " (fix: " + w.Fix.Message + ")"
+			}
+			res = append(res, Diagnostic{
+				Range: sourceRange(d, q, w.Offset, w.End), Severity: warningSeverity, Code: w.Rule, Source: lintSource, Message: msg,
+			})
+		}
+	}
+	return res
+}
+
+func parseErrorMessage(err error) string {
+	if x, ok := err.(participle.Error); ok {
+		return x.Message()
+	}
+	return err.Error()
+}
+
+// parseErrorRange: range of token of parse error, it's whole query if error doesn't have token
+func parseErrorRange(d *document, q *query) Range {
+	var x, ok = q.err.(participle.Error)
+	if !ok {
+		return d.span(q.line, q.start, q.start+len(q.text))
+	}
+	var offset = x.Token().Pos.Offset
+	if offset > len(q.text) {
+		offset = len(q.text)
+	}
+	var end = offset + len(x.Token().Value)
+	if end > len(q.text) {
+		end = len(q.text)
+	}
+	return d.span(q.line, q.start+offset, q.start+end)
+}
+
+// sourceRange: convert span of formatted query to range of document, it's whole query if span can't be mapped
+func sourceRange(d *document, q *query, offset, end int) Range {
+	var start, stop, ok = q.sourceMap().source(offset, end)
+	if !ok {
+		start, stop = 0, len(q.text)
+	}
+	return d.span(q.line, q.start+start, q.start+stop)
+}
+
+// hover: describe field query at position, i.e. kind, bound and boost of term
+func hover(d *document, p Position) *Hover {
+	var q = d.query(p.Line)
+	if q == nil || q.err != nil {
+		return nil
+	}
+	var offset, ok = q.sourceMap().formatted(byteOffset(d.lines[p.Line], p.Character) - q.start)
+	if !ok {
+		return nil
+	}
+	var res *Hover
+	lucene_parser.Inspect(q.lucene, func(node interface{}, start int) bool {
+		var x, ok = node.(*lucene_parser.FieldQuery)
+		if !ok || res != nil {
+			return res == nil
+		}
+		var s = x.String()
+		if start <= offset && offset < start+len(s) {
+			var r = sourceRange(d, q, start, start+len(s))
+			res = &Hover{Contents: MarkupContent{Kind: markdownMarkupKind, Value: describe(x)}, Range: &r}
+		}
+		return false
+	})
+	return res
+}
+
+// describe: markdown description of field query
+func describe(q *lucene_parser.FieldQuery) string {
+	var sb = strings.Builder{}
+	var t = q.Term
+	fmt.Fprintf(&sb, "`%s`\n\n", q.String())
+	fmt.Fprintf(&sb, "- field: `%s`\n", q.Field.String())
+	fmt.Fprintf(&sb, "- kind: %s\n", termKind(t.GetTermType()))
+	if b := t.GetBound(); b != nil {
+		fmt.Fprintf(&sb, "- bound: %s\n", bound(b))
+	}
+	if f := t.Fuzziness(); f == term.AutoFuzzy {
+		sb.WriteString("- fuzziness: AUTO\n")
+	} else if f != term.NoFuzzy {
+		fmt.Fprintf(&sb, "- fuzziness: %g\n", f.Float())
+	}
+	fmt.Fprintf(&sb, "- boost: %g\n", t.Boost().Float())
+	return sb.String()
+}
+
+var termKinds = []struct {
+	typ  term.TermType
+	name string
+}{
+	{term.SINGLE_TERM_TYPE, "single"},
+	{term.PHRASE_TERM_TYPE, "phrase"},
+	{term.REGEXP_TERM_TYPE, "regexp"},
+	{term.RANGE_TERM_TYPE, "range"},
+	{term.WILDCARD_TERM_TYPE, "wildcard"},
+	{term.GROUP_TERM_TYPE, "group"},
+	{term.FUZZY_TERM_TYPE, "fuzzy"},
+	{term.BOOST_TERM_TYPE, "boost"},
+	{term.EXISTS_TERM_TYPE, "exists"},
+}
+
+// termKind: names of flags of term type, i.e. "single, wildcard"
+func termKind(typ term.TermType) string {
+	var names = []string{}
+	for _, k := range termKinds {
+		if typ&k.typ != 0 {
+			names = append(names, k.name)
+		}
+	}
+	if len(names) == 0 {
+		return "unknown"
+	}
+	return strings.Join(names, ", ")
+}
+
+// bound: interval notation of bound, i.e. `[1, 2)`
+func bound(b *term.Bound) string {
+	var l, r = "(", ")"
+	if b.LeftInclude {
+		l = "["
+	}
+	if b.RightInclude {
+		r = "]"
+	}
+	return l + b.LeftValue.String() + ", " + b.RightValue.String() + r
+}
+
+// format: replace queries which can be parsed with formatted queries
+func format(d *document) []TextEdit {
+	var res = []TextEdit{}
+	for _, q := range d.queries() {
+		if q.err != nil {
+			continue
+		}
+		if s := q.lucene.String(); s != q.text {
+			res = append(res, TextEdit{Range: d.span(q.line, q.start, q.start+len(q.text)), NewText: s})
+		}
+	}
+	return res
+}
+
+// semanticTokens: encode highlight spans of all lines as relative positions
+func semanticTokens(d *document) *SemanticTokens {
+	var res = &SemanticTokens{Data: []int{}}
+	var prevLine, prevChar = 0, 0
+	for i, line := range d.lines {
+		var text = strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+		var start = strings.Index(line, text)
+		for _, s := range highlight.Highlight(text) {
+			var typ, ok = semanticTokenTypes[s.Kind]
+			if !ok {
+				continue
+			}
+			var char = charOffset(line, start+s.Start)
+			var length = charOffset(line, start+s.End) - char
+			if i != prevLine {
+				prevChar = 0
+			}
+			res.Data = append(res.Data, i-prevLine, char-prevChar, length, typ, 0)
+			prevLine, prevChar = i, char
+		}
+	}
+	return res
+}
+
+var completionKinds = map[suggest.SuggestionKind]struct {
+	kind   int
+	detail string
+}{
+	suggest.FIELD_SUGGESTION:    {fieldCompletion, "field"},
+	suggest.OPERATOR_SUGGESTION: {keywordCompletion, "operator"},
+	suggest.BRACKET_SUGGESTION:  {textCompletion, "bracket"},
+	suggest.VALUE_SUGGESTION:    {valueCompletion, "value"},
+}
+
+// complete: complete field names of schema, operators and closing brackets at position by suggest package
+func complete(d *document, p Position, fields []string) *CompletionList {
+	var res = &CompletionList{Items: []CompletionItem{}}
+	if p.Line >= len(d.lines) {
+		return res
+	}
+	var line = d.lines[p.Line]
+	var offset = byteOffset(line, p.Character)
+	// leading whitespaces can't be parsed
+	var start = len(line[:offset]) - len(strings.TrimLeft(line[:offset], " \t"))
+	var text = strings.TrimRight(line[start:], " \t")
+	if offset-start > len(text) {
+		text = line[start:offset]
+	}
+	for _, x := range suggest.Complete(text, offset-start, suggest.Options{Fields: fields}) {
+		var k = completionKinds[x.Kind]
+		res.Items = append(res.Items, CompletionItem{
+			Label: strings.TrimSuffix(strings.TrimSpace(x.Text), ":"), Kind: k.kind, Detail: k.detail,
+			TextEdit: &TextEdit{Range: d.span(p.Line, start+x.Start, start+x.End), NewText: x.Text},
+		})
+	}
+	return res
+}
@@ -0,0 +1,192 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// session: run server with messages and return messages written by server
+func session(t *testing.T, opts Options, msgs ...string) ([]*message, error) {
+	var in = &bytes.Buffer{}
+	for _, m := range msgs {
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	var out = &bytes.Buffer{}
+	var err = NewServer(opts).Serve(in, out)
+	var res = []*message{}
+	var r = bufio.NewReader(out)
+	for {
+		var data, e = readMessage(r)
+		if e == io.EOF {
+			break
+		}
+		assert.Nil(t, e)
+		var msg = &message{}
+		assert.Nil(t, json.Unmarshal(data, msg))
+		res = append(res, msg)
+	}
+	return res, err
+}
+
+func request(id int, method string, params string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":%s}`, id, method, params)
+}
+
+func notification(method string, params string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":%q,"params":%s}`, method, params)
+}
+
+const uri = "file:///alerts.lucene"
+
+func didOpen(text string) string {
+	var data, _ = json.Marshal(text)
+	return notification("textDocument/didOpen", fmt.Sprintf(`{"textDocument":{"uri":%q,"languageId":"lucene","version":1,"text":%s}}`, uri, data))
+}
+
+func position(line, char int) string {
+	return fmt.Sprintf(`{"textDocument":{"uri":%q},"position":{"line":%d,"character":%d}}`, uri, line, char)
+}
+
+func TestServe(t *testing.T) {
+	var msgs, err = session(t, Options{Fields: []string{"status"}},
+		request(1, "initialize", `{"initializationOptions":{"fields":["host","host.name"]}}`),
+		notification("initialized", `{}`),
+		didOpen("x:1 and y:foo~3\nx:(1\n\n  z:[1 TO 2]^2\nho"),
+		request(2, "textDocument/hover", position(3, 5)),
+		request(3, "textDocument/hover", position(2, 0)),
+		request(4, "textDocument/formatting", fmt.Sprintf(`{"textDocument":{"uri":%q}}`, uri)),
+		request(5, "textDocument/semanticTokens/full", fmt.Sprintf(`{"textDocument":{"uri":%q}}`, uri)),
+		request(6, "textDocument/completion", position(4, 2)),
+		notification("textDocument/didChange", fmt.Sprintf(`{"textDocument":{"uri":%q,"version":2},"contentChanges":[{"text":"x:1"}]}`, uri)),
+		request(7, "foo/bar", `{}`),
+		request(8, "shutdown", `null`),
+		notification("exit", `null`),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(msgs))
+
+	var result = func(i int, v interface{}) {
+		assert.Nil(t, msgs[i].Error)
+		assert.Nil(t, json.Unmarshal(msgs[i].Result, v))
+	}
+	var init = &InitializeResult{}
+	result(0, init)
+	assert.Equal(t, tokenTypes, init.Capabilities.SemanticTokensProvider.Legend.TokenTypes)
+
+	assert.Equal(t, "textDocument/publishDiagnostics", msgs[1].Method)
+	var diags = &PublishDiagnosticsParams{}
+	assert.Nil(t, json.Unmarshal(msgs[1].Params, diags))
+	var got = []string{}
+	for _, d := range diags.Diagnostics {
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d %d %s %s", d.Range.Start.Line, d.Range.Start.Character,
+			d.Range.End.Line, d.Range.End.Character, d.Severity, d.Code, d.Message))
+	}
+	assert.Equal(t, []string{
+		"0:10-0:15 1  invalid term foo~3: edit distance must be 0 ~ 2 or similarity between 0 and 1: ~3",
+		"0:4-0:7 2 lowercase-operator lowercase \"and\" is used as operator AND, quote it if it's a word (fix: write AND)",
+		"1:4-1:4 1  unexpected token \"<EOF>\" (expected <rparen>)",
+		"4:2-4:2 1  unexpected token \"<EOF>\" (expected <colon>)",
+	}, got)
+
+	var h = &Hover{}
+	result(2, h)
+	assert.Equal(t, "`z:[ 1 TO 2 ]^2`\n\n- field: `z`\n- kind: range, boost\n- bound: [1, 2]\n- boost: 2\n", h.Contents.Value)
+	assert.Equal(t, Range{Start: Position{3, 2}, End: Position{3, 14}}, *h.Range)
+	assert.Equal(t, "null", string(msgs[3].Result))
+
+	var edits = []TextEdit{}
+	result(4, &edits)
+	assert.Equal(t, []TextEdit{
+		{Range: Range{Start: Position{0, 0}, End: Position{0, 15}}, NewText: "x:1 AND y:foo~3"},
+		{Range: Range{Start: Position{3, 2}, End: Position{3, 14}}, NewText: "z:[ 1 TO 2 ]^2"},
+	}, edits)
+
+	var tokens = &SemanticTokens{}
+	result(5, tokens)
+	// types are indexes of tokenTypes: property, keyword, variable, string, regexp, operator and modifier
+	assert.Equal(t, []int{
+		0, 0, 1, 0, 0, 0, 2, 1, 2, 0, 0, 2, 3, 1, 0, 0, 4, 1, 0, 0, 0, 2, 3, 2, 0, 0, 3, 2, 6, 0,
+		1, 0, 1, 0, 0, 0, 3, 1, 2, 0,
+		2, 2, 1, 0, 0, 0, 2, 1, 5, 0, 0, 1, 1, 2, 0, 0, 2, 2, 1, 0, 0, 3, 1, 2, 0, 0, 1, 1, 5, 0, 0, 1, 2, 6, 0,
+		1, 0, 2, 2, 0,
+	}, tokens.Data)
+
+	var list = &CompletionList{}
+	result(6, list)
+	assert.Equal(t, []CompletionItem{
+		{Label: "host", Kind: fieldCompletion, Detail: "field", TextEdit: &TextEdit{Range: Range{Start: Position{4, 0}, End: Position{4, 2}}, NewText: "host:"}},
+		{Label: "host.name", Kind: fieldCompletion, Detail: "field", TextEdit: &TextEdit{Range: Range{Start: Position{4, 0}, End: Position{4, 2}}, NewText: "host.name:"}},
+	}, list.Items)
+
+	diags = &PublishDiagnosticsParams{}
+	assert.Nil(t, json.Unmarshal(msgs[7].Params, diags))
+	assert.Equal(t, 2, diags.Version)
+	assert.Equal(t, []Diagnostic{}, diags.Diagnostics)
+
+	assert.Equal(t, methodNotFoundCode, msgs[8].Error.Code)
+	assert.Equal(t, "null", string(msgs[9].Result))
+}
+
+func TestServeLifecycle(t *testing.T) {
+	var msgs, err = session(t, Options{}, request(1, "textDocument/hover", position(0, 0)), notification("exit", `null`))
+	assert.Equal(t, ErrExitWithoutShutdown, err)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, serverNotInitializedCode, msgs[0].Error.Code)
+
+	msgs, err = session(t, Options{}, request(1, "initialize", `{}`), request(2, "textDocument/hover", position(0, 0)), `{bad json`)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(msgs))
+	assert.Equal(t, invalidParamsCode, msgs[1].Error.Code)
+	assert.Equal(t, parseErrorCode, msgs[2].Error.Code)
+}
+
+func TestSourceMap(t *testing.T) {
+	type testCase struct {
+		name      string
+		source    string
+		formatted string
+		span      [2]int
+		want      string
+	}
+	var testCases = []testCase{
+		{name: "test_lowercase_operator", source: "a:1 and  b:2", formatted: "a:1 AND b:2", span: [2]int{4, 7}, want: "and"},
+		{name: "test_symbol_operator", source: "a:1&&!b:2", formatted: "a:1 AND NOT b:2", span: [2]int{4, 11}, want: "&&!"},
+		{name: "test_inserted_operator", source: "a:1 NOT b:[1 TO 2]", formatted: "a:1 AND NOT b:[ 1 TO 2 ]", span: [2]int{14, 24}, want: "[1 TO 2]"},
+		{name: "test_unicode", source: `a:"北京"  OR b:1`, formatted: `a:"北京" OR b:1`, span: [2]int{11, 13}, want: "OR"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var m = newSourceMap(tt.source, tt.formatted)
+			var start, end, ok = m.source(tt.span[0], tt.span[1])
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, tt.source[start:end])
+			var offset, _ = m.formatted(start)
+			assert.Equal(t, tt.span[0], offset)
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	var fields = []string{"host", "status", "status_code"}
+	var d = newDocument(uri, 1, "sta\nx:1 AND -st\nx:st\nx:\"a st\nx:(st")
+	var labels = func(line, char int) []string {
+		var res = []string{}
+		for _, x := range complete(d, Position{line, char}, fields).Items {
+			res = append(res, x.Label)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"status", "status_code"}, labels(0, 3))
+	assert.Equal(t, []string{"host", "status", "status_code", "NOT"}, labels(0, 0))
+	assert.Equal(t, []string{"status", "status_code"}, labels(1, 12))
+	assert.Equal(t, []string{}, labels(2, 4))
+	assert.Equal(t, []string{}, labels(3, 7))
+	// term of term group isn't field
+	assert.Equal(t, []string{")"}, labels(4, 5))
+}
@@ -0,0 +1,289 @@
+// Package lsp: language server of `.lucene` files (every non-blank line is one query) which communicates with client
+// by JSON-RPC over stdio. it publishes diagnostics of parse errors and lint warnings, and provides hover of terms,
+// formatting, semantic tokens and completion of field names of schema.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/zhuliquan/lucene_parser/lint"
+)
+
+var ErrExitWithoutShutdown = fmt.Errorf("exit notification is received before shutdown request")
+
+// Options: options of server
+type Options struct {
+	Fields []string    // field names of schema which are completed
+	Rules  []lint.Rule // lint rules of diagnostics, lint.DefaultRules is used if it's empty
+}
+
+// Server: language server, documents are kept in memory and analyzed when they are opened or changed
+type Server struct {
+	opts        Options
+	docs        map[string]*document
+	w           io.Writer
+	mu          sync.Mutex // protects w
+	initialized bool
+	shutdown    bool
+	exited      bool
+}
+
+func NewServer(opts Options) *Server {
+	return &Server{opts: opts, docs: map[string]*document{}}
+}
+
+type handler func(s *Server, params json.RawMessage) (interface{}, error)
+
+var handlers = map[string]handler{
+	"initialize":                          (*Server).initialize,
+	"initialized":                         (*Server).ignore,
+	"shutdown":                            (*Server).shutdownRequest,
+	"exit":                                (*Server).exit,
+	"textDocument/didOpen":                (*Server).didOpen,
+	"textDocument/didChange":              (*Server).didChange,
+	"textDocument/didClose":               (*Server).didClose,
+	"textDocument/hover":                  (*Server).hover,
+	"textDocument/formatting":             (*Server).formatting,
+	"textDocument/semanticTokens/full":    (*Server).semanticTokens,
+	"textDocument/completion":             (*Server).completion,
+	"workspace/didChangeConfiguration":    (*Server).ignore,
+	"textDocument/didSave":                (*Server).ignore,
+	"$/cancelRequest":                     (*Server).ignore,
+	"$/setTrace":                          (*Server).ignore,
+	"workspace/didChangeWatchedFiles":     (*Server).ignore,
+	"workspace/didChangeWorkspaceFolders": (*Server).ignore,
+}
+
+// Serve: read messages from r and write responses and notifications to w until exit notification is received or r is closed.
+// ErrExitWithoutShutdown is returned if exit notification is received before shutdown request.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.w = w
+	var br = bufio.NewReader(r)
+	for !s.exited {
+		var data, err = readMessage(br)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var msg = &message{}
+		if err := json.Unmarshal(data, msg); err != nil {
+			if err := s.reply(json.RawMessage("null"), nil, &rpcError{Code: parseErrorCode, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+	if !s.shutdown {
+		return ErrExitWithoutShutdown
+	}
+	return nil
+}
+
+// handle: dispatch message to handler, responses aren't sent for notifications (message without id)
+func (s *Server) handle(msg *message) error {
+	var notification = len(msg.ID) == 0
+	var h, ok = handlers[msg.Method]
+	var result interface{}
+	var err error
+	switch {
+	case !ok:
+		err = &rpcError{Code: methodNotFoundCode, Message: fmt.Sprintf("method %q isn't supported", msg.Method)}
+	case !s.initialized && msg.Method != "initialize" && msg.Method != "exit":
+		err = &rpcError{Code: serverNotInitializedCode, Message: "server isn't initialized"}
+	case s.shutdown && msg.Method != "exit":
+		err = &rpcError{Code: invalidRequestCode, Message: "server is shut down"}
+	default:
+		result, err = h(s, msg.Params)
+	}
+	if notification {
+		return nil
+	}
+	if err != nil {
+		var e, ok = err.(*rpcError)
+		if !ok {
+			e = &rpcError{Code: internalErrorCode, Message: err.Error()}
+		}
+		return s.reply(msg.ID, nil, e)
+	}
+	return s.reply(msg.ID, result, nil)
+}
+
+func (s *Server) reply(id json.RawMessage, result interface{}, e *rpcError) error {
+	var msg = &message{JSONRPC: "2.0", ID: id, Error: e}
+	if e == nil {
+		var data, err = json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		msg.Result = data
+	}
+	return s.send(msg)
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	var data, err = json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.send(&message{JSONRPC: "2.0", Method: method, Params: data})
+}
+
+func (s *Server) send(msg *message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeMessage(s.w, msg)
+}
+
+func unmarshal(params json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: invalidParamsCode, Message: err.Error()}
+	}
+	return nil
+}
+
+// document: return opened document of uri
+func (s *Server) document(uri string) (*document, error) {
+	if d, ok := s.docs[uri]; ok {
+		return d, nil
+	}
+	return nil, &rpcError{Code: invalidParamsCode, Message: fmt.Sprintf("document %q isn't opened", uri)}
+}
+
+func (s *Server) ignore(params json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
+
+func (s *Server) initialize(params json.RawMessage) (interface{}, error) {
+	var p = &InitializeParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	if p.InitializationOptions != nil {
+		s.opts.Fields = append(append([]string{}, s.opts.Fields...), p.InitializationOptions.Fields...)
+	}
+	s.initialized = true
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:           TextDocumentSyncOptions{OpenClose: true, Change: fullSync},
+			HoverProvider:              true,
+			DocumentFormattingProvider: true,
+			CompletionProvider:         CompletionOptions{TriggerCharacters: []string{"(", " "}},
+			SemanticTokensProvider: SemanticTokensOptions{
+				Legend: SemanticTokensLegend{TokenTypes: tokenTypes, TokenModifiers: []string{}},
+				Full:   true,
+			},
+		},
+		ServerInfo: ServerInfo{Name: "lucene-lsp"},
+	}, nil
+}
+
+func (s *Server) shutdownRequest(params json.RawMessage) (interface{}, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) exit(params json.RawMessage) (interface{}, error) {
+	s.exited = true
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) (interface{}, error) {
+	var p = &DidOpenTextDocumentParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d = newDocument(p.TextDocument.URI, p.TextDocument.Version, p.TextDocument.Text)
+	s.docs[d.uri] = d
+	return nil, s.publish(d)
+}
+
+func (s *Server) didChange(params json.RawMessage) (interface{}, error) {
+	var p = &DidChangeTextDocumentParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d, err = s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range p.ContentChanges {
+		d.apply(c)
+	}
+	d.version = p.TextDocument.Version
+	return nil, s.publish(d)
+}
+
+func (s *Server) didClose(params json.RawMessage) (interface{}, error) {
+	var p = &DidCloseTextDocumentParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	delete(s.docs, p.TextDocument.URI)
+	// clear diagnostics of closed document
+	return nil, s.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+}
+
+func (s *Server) publish(d *document) error {
+	return s.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI: d.uri, Version: d.version, Diagnostics: diagnostics(d, s.opts.Rules),
+	})
+}
+
+func (s *Server) hover(params json.RawMessage) (interface{}, error) {
+	var p = &TextDocumentPositionParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d, err = s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	if h := hover(d, p.Position); h != nil {
+		return h, nil
+	}
+	return nil, nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (interface{}, error) {
+	var p = &DocumentFormattingParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d, err = s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	return format(d), nil
+}
+
+func (s *Server) semanticTokens(params json.RawMessage) (interface{}, error) {
+	var p = &SemanticTokensParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d, err = s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	return semanticTokens(d), nil
+}
+
+func (s *Server) completion(params json.RawMessage) (interface{}, error) {
+	var p = &TextDocumentPositionParams{}
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	var d, err = s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	return complete(d, p.Position, s.opts.Fields), nil
+}
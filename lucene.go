@@ -1,6 +1,7 @@
 package lucene_parser
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -16,21 +17,41 @@ func init() {
 	LuceneParser = participle.MustBuild(
 		&Lucene{},
 		participle.Lexer(tk.Lexer),
+		// group of fields (i.e. `(x OR y):1`) and paren query both start with LPAREN, so that parser has to look
+		// ahead to COLON behind of group before choosing branch. lookahead doesn't slow down parsing of queries
+		// without group of fields (see BenchmarkLookahead).
+		participle.UseLookahead(1024),
 	)
 }
 
-// ParseLucene: parse query to Lucene struct
+// defaultParser: parser without options which is used by ParseLucene
+var defaultParser = NewParser()
+
+// ParseLucene: parse query to Lucene struct, it's safe for concurrent use
 func ParseLucene(queryString string) (*Lucene, error) {
-	var (
-		err error
-		lqy = &Lucene{}
-	)
+	return defaultParser.Parse(queryString)
+}
+
+// contextParser: parser of ParseLuceneContext, descent parser doesn't panic and can be interrupted
+var contextParser = NewParser(WithEngine(DESCENT_ENGINE), WithLimits(DefaultParseLimits))
+
+// ParseLuceneContext: parse untrusted query to Lucene struct with DefaultParseLimits, ErrQueryTooLong, ErrTooManyTokens
+// or ErrTooDeep is returned as soon as lexer or parser exceeds limits, and error of ctx is returned if ctx is canceled
+// or deadline is exceeded. use NewParser and Parser.ParseContext for other limits.
+func ParseLuceneContext(ctx context.Context, queryString string) (*Lucene, error) {
+	return contextParser.ParseContext(ctx, queryString)
+}
+
+// parseParticiple: parse query by LuceneParser
+func parseParticiple(queryString string) (lqy *Lucene, err error) {
+	// results are named, so that panic of participle is returned as error
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("failed to parse lucene, err: %+v", r)
+			lqy, err = nil, fmt.Errorf("failed to parse lucene, err: %+v", r)
 		}
 	}()
 
+	lqy = &Lucene{}
 	if err = LuceneParser.ParseString(queryString, lqy); err != nil {
 		return nil, err
 	} else {
@@ -38,6 +59,19 @@ func ParseLucene(queryString string) (*Lucene, error) {
 	}
 }
 
+// Engine: implementation of parser, all engines parse query to the same Lucene struct
+type Engine uint32
+
+const (
+	PARTICIPLE_ENGINE Engine = iota // LuceneParser which is built from struct tags by participle
+	DESCENT_ENGINE                  // hand-written lexer and recursive descent parser, it's faster and allocates less
+)
+
+// ParseLuceneWithEngine: parse query to Lucene struct by engine, ParseLucene is the same as PARTICIPLE_ENGINE
+func ParseLuceneWithEngine(queryString string, engine Engine) (*Lucene, error) {
+	return NewParser(WithEngine(engine)).Parse(queryString)
+}
+
 type Query interface {
 	String() string
 	GetQueryType() QueryType
@@ -45,8 +79,8 @@ type Query interface {
 
 // Lucene: consist of or query and or symbol query
 type Lucene struct {
-	OrQuery *OrQuery   `parser:"@@" json:"or_query"`
-	OSQuery []*OSQuery `parser:"@@*" json:"or_sym_query"`
+	OrQuery *OrQuery   `parser:"@@" json:"or_query,omitempty"`
+	OSQuery []*OSQuery `parser:"@@*" json:"or_sym_query,omitempty"`
 }
 
 func (q *Lucene) GetQueryType() QueryType {
@@ -69,8 +103,8 @@ func (q *Lucene) String() string {
 
 // OrQuery: consist of and query and and_symbol_query
 type OrQuery struct {
-	AndQuery *AndQuery   `parser:"@@" json:"and_query"`
-	AnSQuery []*AnSQuery `parser:"@@*" json:"and_sym_query" `
+	AndQuery *AndQuery   `parser:"@@" json:"and_query,omitempty"`
+	AnSQuery []*AnSQuery `parser:"@@*" json:"and_sym_query,omitempty" `
 }
 
 func (q *OrQuery) GetQueryType() QueryType {
@@ -91,8 +125,8 @@ func (q *OrQuery) String() string {
 
 // OSQuery: OSQuery (or symbol query) is or query which is prefix with or symbol
 type OSQuery struct {
-	OrSymbol *op.OrSymbol `parser:"@@" json:"or_symbol"`
-	OrQuery  *OrQuery     `parser:"@@" json:"or_query"`
+	OrSymbol *op.OrSymbol `parser:"@@" json:"or_symbol,omitempty"`
+	OrQuery  *OrQuery     `parser:"@@" json:"or_query,omitempty"`
 }
 
 func (q *OSQuery) GetQueryType() QueryType {
@@ -109,9 +143,9 @@ func (q *OSQuery) String() string {
 
 // AndQuery: consist of not query and paren query and field_query
 type AndQuery struct {
-	NotSymbol  *op.NotSymbol `parser:"  @@?" json:"not_symbol"`
-	ParenQuery *ParenQuery   `parser:"( @@ " json:"paren_query"`
-	FieldQuery *FieldQuery   `parser:"| @@)" json:"field_query"`
+	NotSymbol  *op.NotSymbol `parser:"  @@?" json:"not_symbol,omitempty"`
+	ParenQuery *ParenQuery   `parser:"( @@ " json:"paren_query,omitempty"`
+	FieldQuery *FieldQuery   `parser:"| @@)" json:"field_query,omitempty"`
 }
 
 func (q *AndQuery) GetQueryType() QueryType {
@@ -135,9 +169,9 @@ func (q *AndQuery) String() string {
 
 // AnsQuery: AnSQuery (and symbol query) is AndQuery which be prefix with and symbol ('AND' / 'and' / '&&' )
 type AnSQuery struct {
-	AndSymbol *op.AndSymbol `parser:"( @@ " json:"and_symbol"`
-	NotSymbol *op.NotSymbol `parser:"| WHITESPACE+ @@)" json:"not_symbol"`
-	AndQuery  *AndQuery     `parser:"@@" json:"and_query"`
+	AndSymbol *op.AndSymbol `parser:"( @@ " json:"and_symbol,omitempty"`
+	NotSymbol *op.NotSymbol `parser:"| WHITESPACE+ @@)" json:"not_symbol,omitempty"`
+	AndQuery  *AndQuery     `parser:"@@" json:"and_query,omitempty"`
 }
 
 func (q *AnSQuery) GetQueryType() QueryType {
@@ -161,7 +195,7 @@ func (q *AnSQuery) String() string {
 
 // ParenQuery: lucene query is surround with paren
 type ParenQuery struct {
-	SubQuery *Lucene `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_query"`
+	SubQuery *Lucene `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_query,omitempty"`
 }
 
 func (q *ParenQuery) GetQueryType() QueryType {
@@ -178,8 +212,8 @@ func (q *ParenQuery) String() string {
 
 // FieldQuery: consist of field and term
 type FieldQuery struct {
-	Field *tm.Field `parser:"@@ COLON" json:"field"`
-	Term  *tm.Term  `parser:"@@" json:"term"`
+	Field *tm.Field `parser:"@@ COLON" json:"field,omitempty"`
+	Term  *tm.Term  `parser:"@@" json:"term,omitempty"`
 }
 
 func (q *FieldQuery) GetQueryType() QueryType {
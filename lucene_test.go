@@ -1,11 +1,14 @@
 package lucene_parser
 
 import (
+	"strconv"
 	"testing"
 
+	"github.com/alecthomas/participle"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhuliquan/lucene_parser/operator"
 	"github.com/zhuliquan/lucene_parser/term"
+	"github.com/zhuliquan/lucene_parser/token"
 )
 
 func TestLucene(t *testing.T) {
@@ -27,7 +30,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "1"},
+								SingleTerm: term.NewSingleTerm("1"),
 							}},
 						},
 					},
@@ -38,7 +41,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"x"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: "2"},
+										SingleTerm: term.NewSingleTerm("2"),
 									}},
 								},
 							},
@@ -59,7 +62,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "1"},
+								SingleTerm: term.NewSingleTerm("1"),
 							}},
 						},
 					},
@@ -70,7 +73,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"x"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: "2"},
+										SingleTerm: term.NewSingleTerm("2"),
 									}},
 								},
 							},
@@ -90,7 +93,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "1"},
+								SingleTerm: term.NewSingleTerm("1"),
 							}},
 						},
 					},
@@ -102,7 +105,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"x"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: "2"},
+										SingleTerm: term.NewSingleTerm("2"),
 									}},
 								},
 							},
@@ -127,7 +130,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"x"}},
 											Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "1"},
+												SingleTerm: term.NewSingleTerm("1"),
 											}},
 										},
 									},
@@ -138,7 +141,7 @@ func TestLucene(t *testing.T) {
 												FieldQuery: &FieldQuery{
 													Field: &term.Field{Value: []string{"y"}},
 													Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-														SingleTerm: &term.SingleTerm{Begin: "2"},
+														SingleTerm: term.NewSingleTerm("2"),
 													}},
 												},
 											},
@@ -157,7 +160,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"z"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: "9"},
+										SingleTerm: term.NewSingleTerm("9"),
 									}},
 								},
 							},
@@ -181,7 +184,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"x"}},
 											Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "1"},
+												SingleTerm: term.NewSingleTerm("1"),
 											}},
 										},
 									},
@@ -193,7 +196,7 @@ func TestLucene(t *testing.T) {
 												FieldQuery: &FieldQuery{
 													Field: &term.Field{Value: []string{"y"}},
 													Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-														SingleTerm: &term.SingleTerm{Begin: "2"},
+														SingleTerm: term.NewSingleTerm("2"),
 													}},
 												},
 											},
@@ -215,7 +218,7 @@ func TestLucene(t *testing.T) {
 												FieldQuery: &FieldQuery{
 													Field: &term.Field{Value: []string{"x"}},
 													Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-														SingleTerm: &term.SingleTerm{Begin: "8"},
+														SingleTerm: term.NewSingleTerm("8"),
 													}},
 												},
 											},
@@ -226,7 +229,7 @@ func TestLucene(t *testing.T) {
 														FieldQuery: &FieldQuery{
 															Field: &term.Field{Value: []string{"k"}},
 															Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-																SingleTerm: &term.SingleTerm{Begin: "90"},
+																SingleTerm: term.NewSingleTerm("90"),
 															}},
 														},
 													},
@@ -256,7 +259,7 @@ func TestLucene(t *testing.T) {
 									OrTermGroup: &term.OrTermGroup{
 										AndTermGroup: &term.AndTermGroup{
 											FieldTermGroup: &term.FieldTermGroup{
-												SingleTerm: &term.SingleTerm{Begin: "txt"},
+												SingleTerm: term.NewSingleTerm("txt"),
 											},
 										},
 									},
@@ -266,7 +269,7 @@ func TestLucene(t *testing.T) {
 											OrTermGroup: &term.OrTermGroup{
 												AndTermGroup: &term.AndTermGroup{
 													FieldTermGroup: &term.FieldTermGroup{
-														SingleTerm: &term.SingleTerm{Begin: "foo"},
+														SingleTerm: term.NewSingleTerm("foo"),
 													},
 												},
 											},
@@ -276,7 +279,7 @@ func TestLucene(t *testing.T) {
 											OrTermGroup: &term.OrTermGroup{
 												AndTermGroup: &term.AndTermGroup{
 													FieldTermGroup: &term.FieldTermGroup{
-														SingleTerm: &term.SingleTerm{Begin: "bar"},
+														SingleTerm: term.NewSingleTerm("bar"),
 													},
 												},
 											},
@@ -310,7 +313,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"zz"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: `iopio`, Chars: []string{`\ `, `90`}},
+										SingleTerm: term.NewSingleTerm(`iopio\ 90`),
 									}},
 								},
 							},
@@ -337,12 +340,12 @@ func TestLucene(t *testing.T) {
 											Term: &term.Term{
 												TermGroup: &term.TermGroup{
 													LogicTermGroup: &term.LogicTermGroup{
-														OrTermGroup: &term.OrTermGroup{AndTermGroup: &term.AndTermGroup{FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: "foo"}}}},
+														OrTermGroup: &term.OrTermGroup{AndTermGroup: &term.AndTermGroup{FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm("foo")}}},
 														OSTermGroup: []*term.OSTermGroup{
 															{
 																OrSymbol: &operator.OrSymbol{Symbol: "or"},
 																OrTermGroup: &term.OrTermGroup{
-																	AndTermGroup: &term.AndTermGroup{FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: "bar"}}},
+																	AndTermGroup: &term.AndTermGroup{FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm("bar")}},
 																},
 															},
 														},
@@ -362,7 +365,7 @@ func TestLucene(t *testing.T) {
 								FieldQuery: &FieldQuery{
 									Field: &term.Field{Value: []string{"z"}},
 									Term: &term.Term{FuzzyTerm: &term.FuzzyTerm{
-										SingleTerm: &term.SingleTerm{Begin: `you`},
+										SingleTerm: term.NewSingleTerm(`you`),
 									}},
 								},
 							},
@@ -508,3 +511,28 @@ func TestWrongLuceneString(t *testing.T) {
 	var p *ParenQuery
 	assert.Equal(t, "", p.String())
 }
+
+// BenchmarkLookahead: cost of lookahead of LuceneParser, which is needed by group of fields (i.e. `(x OR y):1`),
+// compared with parser without lookahead on queries which can be parsed by both.
+func BenchmarkLookahead(b *testing.B) {
+	var noLookahead = participle.MustBuild(&Lucene{}, participle.Lexer(token.Lexer))
+	for _, parser := range []struct {
+		name   string
+		parser *participle.Parser
+	}{{"lookahead", LuceneParser}, {"no_lookahead", noLookahead}} {
+		for i, query := range []string{
+			`x:1`,
+			`status:active AND age:[18 TO 65} AND NOT name:"john \"doe\""~2`,
+			`(x:1 OR y:(a OR b)^2) AND NOT (z:>=2020-01-01 || url:/https?:\/\/.*/)`,
+		} {
+			b.Run(parser.name+"_"+strconv.Itoa(i), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					if err := parser.parser.ParseString(query, &Lucene{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package operator
+
+import (
+	"github.com/zhuliquan/lucene_parser/internal/codec"
+)
+
+// json encoding of operator is an object with "type" of operator, for instance {"type":"and_symbol","symbol":"&&"}
+func (o *AndSymbol) MarshalJSON() ([]byte, error) {
+	type alias AndSymbol
+	return codec.MarshalNode("and_symbol", (*alias)(o))
+}
+
+func (o *AndSymbol) UnmarshalJSON(data []byte) error {
+	type alias AndSymbol
+	return codec.UnmarshalNode(data, "and_symbol", (*alias)(o))
+}
+
+func (o *OrSymbol) MarshalJSON() ([]byte, error) {
+	type alias OrSymbol
+	return codec.MarshalNode("or_symbol", (*alias)(o))
+}
+
+func (o *OrSymbol) UnmarshalJSON(data []byte) error {
+	type alias OrSymbol
+	return codec.UnmarshalNode(data, "or_symbol", (*alias)(o))
+}
+
+func (o *NotSymbol) MarshalJSON() ([]byte, error) {
+	type alias NotSymbol
+	return codec.MarshalNode("not_symbol", (*alias)(o))
+}
+
+func (o *NotSymbol) UnmarshalJSON(data []byte) error {
+	type alias NotSymbol
+	return codec.UnmarshalNode(data, "not_symbol", (*alias)(o))
+}
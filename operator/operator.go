@@ -21,6 +21,11 @@ func (o *AndSymbol) GetLogicType() LogicOPType {
 	}
 }
 
+// Equal: check whether two AndSymbol are same, different spellings of the operator (e.g. "AND" and "&&") are regarded as equal
+func (o *AndSymbol) Equal(other *AndSymbol) bool {
+	return o.String() == other.String()
+}
+
 // OrSymbol: or operator ("OR" / "or" / "||")
 type OrSymbol struct {
 	Symbol string `parser:"((WHITESPACE* @(SOR SOR) WHITESPACE*) | (WHITESPACE+ @('OR' | 'or') WHITESPACE+))" json:"symbol"`
@@ -42,6 +47,11 @@ func (o *OrSymbol) GetLogicType() LogicOPType {
 	}
 }
 
+// Equal: check whether two OrSymbol are same, different spellings of the operator (e.g. "OR" and "||") are regarded as equal
+func (o *OrSymbol) Equal(other *OrSymbol) bool {
+	return o.String() == other.String()
+}
+
 // NotSymbol: not operator ("NOT " / "not " / "!")
 type NotSymbol struct {
 	Symbol string `parser:"( (@NOT WHITESPACE*) | (@('NOT' | 'not') WHITESPACE+))" json:"symbol"`
@@ -62,3 +72,29 @@ func (o *NotSymbol) GetLogicType() LogicOPType {
 		return NOT_LOGIC_TYPE
 	}
 }
+
+// Equal: check whether two NotSymbol are same, different spellings of the operator (e.g. "NOT" and "!") are regarded as equal
+func (o *NotSymbol) Equal(other *NotSymbol) bool {
+	return o.String() == other.String()
+}
+
+func (o *AndSymbol) Clone() *AndSymbol {
+	if o == nil {
+		return nil
+	}
+	return &AndSymbol{Symbol: o.Symbol}
+}
+
+func (o *OrSymbol) Clone() *OrSymbol {
+	if o == nil {
+		return nil
+	}
+	return &OrSymbol{Symbol: o.Symbol}
+}
+
+func (o *NotSymbol) Clone() *NotSymbol {
+	if o == nil {
+		return nil
+	}
+	return &NotSymbol{Symbol: o.Symbol}
+}
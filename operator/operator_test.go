@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/alecthomas/participle"
@@ -177,6 +178,26 @@ func TestNotSymbol(t *testing.T) {
 	assert.Empty(t, o.String())
 }
 
+func TestSymbolEqual(t *testing.T) {
+	assert.True(t, (&AndSymbol{Symbol: "AND"}).Equal(&AndSymbol{Symbol: "&&"}))
+	assert.False(t, (&AndSymbol{Symbol: "AND"}).Equal(nil))
+	assert.True(t, (&OrSymbol{Symbol: "or"}).Equal(&OrSymbol{Symbol: "||"}))
+	assert.False(t, (*OrSymbol)(nil).Equal(&OrSymbol{Symbol: "OR"}))
+	assert.True(t, (&NotSymbol{Symbol: "!"}).Equal(&NotSymbol{Symbol: "NOT"}))
+	assert.True(t, (*NotSymbol)(nil).Equal(nil))
+}
+
+func TestSymbolJSON(t *testing.T) {
+	b, err := json.Marshal(&AndSymbol{Symbol: "&&"})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type":"and_symbol","symbol":"&&"}`, string(b))
+	var out = &AndSymbol{}
+	assert.Nil(t, json.Unmarshal(b, out))
+	assert.Equal(t, &AndSymbol{Symbol: "&&"}, out)
+	assert.NotNil(t, json.Unmarshal(b, &OrSymbol{}))
+	assert.NotNil(t, json.Unmarshal(b, &NotSymbol{}))
+}
+
 func TestPrefixOperator(t *testing.T) {
 
 }
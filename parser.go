@@ -0,0 +1,285 @@
+package lucene_parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	op "github.com/zhuliquan/lucene_parser/operator"
+	tm "github.com/zhuliquan/lucene_parser/term"
+)
+
+// Operator: operator which joins clauses separated by whitespaces only (i.e. `x:1 y:2`)
+type Operator uint32
+
+const (
+	NO_OPERATOR  Operator = iota // clauses must be joined by operators explicitly, `x:1 y:2` is illegal
+	AND_OPERATOR                 // `x:1 y:2` is parsed as `x:1 AND y:2`
+	OR_OPERATOR                  // `x:1 y:2` is parsed as `x:1 OR y:2`
+)
+
+// Feature: syntax feature of query, features are bit flags which can be combined
+type Feature uint64
+
+const (
+	PHRASE_FEATURE      Feature = 1 << iota // phrase term, i.e. `x:"foo bar"`
+	REGEXP_FEATURE                          // regexp term, i.e. `x:/fo+/`
+	RANGE_FEATURE                           // range term, i.e. `x:[1 TO 2]` and `x:>1`
+	WILDCARD_FEATURE                        // term with wildcard, i.e. `x:fo?o*`
+	FUZZY_FEATURE                           // fuzzy term and proximity phrase, i.e. `x:foo~2` and `x:"foo bar"~2`
+	BOOST_FEATURE                           // boost of term, i.e. `x:foo^2`
+	TERM_GROUP_FEATURE                      // group of terms, i.e. `x:(foo OR bar)`
+	FIELD_GROUP_FEATURE                     // group of fields and field pattern, i.e. `(x OR y):foo` and `x.*:foo`
+	PAREN_FEATURE                           // paren query, i.e. `(x:1 OR y:2)`
+	NOT_FEATURE                             // not operator, i.e. `NOT x:1` and `!x:1`
+
+	ALL_FEATURES Feature = 1<<iota - 1
+)
+
+var featureNames = []string{"phrase", "regexp", "range", "wildcard", "fuzzy", "boost", "term_group", "field_group", "paren", "not"}
+
+// String: names of features which are joined by "|", i.e. "phrase|regexp"
+func (f Feature) String() string {
+	var sl = []string{}
+	for i, name := range featureNames {
+		if f&(1<<uint(i)) != 0 {
+			sl = append(sl, name)
+		}
+	}
+	return strings.Join(sl, "|")
+}
+
+var (
+	ErrFeatureNotAllowed = fmt.Errorf("syntax feature isn't allowed")
+	ErrInvalidOption     = fmt.Errorf("invalid option of parser")
+)
+
+// Keywords: words of operators, token is matched with words as a whole, so that `ANDROID` isn't operator.
+// symbols `&&`, `||` and `!` are always recognized. words of operators can't be used as bare terms of default field.
+type Keywords struct {
+	And []string
+	Or  []string
+	Not []string
+	To  []string // separator of double range term, i.e. `x:[1 TO 2]`
+}
+
+// DefaultKeywords: keywords of lucene syntax
+var DefaultKeywords = Keywords{
+	And: []string{"AND", "and"},
+	Or:  []string{"OR", "or"},
+	Not: []string{"NOT", "not"},
+	To:  []string{"TO"},
+}
+
+// Parser: parser of lucene query with options, Parser isn't modified after NewParser returns,
+// so that it's safe for concurrent use by multiple goroutines and should be built once and reused.
+type Parser struct {
+	engine          Engine
+	defaultField    *tm.Field
+	defaultOperator Operator
+	caseSensitive   bool
+	keywords        Keywords
+	features        Feature
+	limits          ParseLimits
+	grammar         bool  // options change grammar, so that query is parsed by DESCENT_ENGINE
+	err             error // error of options which is returned by Parse
+}
+
+// Option: option of Parser
+type Option func(*Parser)
+
+// WithEngine: set engine of parser, default engine is PARTICIPLE_ENGINE. options which change grammar (default field,
+// default operator, case-insensitive operators and custom keywords) are only supported by DESCENT_ENGINE,
+// parser uses DESCENT_ENGINE if any of them is set.
+func WithEngine(engine Engine) Option {
+	return func(p *Parser) {
+		p.engine = engine
+	}
+}
+
+// WithDefaultField: field of bare terms which are written without field, i.e. `foo` is parsed as `field:foo`
+// and `(foo OR bar)` is parsed as `(field:foo OR field:bar)`. field is written as it is written in query (i.e. `x\:y`).
+func WithDefaultField(field string) Option {
+	return func(p *Parser) {
+		var q, err = parseParticiple(field + ":*")
+		if err != nil {
+			p.err = fmt.Errorf("%w: default field %q, err: %+v", ErrInvalidOption, field, err)
+			return
+		}
+		p.defaultField = q.OrQuery.AndQuery.FieldQuery.Field
+		p.grammar = true
+	}
+}
+
+// WithDefaultOperator: operator of clauses and terms of group which are separated by whitespaces only,
+// `x:1 NOT y:2` is always parsed as `x:1 AND NOT y:2`.
+func WithDefaultOperator(operator Operator) Option {
+	return func(p *Parser) {
+		if operator > OR_OPERATOR {
+			p.err = fmt.Errorf("%w: unknown operator: %d", ErrInvalidOption, operator)
+			return
+		}
+		p.defaultOperator = operator
+		p.grammar = p.grammar || operator != NO_OPERATOR
+	}
+}
+
+// WithCaseSensitiveOperators: whether words of operators are matched case-sensitively, default is true,
+// i.e. `And` isn't operator. if it's false, `And` is regarded as `AND`.
+func WithCaseSensitiveOperators(caseSensitive bool) Option {
+	return func(p *Parser) {
+		p.caseSensitive = caseSensitive
+		p.grammar = p.grammar || !caseSensitive
+	}
+}
+
+// WithKeywords: set words of operators, empty words of operator mean default words, i.e. Keywords{And: []string{"UND"}}.
+// words are copied, operators are formatted as " AND ", " OR " and "NOT " whatever words are.
+func WithKeywords(keywords Keywords) Option {
+	return func(p *Parser) {
+		for _, w := range [][]string{keywords.And, keywords.Or, keywords.Not, keywords.To} {
+			for _, x := range w {
+				if len(x) == 0 {
+					p.err = fmt.Errorf("%w: empty keyword", ErrInvalidOption)
+					return
+				}
+			}
+		}
+		p.keywords = Keywords{
+			And: words(keywords.And, DefaultKeywords.And),
+			Or:  words(keywords.Or, DefaultKeywords.Or),
+			Not: words(keywords.Not, DefaultKeywords.Not),
+			To:  words(keywords.To, DefaultKeywords.To),
+		}
+		p.grammar = true
+	}
+}
+
+func words(w, defaults []string) []string {
+	if len(w) == 0 {
+		return defaults
+	}
+	return append([]string{}, w...)
+}
+
+// WithFeatures: allowed syntax features, default is ALL_FEATURES. Parse returns ErrFeatureNotAllowed if query uses
+// any feature which isn't allowed, i.e. WithFeatures(ALL_FEATURES &^ REGEXP_FEATURE) rejects `x:/.*/`.
+func WithFeatures(features Feature) Option {
+	return func(p *Parser) {
+		p.features = features
+	}
+}
+
+// WithLimits: limits of query which are checked before parsing, default is no limits.
+func WithLimits(limits ParseLimits) Option {
+	return func(p *Parser) {
+		p.limits = limits
+	}
+}
+
+// NewParser: build parser with options, parser without options is the same as ParseLucene.
+func NewParser(opts ...Option) *Parser {
+	var p = &Parser{
+		engine:        PARTICIPLE_ENGINE,
+		caseSensitive: true,
+		keywords:      DefaultKeywords,
+		features:      ALL_FEATURES,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse: parse query to Lucene struct, every call returns new ast which isn't shared with other calls.
+func (p *Parser) Parse(queryString string) (*Lucene, error) {
+	return p.ParseContext(context.Background(), queryString)
+}
+
+// ParseContext: parse query like Parse, parsing is stopped and error of ctx is returned as soon as ctx is done.
+// DESCENT_ENGINE enforces limits while tokenizing and parsing, PARTICIPLE_ENGINE checks limits by scanning tokens
+// before parsing and it can't be interrupted, so that ctx is only checked before parsing.
+func (p *Parser) ParseContext(ctx context.Context, queryString string) (*Lucene, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var (
+		q   *Lucene
+		err error
+	)
+	switch {
+	case p.engine != PARTICIPLE_ENGINE && p.engine != DESCENT_ENGINE:
+		return nil, fmt.Errorf("unknown engine: %d", p.engine)
+	case p.engine == DESCENT_ENGINE || p.grammar:
+		if err = (ParseLimits{MaxLength: p.limits.MaxLength}).Check(queryString); err == nil {
+			q, err = p.parseDescent(ctx, queryString)
+		}
+	default:
+		if err = p.limits.Check(queryString); err == nil {
+			q, err = parseParticiple(queryString)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = p.checkFeatures(q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// checkFeatures: return ErrFeatureNotAllowed with the first node which uses feature isn't allowed
+func (p *Parser) checkFeatures(q *Lucene) error {
+	if p.features&ALL_FEATURES == ALL_FEATURES {
+		return nil
+	}
+	var err error
+	Inspect(q, func(node interface{}, offset int) bool {
+		if f := nodeFeatures(node); f&^p.features != 0 && err == nil {
+			err = fmt.Errorf("%w: %s at %d", ErrFeatureNotAllowed, node.(fmt.Stringer).String(), offset)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// nodeFeatures: features used by node itself, features of children aren't included
+func nodeFeatures(node interface{}) Feature {
+	switch x := node.(type) {
+	case *op.NotSymbol:
+		return NOT_FEATURE
+	case *ParenQuery:
+		return PAREN_FEATURE
+	case *tm.Field:
+		if len(x.Group) != 0 || x.IsPattern() {
+			return FIELD_GROUP_FEATURE
+		}
+	case *tm.Term:
+		var res Feature
+		if x.TermGroup != nil {
+			res |= TERM_GROUP_FEATURE
+		}
+		var t = x.GetTermType()
+		if t&tm.BOOST_TERM_TYPE != 0 {
+			res |= BOOST_FEATURE
+		}
+		if t&tm.FUZZY_TERM_TYPE != 0 {
+			res |= FUZZY_FEATURE
+		}
+		return res
+	case *tm.RegexpTerm:
+		return REGEXP_FEATURE
+	case *tm.RangeTerm, *tm.SRangeTerm, *tm.DRangeTerm:
+		return RANGE_FEATURE
+	case *tm.PhraseTerm:
+		return PHRASE_FEATURE
+	case *tm.SingleTerm:
+		if x.GetTermType()&tm.WILDCARD_TERM_TYPE != 0 {
+			return WILDCARD_FEATURE
+		}
+	}
+	return 0
+}
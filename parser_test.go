@@ -0,0 +1,140 @@
+package lucene_parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewParser(t *testing.T) {
+	type testCase struct {
+		name   string
+		opts   []Option
+		input  string
+		output string
+		err    error
+	}
+	var german = Keywords{And: []string{"UND"}, Or: []string{"ODER"}, Not: []string{"NICHT"}, To: []string{"BIS"}}
+	for _, tt := range []testCase{
+		{name: "test_default", input: `x:1 AND y:2`, output: `x:1 AND y:2`},
+		{name: "test_descent", opts: []Option{WithEngine(DESCENT_ENGINE)}, input: `x:1 AND y:2`, output: `x:1 AND y:2`},
+		{name: "test_unknown_engine", opts: []Option{WithEngine(Engine(100))}, input: `x:1`, err: errors.New("")},
+		{name: "test_no_default_field", input: `foo`, err: errors.New("")},
+		{name: "test_default_field", opts: []Option{WithDefaultField("body")}, input: `foo`, output: `body:foo`},
+		{name: "test_default_field_terms", opts: []Option{WithDefaultField("body")},
+			input: `"foo bar"~2 AND /ba?r/ OR NOT >10 OR (a OR b) AND x:1`, output: `body:"foo bar"~2 AND body:/ba?r/ OR NOT body:{ 10 TO * } OR ( body:a OR body:b ) AND x:1`},
+		{name: "test_default_field_escape", opts: []Option{WithDefaultField(`x\:y`)}, input: `foo`, output: `x\:y:foo`},
+		{name: "test_default_field_keyword", opts: []Option{WithDefaultField("body")}, input: `foo AND`, err: errors.New("")},
+		{name: "test_default_field_keyword_prefix", opts: []Option{WithDefaultField("body")}, input: `ANDROID AND OR*`, output: `body:ANDROID AND body:OR*`},
+		{name: "test_invalid_default_field", opts: []Option{WithDefaultField("a b")}, input: `foo`, err: ErrInvalidOption},
+		{name: "test_no_default_operator", input: `x:1 y:2`, err: errors.New("")},
+		{name: "test_default_and", opts: []Option{WithDefaultOperator(AND_OPERATOR)}, input: `x:1 y:2 OR z:3`, output: `x:1 AND y:2 OR z:3`},
+		{name: "test_default_and_not", opts: []Option{WithDefaultOperator(AND_OPERATOR)}, input: `x:1 NOT y:2`, output: `x:1 AND NOT y:2`},
+		{name: "test_default_and_group", opts: []Option{WithDefaultOperator(AND_OPERATOR)}, input: `x:(a b OR c)`, output: `x:( a AND b OR c )`},
+		{name: "test_default_and_group_keyword", opts: []Option{WithDefaultOperator(AND_OPERATOR)}, input: `x:(a OR)`, err: errors.New("")},
+		{name: "test_default_or", opts: []Option{WithDefaultOperator(OR_OPERATOR)}, input: `x:1 y:2 AND z:3`, output: `x:1 OR y:2 AND z:3`},
+		{name: "test_default_or_group", opts: []Option{WithDefaultOperator(OR_OPERATOR)}, input: `x:(a b AND c)`, output: `x:( a OR b AND c )`},
+		{name: "test_default_or_field", opts: []Option{WithDefaultOperator(OR_OPERATOR), WithDefaultField("body")},
+			input: `foo bar OR x:1`, output: `body:foo OR body:bar OR x:1`},
+		{name: "test_invalid_default_operator", opts: []Option{WithDefaultOperator(Operator(9))}, input: `x:1`, err: ErrInvalidOption},
+		{name: "test_case_sensitive", input: `x:1 And y:2`, err: errors.New("")},
+		{name: "test_case_insensitive", opts: []Option{WithCaseSensitiveOperators(false)},
+			input: `x:1 And y:[1 to 2] oR Not z:3`, output: `x:1 AND y:[ 1 TO 2 ] OR NOT z:3`},
+		{name: "test_keywords", opts: []Option{WithKeywords(german)},
+			input: `x:1 UND NICHT y:[1 BIS 2] ODER z:(a ODER b) && !w:4`, output: `x:1 AND NOT y:[ 1 TO 2 ] OR z:( a OR b ) AND NOT w:4`},
+		{name: "test_keywords_replace_default", opts: []Option{WithKeywords(german)}, input: `x:1 AND y:2`, err: errors.New("")},
+		{name: "test_keywords_partial", opts: []Option{WithKeywords(Keywords{And: []string{"UND"}})}, input: `x:1 UND y:2 OR z:3`, output: `x:1 AND y:2 OR z:3`},
+		{name: "test_invalid_keywords", opts: []Option{WithKeywords(Keywords{And: []string{""}})}, input: `x:1`, err: ErrInvalidOption},
+		{name: "test_length", opts: []Option{WithLimits(ParseLimits{MaxLength: 3})}, input: `x:1`, output: `x:1`},
+		{name: "test_too_long", opts: []Option{WithLimits(ParseLimits{MaxLength: 3})}, input: `x:10`, err: ErrQueryTooLong},
+		{name: "test_too_deep", opts: []Option{WithLimits(ParseLimits{MaxDepth: 1})}, input: `((x:1))`, err: ErrTooDeep},
+		{name: "test_too_deep_slash", opts: []Option{WithLimits(DefaultParseLimits)},
+			input: "x:a/b OR " + strings.Repeat("(", 200) + "y:1" + strings.Repeat(")", 200), err: ErrTooDeep},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewParser(tt.opts...).Parse(tt.input)
+			if tt.err != nil {
+				assert.Nil(t, q)
+				assert.NotNil(t, err)
+				if tt.err.Error() != "" {
+					assert.True(t, errors.Is(err, tt.err), "%v", err)
+				}
+			} else if assert.Nil(t, err) {
+				assert.Equal(t, tt.output, q.String())
+			}
+		})
+	}
+}
+
+func TestParserFeatures(t *testing.T) {
+	type testCase struct {
+		input   string
+		feature Feature
+		other   Feature // other features used by query
+	}
+	for _, tt := range []testCase{
+		{input: `x:"foo bar"`, feature: PHRASE_FEATURE},
+		{input: `x:(a OR "foo bar")`, feature: PHRASE_FEATURE},
+		{input: `x:/fo+/`, feature: REGEXP_FEATURE},
+		{input: `x:[1 TO 2]`, feature: RANGE_FEATURE},
+		{input: `x:>1`, feature: RANGE_FEATURE},
+		{input: `x:(a OR <=1)`, feature: RANGE_FEATURE},
+		{input: `x:fo?o*`, feature: WILDCARD_FEATURE},
+		{input: `x:(a OR b*)`, feature: WILDCARD_FEATURE},
+		{input: `x:foo~2`, feature: FUZZY_FEATURE},
+		{input: `x:"foo bar"~2`, feature: FUZZY_FEATURE, other: PHRASE_FEATURE},
+		{input: `x:foo^2`, feature: BOOST_FEATURE},
+		{input: `x:[1 TO 2]^2`, feature: BOOST_FEATURE, other: RANGE_FEATURE},
+		{input: `x:(a OR b)^2`, feature: BOOST_FEATURE, other: TERM_GROUP_FEATURE},
+		{input: `x:(a OR b)`, feature: TERM_GROUP_FEATURE},
+		{input: `(x OR y):foo`, feature: FIELD_GROUP_FEATURE},
+		{input: `x.*:foo`, feature: FIELD_GROUP_FEATURE},
+		{input: `(x:1 OR y:2)`, feature: PAREN_FEATURE},
+		{input: `x:1 AND NOT y:2`, feature: NOT_FEATURE},
+		{input: `!x:1`, feature: NOT_FEATURE},
+		{input: `x:(a AND !b)`, feature: NOT_FEATURE},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := NewParser(WithFeatures(ALL_FEATURES &^ tt.feature)).Parse(tt.input)
+			assert.Nil(t, q)
+			assert.True(t, errors.Is(err, ErrFeatureNotAllowed), "%v", err)
+
+			q, err = NewParser(WithFeatures(tt.feature | tt.other | TERM_GROUP_FEATURE)).Parse(tt.input)
+			assert.Nil(t, err)
+			assert.NotNil(t, q)
+		})
+	}
+	// exists query and plain terms don't need any feature
+	q, err := NewParser(WithFeatures(0)).Parse(`x:* AND y:foo OR z:1`)
+	assert.Nil(t, err)
+	assert.NotNil(t, q)
+}
+
+func TestParserConcurrent(t *testing.T) {
+	var parsers = []*Parser{
+		NewParser(),
+		NewParser(WithEngine(DESCENT_ENGINE)),
+		NewParser(WithDefaultField("body"), WithDefaultOperator(AND_OPERATOR), WithCaseSensitiveOperators(false)),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				var query = `x:` + strconv.Itoa(i) + ` AND y:( a OR "b c" )^2 AND NOT z:[ 1 TO ` + strconv.Itoa(n) + ` ]`
+				for _, p := range parsers {
+					q, err := p.Parse(query)
+					if assert.Nil(t, err) {
+						assert.Equal(t, query, q.String())
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
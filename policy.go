@@ -0,0 +1,189 @@
+package lucene_parser
+
+import (
+	"fmt"
+	"strings"
+
+	op "github.com/zhuliquan/lucene_parser/operator"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+type PolicyMode uint32
+
+const (
+	REJECT_POLICY_MODE PolicyMode = iota // return error if query references disallowed field
+	STRIP_POLICY_MODE                    // remove field queries which reference disallowed field
+)
+
+var (
+	ErrFieldNotAllowed  = fmt.Errorf("field isn't allowed by policy")
+	ErrEmptyPolicyQuery = fmt.Errorf("query is empty after removing disallowed fields")
+)
+
+// Policy: field level access control of lucene query. patterns of Allow / Deny are unescaped field names in which
+// '*' matches any chars including '/' (i.e. `internal.*` matches `internal.a/b`), field is allowed if it matches one of Allow patterns (or Allow is empty)
+// and doesn't match any of Deny patterns. Filter is mandatory query (i.e. `tenant:X`) which is joined with user's query.
+type Policy struct {
+	Allow  []string
+	Deny   []string
+	Mode   PolicyMode
+	Filter *Lucene
+}
+
+// PolicyReport: report of applying policy, Removed is disallowed fields which are removed (or rejected) from query,
+// offset of field is relative to formatted origin query.
+type PolicyReport struct {
+	Removed []FieldRef
+}
+
+// Allowed: check whether field is allowed by policy, field is name of field as it is written in query (i.e. `x\:y`)
+func (p *Policy) Allowed(field string) bool {
+	var name = term.Unescape(field)
+	var allowed = len(p.Allow) == 0
+	for _, pattern := range p.Allow {
+		if matchPattern(pattern, name) {
+			allowed = true
+			break
+		}
+	}
+	for _, pattern := range p.Deny {
+		if matchPattern(pattern, name) {
+			return false
+		}
+	}
+	return allowed
+}
+
+// matchPattern: match unescaped field name with pattern of policy by matching of field pattern,
+// pattern is escaped by NewField whose escaped '*' is regarded as wildcard and other chars are literal.
+func matchPattern(pattern, name string) bool {
+	return term.NewField(pattern).Match(name)
+}
+
+// allowedField: group of fields is allowed if all elements are allowed, field pattern (i.e. `http.*`) and exists query
+// whose field can't be recognized are allowed only if there isn't any restriction, because concrete fields are unknown.
+// expand patterns by ExpandFields before applying policy.
+func (p *Policy) allowedField(f *term.Field) bool {
+	if len(f.Group) != 0 {
+		for _, x := range f.Group {
+			if !p.allowedField(x) {
+				return false
+			}
+		}
+		return true
+	} else if f.IsPattern() || f.IsExists() || f.IsMissing() {
+		// `_exists_` / `_missing_` is left only if its term isn't name of field (i.e. `_exists_:(a OR b)`)
+		return len(p.Allow) == 0 && len(p.Deny) == 0
+	}
+	return p.Allowed(f.String())
+}
+
+// Apply: check fields referenced by query (including fields of term group) with policy, and return new query
+// which is wrapped as `(query) AND (filter)` if filter is given. origin query isn't modified.
+// in REJECT_POLICY_MODE, ErrFieldNotAllowed is returned if any disallowed field is referenced.
+// in STRIP_POLICY_MODE, field queries of disallowed fields are removed with their bool operators,
+// and ErrEmptyPolicyQuery is returned if nothing is left, because bare filter would match more than user wants.
+func (p *Policy) Apply(q *Lucene) (*Lucene, *PolicyReport, error) {
+	var report = &PolicyReport{Removed: filterFields(q, func(f *term.Field) bool {
+		return !p.allowedField(f)
+	})}
+
+	var res = q.Clone()
+	if len(report.Removed) != 0 {
+		if p.Mode == REJECT_POLICY_MODE {
+			var names = make([]string, 0, len(report.Removed))
+			for _, ref := range report.Removed {
+				names = append(names, ref.Field)
+			}
+			return nil, report, fmt.Errorf("%w: %s", ErrFieldNotAllowed, strings.Join(names, ", "))
+		}
+		res = p.stripLucene(res)
+	}
+	if res == nil || res.OrQuery == nil {
+		return nil, report, ErrEmptyPolicyQuery
+	}
+	if p.Filter != nil && p.Filter.OrQuery != nil {
+		res = &Lucene{
+			OrQuery: &OrQuery{
+				AndQuery: &AndQuery{ParenQuery: &ParenQuery{SubQuery: res}},
+				AnSQuery: []*AnSQuery{
+					{
+						AndSymbol: &op.AndSymbol{Symbol: "AND"},
+						AndQuery:  &AndQuery{ParenQuery: &ParenQuery{SubQuery: p.Filter.Clone()}},
+					},
+				},
+			},
+		}
+	}
+	return res, report, nil
+}
+
+// stripLucene: remove disallowed or query, first one of rest or queries takes place of removed or query
+func (p *Policy) stripLucene(q *Lucene) *Lucene {
+	if q == nil {
+		return nil
+	}
+	var res = &Lucene{OrQuery: p.stripOrQuery(q.OrQuery)}
+	for _, x := range q.OSQuery {
+		if x == nil {
+			continue
+		} else if t := p.stripOrQuery(x.OrQuery); t == nil {
+			continue
+		} else if res.OrQuery == nil {
+			res.OrQuery = t
+		} else {
+			res.OSQuery = append(res.OSQuery, &OSQuery{OrSymbol: x.OrSymbol, OrQuery: t})
+		}
+	}
+	if res.OrQuery == nil {
+		return nil
+	}
+	return res
+}
+
+// stripOrQuery: remove disallowed and query, first one of rest and queries takes place of removed and query
+func (p *Policy) stripOrQuery(q *OrQuery) *OrQuery {
+	if q == nil {
+		return nil
+	}
+	var res = &OrQuery{AndQuery: p.stripAndQuery(q.AndQuery)}
+	for _, x := range q.AnSQuery {
+		if x == nil {
+			continue
+		} else if t := p.stripAndQuery(x.AndQuery); t == nil {
+			continue
+		} else if res.AndQuery != nil {
+			res.AnSQuery = append(res.AnSQuery, &AnSQuery{AndSymbol: x.AndSymbol, NotSymbol: x.NotSymbol, AndQuery: t})
+		} else if x.AndSymbol == nil && x.NotSymbol != nil {
+			// NOT of `x:1 NOT y:2` is kept when `x:1` is removed
+			if t.NotSymbol == nil {
+				t.NotSymbol = x.NotSymbol
+			} else {
+				t = &AndQuery{
+					NotSymbol:  x.NotSymbol,
+					ParenQuery: &ParenQuery{SubQuery: &Lucene{OrQuery: &OrQuery{AndQuery: t}}},
+				}
+			}
+			res.AndQuery = t
+		} else {
+			res.AndQuery = t
+		}
+	}
+	if res.AndQuery == nil {
+		return nil
+	}
+	return res
+}
+
+func (p *Policy) stripAndQuery(q *AndQuery) *AndQuery {
+	if q == nil {
+		return nil
+	} else if q.ParenQuery != nil {
+		if t := p.stripLucene(q.ParenQuery.SubQuery); t != nil {
+			return &AndQuery{NotSymbol: q.NotSymbol, ParenQuery: &ParenQuery{SubQuery: t}}
+		}
+	} else if q.FieldQuery != nil && p.allowedField(queryField(q.FieldQuery)) {
+		return q
+	}
+	return nil
+}
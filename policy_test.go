@@ -0,0 +1,182 @@
+package lucene_parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyAllowed(t *testing.T) {
+	var p = &Policy{Allow: []string{"x", "http.*", `a:b`}, Deny: []string{"http.secret"}}
+	assert.True(t, p.Allowed("x"))
+	assert.True(t, p.Allowed("http.status"))
+	assert.True(t, p.Allowed(`a\:b`))
+	assert.False(t, p.Allowed("http.secret"))
+	assert.False(t, p.Allowed("y"))
+
+	p = &Policy{Deny: []string{"internal.*"}}
+	assert.True(t, p.Allowed("y"))
+	assert.False(t, p.Allowed("internal.id"))
+	// '*' matches '/' of field name, so that escaped slash can't bypass policy
+	assert.False(t, p.Allowed(`internal.a\/b`))
+	assert.False(t, p.Allowed(`internal.a\/b\/c`))
+
+	// chars of pattern except for '*' are literal
+	p = &Policy{Allow: []string{"[a]?", `x\y*`}}
+	assert.True(t, p.Allowed(`\[a\]\?`))
+	assert.False(t, p.Allowed("a"))
+	assert.True(t, p.Allowed(`x\\y.z`))
+}
+
+func TestPolicyApply(t *testing.T) {
+	filter, err := ParseLucene(`tenant:X`)
+	assert.Nil(t, err)
+
+	type testCase struct {
+		name    string
+		mode    PolicyMode
+		input   string
+		want    string
+		removed []string
+		wantErr error
+	}
+	for _, tt := range []testCase{
+		{
+			name:    "test_allowed",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 OR y:2`,
+			want:    `( x:1 OR y:2 ) AND ( tenant:X )`,
+			removed: []string{},
+		},
+		{
+			name:    "test_reject",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 OR internal.id:2`,
+			removed: []string{"internal.id"},
+			wantErr: ErrFieldNotAllowed,
+		},
+		{
+			name:    "test_reject_term_group",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 AND internal.id:(1 OR 2)`,
+			removed: []string{"internal.id"},
+			wantErr: ErrFieldNotAllowed,
+		},
+		{
+			name:    "test_strip_or",
+			mode:    STRIP_POLICY_MODE,
+			input:   `internal.id:1 OR x:1 OR internal.id:(1 OR 2)`,
+			want:    `( x:1 ) AND ( tenant:X )`,
+			removed: []string{"internal.id", "internal.id"},
+		},
+		{
+			name:    "test_strip_and",
+			mode:    STRIP_POLICY_MODE,
+			input:   `internal.id:1 && x:1 AND NOT internal.name:foo`,
+			want:    `( x:1 ) AND ( tenant:X )`,
+			removed: []string{"internal.id", "internal.name"},
+		},
+		{
+			name:    "test_strip_keep_not",
+			mode:    STRIP_POLICY_MODE,
+			input:   `internal.id:1 NOT x:1 OR y:2`,
+			want:    `( NOT x:1 OR y:2 ) AND ( tenant:X )`,
+			removed: []string{"internal.id"},
+		},
+		{
+			name:    "test_strip_paren",
+			mode:    STRIP_POLICY_MODE,
+			input:   `x:1 AND (internal.id:1 OR internal.name:2) OR !(y:1 AND internal.id:2)`,
+			want:    `( x:1 OR NOT ( y:1 ) ) AND ( tenant:X )`,
+			removed: []string{"internal.id", "internal.name", "internal.id"},
+		},
+		{
+			name:    "test_reject_field_pattern",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 AND (x OR internal.id):1 OR *:2`,
+			removed: []string{"( x OR internal.id )", "*"},
+			wantErr: ErrFieldNotAllowed,
+		},
+		{
+			name:    "test_strip_field_pattern",
+			mode:    STRIP_POLICY_MODE,
+			input:   `x:1 AND (x OR y):1 OR in*:2`,
+			want:    `( x:1 AND ( x OR y ):1 ) AND ( tenant:X )`,
+			removed: []string{"in*"},
+		},
+		{
+			name:    "test_reject_exists_phrase",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 OR _exists_:"internal.secret"`,
+			removed: []string{"internal.secret"},
+			wantErr: ErrFieldNotAllowed,
+		},
+		{
+			name:    "test_reject_exists_group",
+			mode:    REJECT_POLICY_MODE,
+			input:   `x:1 OR _exists_:(internal.secret)`,
+			removed: []string{"internal.secret"},
+			wantErr: ErrFieldNotAllowed,
+		},
+		{
+			name:    "test_strip_exists_unknown",
+			mode:    STRIP_POLICY_MODE,
+			input:   `x:1 OR _exists_:(internal.secret OR y) OR _missing_:"y"`,
+			want:    `( x:1 OR _missing_:"y" ) AND ( tenant:X )`,
+			removed: []string{"_exists_"},
+		},
+		{
+			name:    "test_strip_all",
+			mode:    STRIP_POLICY_MODE,
+			input:   `internal.id:1 OR internal.name:2`,
+			removed: []string{"internal.id", "internal.name"},
+			wantErr: ErrEmptyPolicyQuery,
+		},
+		{
+			name:    "test_bypass_filter",
+			mode:    STRIP_POLICY_MODE,
+			input:   `x:1 OR tenant:Y`,
+			want:    `( x:1 OR tenant:Y ) AND ( tenant:X )`,
+			removed: []string{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			var origin = q.String()
+			var p = &Policy{Deny: []string{"internal.*"}, Mode: tt.mode, Filter: filter}
+			res, report, err := p.Apply(q)
+			assert.True(t, errors.Is(err, tt.wantErr), "%v", err)
+			assert.Equal(t, tt.want, res.String())
+			var removed = []string{}
+			for _, x := range report.Removed {
+				removed = append(removed, x.Field)
+			}
+			assert.Equal(t, tt.removed, removed)
+			// origin query isn't modified
+			assert.Equal(t, origin, q.String())
+			if res != nil {
+				// result can be parsed again
+				r, err := ParseLucene(res.String())
+				assert.Nil(t, err)
+				assert.Equal(t, res.String(), r.String())
+			}
+		})
+	}
+
+	t.Run("test_escaped_slash", func(t *testing.T) {
+		q, err := ParseLucene(`x:1 OR internal.a\/b:1`)
+		assert.Nil(t, err)
+		_, report, err := (&Policy{Deny: []string{"internal.*"}}).Apply(q)
+		assert.True(t, errors.Is(err, ErrFieldNotAllowed), "%v", err)
+		assert.Equal(t, `internal.a\/b`, report.Removed[0].Field)
+	})
+
+	t.Run("test_no_filter", func(t *testing.T) {
+		q, _ := ParseLucene(`x:1 AND internal.id:1`)
+		res, _, err := (&Policy{Deny: []string{"internal.*"}, Mode: STRIP_POLICY_MODE}).Apply(q)
+		assert.Nil(t, err)
+		assert.Equal(t, `x:1`, res.String())
+	})
+}
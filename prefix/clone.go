@@ -0,0 +1,85 @@
+package prefix
+
+// Clone: deep copy lucene query, the copy doesn't share any memory with origin
+func (q *Lucene) Clone() *Lucene {
+	if q == nil {
+		return nil
+	}
+	var res = &Lucene{}
+	if q.Clauses != nil {
+		res.Clauses = make([]*PrefixClause, 0, len(q.Clauses))
+		for _, x := range q.Clauses {
+			res.Clauses = append(res.Clauses, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *PrefixClause) Clone() *PrefixClause {
+	if q == nil {
+		return nil
+	}
+	return &PrefixClause{
+		PrefixOp:   q.PrefixOp,
+		ParenQuery: q.ParenQuery.Clone(),
+		FieldQuery: q.FieldQuery.Clone(),
+	}
+}
+
+func (q *ParenQuery) Clone() *ParenQuery {
+	if q == nil {
+		return nil
+	}
+	return &ParenQuery{SubQuery: q.SubQuery.Clone()}
+}
+
+func (q *FieldQuery) Clone() *FieldQuery {
+	if q == nil {
+		return nil
+	}
+	return &FieldQuery{Field: q.Field.Clone(), Term: q.Term.Clone()}
+}
+
+func (t *Term) Clone() *Term {
+	if t == nil {
+		return nil
+	}
+	return &Term{
+		RegexpTerm: t.RegexpTerm.Clone(),
+		FuzzyTerm:  t.FuzzyTerm.Clone(),
+		RangeTerm:  t.RangeTerm.Clone(),
+		TermGroup:  t.TermGroup.Clone(),
+	}
+}
+
+func (t *TermGroup) Clone() *TermGroup {
+	if t == nil {
+		return nil
+	}
+	return &TermGroup{PrefixTermGroup: t.PrefixTermGroup.Clone(), BoostSymbol: t.BoostSymbol}
+}
+
+func (t *PrefixTermGroup) Clone() *PrefixTermGroup {
+	if t == nil {
+		return nil
+	}
+	var res = &PrefixTermGroup{}
+	if t.PrefixTerms != nil {
+		res.PrefixTerms = make([]*PrefixOperatorTerm, 0, len(t.PrefixTerms))
+		for _, x := range t.PrefixTerms {
+			res.PrefixTerms = append(res.PrefixTerms, x.Clone())
+		}
+	}
+	return res
+}
+
+func (t *PrefixOperatorTerm) Clone() *PrefixOperatorTerm {
+	if t == nil {
+		return nil
+	}
+	return &PrefixOperatorTerm{
+		PrefixOp:       t.PrefixOp,
+		FieldTermGroup: t.FieldTermGroup.Clone(),
+		ParenTermGroup: t.ParenTermGroup.Clone(),
+	}
+}
@@ -0,0 +1,25 @@
+package prefix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	for _, input := range []string{
+		`+x:1 -y:2 z:[1 TO 2]^2`,
+		`+(x:foo~2 y:"foo bar"^3) -z:/\d+/`,
+		`x:(+foo -(bar baz))^2`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			var c = q.Clone()
+			assert.Equal(t, q, c)
+			c.Clauses[0].PrefixOp = "!"
+			assert.NotEqual(t, q.String(), c.String())
+		})
+	}
+	assert.Nil(t, (*Lucene)(nil).Clone())
+}
@@ -0,0 +1,117 @@
+package prefix
+
+import (
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Equal: check whether two ast nodes are structurally equal. a and b must be pointers to the same type of node,
+// nodes of term package are compared by term.Equal.
+func Equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *Lucene:
+		y, ok := b.(*Lucene)
+		return ok && x.Equal(y)
+	case *PrefixClause:
+		y, ok := b.(*PrefixClause)
+		return ok && x.Equal(y)
+	case *ParenQuery:
+		y, ok := b.(*ParenQuery)
+		return ok && x.Equal(y)
+	case *FieldQuery:
+		y, ok := b.(*FieldQuery)
+		return ok && x.Equal(y)
+	case *Term:
+		y, ok := b.(*Term)
+		return ok && x.Equal(y)
+	case *TermGroup:
+		y, ok := b.(*TermGroup)
+		return ok && x.Equal(y)
+	case *PrefixTermGroup:
+		y, ok := b.(*PrefixTermGroup)
+		return ok && x.Equal(y)
+	case *PrefixOperatorTerm:
+		y, ok := b.(*PrefixOperatorTerm)
+		return ok && x.Equal(y)
+	default:
+		return term.Equal(a, b)
+	}
+}
+
+func (q *Lucene) Equal(other *Lucene) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	if len(q.Clauses) != len(other.Clauses) {
+		return false
+	}
+	for i := range q.Clauses {
+		if !q.Clauses[i].Equal(other.Clauses[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *PrefixClause) Equal(other *PrefixClause) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.PrefixOp == other.PrefixOp &&
+		q.ParenQuery.Equal(other.ParenQuery) &&
+		q.FieldQuery.Equal(other.FieldQuery)
+}
+
+func (q *ParenQuery) Equal(other *ParenQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.SubQuery.Equal(other.SubQuery)
+}
+
+func (q *FieldQuery) Equal(other *FieldQuery) bool {
+	if q == nil || other == nil {
+		return q == nil && other == nil
+	}
+	return q.Field.Equal(other.Field) && q.Term.Equal(other.Term)
+}
+
+func (t *Term) Equal(other *Term) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.RegexpTerm.Equal(other.RegexpTerm) &&
+		t.FuzzyTerm.Equal(other.FuzzyTerm) &&
+		t.RangeTerm.Equal(other.RangeTerm) &&
+		t.TermGroup.Equal(other.TermGroup)
+}
+
+func (t *TermGroup) Equal(other *TermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.BoostSymbol == other.BoostSymbol && t.PrefixTermGroup.Equal(other.PrefixTermGroup)
+}
+
+func (t *PrefixTermGroup) Equal(other *PrefixTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	if len(t.PrefixTerms) != len(other.PrefixTerms) {
+		return false
+	}
+	for i := range t.PrefixTerms {
+		if !t.PrefixTerms[i].Equal(other.PrefixTerms[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *PrefixOperatorTerm) Equal(other *PrefixOperatorTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.PrefixOp == other.PrefixOp &&
+		t.FieldTermGroup.Equal(other.FieldTermGroup) &&
+		t.ParenTermGroup.Equal(other.ParenTermGroup)
+}
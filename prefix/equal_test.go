@@ -0,0 +1,54 @@
+package prefix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestEqual(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		other string
+		want  bool
+	}
+	var testCases = []testCase{
+		{
+			name:  "test_same_query",
+			input: `+x:1 -y:(+foo bar) (z:[1 TO 2]^2)`,
+			other: `+x:1 -y:(+foo bar) (z:[1 TO 2]^2)`,
+			want:  true,
+		},
+		{
+			name:  "test_different_prefix",
+			input: `+x:1`,
+			other: `-x:1`,
+			want:  false,
+		},
+		{
+			name:  "test_different_clause_count",
+			input: `x:1 y:2`,
+			other: `x:1`,
+			want:  false,
+		},
+		{
+			name:  "test_different_term_group",
+			input: `x:(+foo bar)`,
+			other: `x:(foo +bar)`,
+			want:  false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			q1, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			q2, err := ParseLucene(tt.other)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, Equal(q1, q2))
+		})
+	}
+	assert.True(t, Equal(&term.Field{Value: []string{"x"}}, &term.Field{Value: []string{"x"}}))
+	assert.False(t, Equal(&Lucene{}, &ParenQuery{}))
+}
@@ -0,0 +1,88 @@
+package prefix
+
+import (
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/internal/codec"
+)
+
+// json encoding of prefix query shares schema version with lucene_parser.JSONSchemaVersion,
+// and "type" of node is prefixed with "prefix_" to distinguish from nodes of lucene_parser.
+func (q *Lucene) MarshalJSON() ([]byte, error) {
+	type alias Lucene
+	return codec.MarshalVersionedNode("prefix_lucene", lucene_parser.JSONSchemaVersion, (*alias)(q))
+}
+
+func (q *Lucene) UnmarshalJSON(data []byte) error {
+	type alias Lucene
+	return codec.UnmarshalVersionedNode(data, "prefix_lucene", lucene_parser.JSONSchemaVersion, (*alias)(q))
+}
+
+func (q *PrefixClause) MarshalJSON() ([]byte, error) {
+	type alias PrefixClause
+	return codec.MarshalNode("prefix_clause", (*alias)(q))
+}
+
+func (q *PrefixClause) UnmarshalJSON(data []byte) error {
+	type alias PrefixClause
+	return codec.UnmarshalNode(data, "prefix_clause", (*alias)(q))
+}
+
+func (q *ParenQuery) MarshalJSON() ([]byte, error) {
+	type alias ParenQuery
+	return codec.MarshalNode("prefix_paren_query", (*alias)(q))
+}
+
+func (q *ParenQuery) UnmarshalJSON(data []byte) error {
+	type alias ParenQuery
+	return codec.UnmarshalNode(data, "prefix_paren_query", (*alias)(q))
+}
+
+func (q *FieldQuery) MarshalJSON() ([]byte, error) {
+	type alias FieldQuery
+	return codec.MarshalNode("prefix_field_query", (*alias)(q))
+}
+
+func (q *FieldQuery) UnmarshalJSON(data []byte) error {
+	type alias FieldQuery
+	return codec.UnmarshalNode(data, "prefix_field_query", (*alias)(q))
+}
+
+func (t *Term) MarshalJSON() ([]byte, error) {
+	type alias Term
+	return codec.MarshalNode("prefix_term", (*alias)(t))
+}
+
+func (t *Term) UnmarshalJSON(data []byte) error {
+	type alias Term
+	return codec.UnmarshalNode(data, "prefix_term", (*alias)(t))
+}
+
+func (t *TermGroup) MarshalJSON() ([]byte, error) {
+	type alias TermGroup
+	return codec.MarshalNode("prefix_group", (*alias)(t))
+}
+
+func (t *TermGroup) UnmarshalJSON(data []byte) error {
+	type alias TermGroup
+	return codec.UnmarshalNode(data, "prefix_group", (*alias)(t))
+}
+
+func (t *PrefixTermGroup) MarshalJSON() ([]byte, error) {
+	type alias PrefixTermGroup
+	return codec.MarshalNode("prefix_term_group", (*alias)(t))
+}
+
+func (t *PrefixTermGroup) UnmarshalJSON(data []byte) error {
+	type alias PrefixTermGroup
+	return codec.UnmarshalNode(data, "prefix_term_group", (*alias)(t))
+}
+
+func (t *PrefixOperatorTerm) MarshalJSON() ([]byte, error) {
+	type alias PrefixOperatorTerm
+	return codec.MarshalNode("prefix_operator_term", (*alias)(t))
+}
+
+func (t *PrefixOperatorTerm) UnmarshalJSON(data []byte) error {
+	type alias PrefixOperatorTerm
+	return codec.UnmarshalNode(data, "prefix_operator_term", (*alias)(t))
+}
@@ -0,0 +1,35 @@
+package prefix
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, input := range []string{
+		`+x:1 -y:2 z:[1 TO 2]^2`,
+		`+(x:foo~2 y:"foo bar"^3) -z:/\d+/`,
+		`x:(+foo -(bar baz))^2`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			b, err := json.Marshal(q)
+			assert.Nil(t, err)
+			var out = &Lucene{}
+			assert.Nil(t, json.Unmarshal(b, out))
+			assert.Equal(t, q, out)
+		})
+	}
+
+	t.Run("test_lucene_type_is_different", func(t *testing.T) {
+		q, err := lucene_parser.ParseLucene(`x:1`)
+		assert.Nil(t, err)
+		b, err := json.Marshal(q)
+		assert.Nil(t, err)
+		assert.NotNil(t, json.Unmarshal(b, &Lucene{}))
+	})
+}
@@ -19,17 +19,24 @@ func init() {
 	)
 }
 
+// defaultParser: parser without options which is used by ParseLucene
+var defaultParser = NewParser()
+
+// ParseLucene: parse query to Lucene struct, it's safe for concurrent use
 func ParseLucene(queryString string) (*Lucene, error) {
-	var (
-		err error
-		lqy = &Lucene{}
-	)
+	return defaultParser.Parse(queryString)
+}
+
+// parseParticiple: parse query by LuceneParser
+func parseParticiple(queryString string) (lqy *Lucene, err error) {
+	// results are named, so that panic of participle is returned as error
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("failed to parse lucene, err: %+v", r)
+			lqy, err = nil, fmt.Errorf("failed to parse lucene, err: %+v", r)
 		}
 	}()
 
+	lqy = &Lucene{}
 	if err = LuceneParser.ParseString(queryString, lqy); err != nil {
 		return nil, err
 	} else {
@@ -39,7 +46,7 @@ func ParseLucene(queryString string) (*Lucene, error) {
 
 // lucene: consist of list of prefix clauses
 type Lucene struct {
-	Clauses []*PrefixClause `parser:"@@*" json:"or_query"`
+	Clauses []*PrefixClause `parser:"@@*" json:"clauses,omitempty"`
 }
 
 func (q *Lucene) GetQueryType() lucene_parser.QueryType {
@@ -60,9 +67,9 @@ func (q *Lucene) String() string {
 
 // PrefixClause: prefix operator is prefix operator paren query and field_query
 type PrefixClause struct {
-	PrefixOp   string      `parser:"WHITESPACE* @( PLUS | MINUS | '!')?" json:"prefix_op"`
-	ParenQuery *ParenQuery `parser:"( @@ " json:"paren_query"`
-	FieldQuery *FieldQuery `parser:"| @@)" json:"field_query"`
+	PrefixOp   string      `parser:"WHITESPACE* @( PLUS | MINUS | '!')?" json:"prefix_op,omitempty"`
+	ParenQuery *ParenQuery `parser:"( @@ " json:"paren_query,omitempty"`
+	FieldQuery *FieldQuery `parser:"| @@)" json:"field_query,omitempty"`
 }
 
 func (q *PrefixClause) String() string {
@@ -91,7 +98,7 @@ func (q *PrefixClause) GetQueryType() lucene_parser.QueryType {
 
 // ParenQuery: lucene query is surround with paren
 type ParenQuery struct {
-	SubQuery *Lucene `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_query"`
+	SubQuery *Lucene `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_query,omitempty"`
 }
 
 func (q *ParenQuery) GetQueryType() lucene_parser.QueryType {
@@ -108,8 +115,8 @@ func (q *ParenQuery) String() string {
 
 // FieldQuery: consist of field and term
 type FieldQuery struct {
-	Field *term.Field `parser:"@@ COLON" json:"field"`
-	Term  *Term       `parser:"@@" json:"term"`
+	Field *term.Field `parser:"@@ COLON" json:"field,omitempty"`
+	Term  *Term       `parser:"@@" json:"term,omitempty"`
 }
 
 func (q *FieldQuery) GetQueryType() lucene_parser.QueryType {
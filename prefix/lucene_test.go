@@ -27,7 +27,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "1"},
+								SingleTerm: term.NewSingleTerm("1"),
 							}},
 						},
 					},
@@ -36,7 +36,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "2"},
+								SingleTerm: term.NewSingleTerm("2"),
 							}},
 						},
 					},
@@ -55,7 +55,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "1"},
+								SingleTerm: term.NewSingleTerm("1"),
 							}},
 						},
 					},
@@ -64,7 +64,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "2"},
+								SingleTerm: term.NewSingleTerm("2"),
 							}},
 						},
 					},
@@ -87,7 +87,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"x"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "1"},
+												SingleTerm: term.NewSingleTerm("1"),
 											}},
 										},
 									},
@@ -96,7 +96,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"y"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "2"},
+												SingleTerm: term.NewSingleTerm("2"),
 											}},
 										},
 									},
@@ -108,7 +108,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"z"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: "9"},
+								SingleTerm: term.NewSingleTerm("9"),
 							}},
 						},
 					},
@@ -130,7 +130,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"x"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "1"},
+												SingleTerm: term.NewSingleTerm("1"),
 											}},
 										},
 									},
@@ -139,7 +139,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"y"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "2"},
+												SingleTerm: term.NewSingleTerm("2"),
 											}},
 										},
 									},
@@ -157,7 +157,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"x"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "8"},
+												SingleTerm: term.NewSingleTerm("8"),
 											}},
 										},
 									},
@@ -166,7 +166,7 @@ func TestLucene(t *testing.T) {
 										FieldQuery: &FieldQuery{
 											Field: &term.Field{Value: []string{"k"}},
 											Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-												SingleTerm: &term.SingleTerm{Begin: "90"},
+												SingleTerm: term.NewSingleTerm("90"),
 											}},
 										},
 									},
@@ -193,17 +193,17 @@ func TestLucene(t *testing.T) {
 										PrefixTerms: []*PrefixOperatorTerm{
 											{
 												FieldTermGroup: &term.FieldTermGroup{
-													SingleTerm: &term.SingleTerm{Begin: "txt"},
+													SingleTerm: term.NewSingleTerm("txt"),
 												},
 											},
 											{
 												FieldTermGroup: &term.FieldTermGroup{
-													SingleTerm: &term.SingleTerm{Begin: "foo"},
+													SingleTerm: term.NewSingleTerm("foo"),
 												},
 											},
 											{
 												FieldTermGroup: &term.FieldTermGroup{
-													SingleTerm: &term.SingleTerm{Begin: "bar"},
+													SingleTerm: term.NewSingleTerm("bar"),
 												},
 											},
 										},
@@ -226,7 +226,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"zz"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: `iopio`, Chars: []string{`\ `, `90`}},
+								SingleTerm: term.NewSingleTerm(`iopio\ 90`),
 							}},
 						},
 					},
@@ -254,12 +254,12 @@ func TestLucene(t *testing.T) {
 														PrefixTerms: []*PrefixOperatorTerm{
 															{
 																FieldTermGroup: &term.FieldTermGroup{
-																	SingleTerm: &term.SingleTerm{Begin: "foo"},
+																	SingleTerm: term.NewSingleTerm("foo"),
 																},
 															},
 															{
 																FieldTermGroup: &term.FieldTermGroup{
-																	SingleTerm: &term.SingleTerm{Begin: "bar"},
+																	SingleTerm: term.NewSingleTerm("bar"),
 																},
 															},
 														},
@@ -277,7 +277,7 @@ func TestLucene(t *testing.T) {
 						FieldQuery: &FieldQuery{
 							Field: &term.Field{Value: []string{"z"}},
 							Term: &Term{FuzzyTerm: &term.FuzzyTerm{
-								SingleTerm: &term.SingleTerm{Begin: `you`},
+								SingleTerm: term.NewSingleTerm(`you`),
 							}},
 						},
 					},
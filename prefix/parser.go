@@ -0,0 +1,185 @@
+package prefix
+
+import (
+	"fmt"
+
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Parser: parser of query with prefix operators, Parser isn't modified after NewParser returns,
+// so that it's safe for concurrent use by multiple goroutines and should be built once and reused.
+type Parser struct {
+	defaultOperator lucene_parser.Operator
+	features        lucene_parser.Feature
+	limits          lucene_parser.ParseLimits
+	err             error // error of options which is returned by Parse
+}
+
+// Option: option of Parser
+type Option func(*Parser)
+
+// WithDefaultOperator: operator of clauses and terms of group without prefix operator. in AND_OPERATOR,
+// they are required, i.e. `x:1 -y:2` is parsed as `+x:1 -y:2`. NO_OPERATOR and OR_OPERATOR keep them optional.
+func WithDefaultOperator(operator lucene_parser.Operator) Option {
+	return func(p *Parser) {
+		if operator > lucene_parser.OR_OPERATOR {
+			p.err = fmt.Errorf("%w: unknown operator: %d", lucene_parser.ErrInvalidOption, operator)
+			return
+		}
+		p.defaultOperator = operator
+	}
+}
+
+// WithFeatures: allowed syntax features, default is ALL_FEATURES. `-` and `!` are regarded as NOT_FEATURE.
+func WithFeatures(features lucene_parser.Feature) Option {
+	return func(p *Parser) {
+		p.features = features
+	}
+}
+
+// WithLimits: limits of query which are checked before parsing, default is no limits.
+func WithLimits(limits lucene_parser.ParseLimits) Option {
+	return func(p *Parser) {
+		p.limits = limits
+	}
+}
+
+// NewParser: build parser with options, parser without options is the same as ParseLucene.
+func NewParser(opts ...Option) *Parser {
+	var p = &Parser{features: lucene_parser.ALL_FEATURES}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse: parse query to Lucene struct, every call returns new ast which isn't shared with other calls.
+func (p *Parser) Parse(queryString string) (*Lucene, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := p.limits.Check(queryString); err != nil {
+		return nil, err
+	}
+	var q, err = parseParticiple(queryString)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.checkFeatures(q); err != nil {
+		return nil, err
+	}
+	if p.defaultOperator == lucene_parser.AND_OPERATOR {
+		requireLucene(q)
+	}
+	return q, nil
+}
+
+// requireLucene: add `+` to clauses and terms of group without prefix operator
+func requireLucene(q *Lucene) {
+	for _, x := range q.Clauses {
+		if x.PrefixOp == "" {
+			x.PrefixOp = "+"
+		}
+		if x.ParenQuery != nil {
+			requireLucene(x.ParenQuery.SubQuery)
+		} else if x.FieldQuery != nil && x.FieldQuery.Term.TermGroup != nil {
+			requireTermGroup(x.FieldQuery.Term.TermGroup.PrefixTermGroup)
+		}
+	}
+}
+
+func requireTermGroup(g *PrefixTermGroup) {
+	for _, x := range g.PrefixTerms {
+		if x.PrefixOp == "" {
+			x.PrefixOp = "+"
+		}
+		if x.ParenTermGroup != nil {
+			requireTermGroup(x.ParenTermGroup)
+		}
+	}
+}
+
+// checkFeatures: return ErrFeatureNotAllowed with the first clause or term which uses feature isn't allowed
+func (p *Parser) checkFeatures(q *Lucene) error {
+	if p.features&lucene_parser.ALL_FEATURES == lucene_parser.ALL_FEATURES {
+		return nil
+	}
+	for _, x := range q.Clauses {
+		var f = prefixFeatures(x.PrefixOp)
+		if x.ParenQuery != nil {
+			f |= lucene_parser.PAREN_FEATURE
+		} else if x.FieldQuery != nil {
+			if len(x.FieldQuery.Field.Group) != 0 || x.FieldQuery.Field.IsPattern() {
+				f |= lucene_parser.FIELD_GROUP_FEATURE
+			}
+			f |= termFeatures(x.FieldQuery.Term)
+		}
+		if f&^p.features != 0 {
+			return fmt.Errorf("%w: %s", lucene_parser.ErrFeatureNotAllowed, x.String())
+		}
+		if x.ParenQuery != nil {
+			if err := p.checkFeatures(x.ParenQuery.SubQuery); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func prefixFeatures(prefixOp string) lucene_parser.Feature {
+	if prefixOp == "-" || prefixOp == "!" {
+		return lucene_parser.NOT_FEATURE
+	}
+	return 0
+}
+
+// termFeatures: features of term including terms of group
+func termFeatures(t *Term) lucene_parser.Feature {
+	switch {
+	case t.RegexpTerm != nil:
+		return typeFeatures(t.RegexpTerm.GetTermType())
+	case t.FuzzyTerm != nil:
+		return typeFeatures(t.FuzzyTerm.GetTermType())
+	case t.RangeTerm != nil:
+		return typeFeatures(t.RangeTerm.GetTermType())
+	case t.TermGroup != nil:
+		return typeFeatures(t.TermGroup.GetTermType()) | groupFeatures(t.TermGroup.PrefixTermGroup)
+	}
+	return 0
+}
+
+func groupFeatures(g *PrefixTermGroup) lucene_parser.Feature {
+	var res lucene_parser.Feature
+	for _, x := range g.PrefixTerms {
+		res |= prefixFeatures(x.PrefixOp)
+		if x.ParenTermGroup != nil {
+			res |= groupFeatures(x.ParenTermGroup)
+		} else if t := x.FieldTermGroup; t != nil {
+			res |= typeFeatures(t.SingleTerm.GetTermType() | t.PhraseTerm.GetTermType() |
+				t.SRangeTerm.GetTermType() | t.DRangeTerm.GetTermType())
+		}
+	}
+	return res
+}
+
+// termTypeFeatures: features of flags of term type
+var termTypeFeatures = map[term.TermType]lucene_parser.Feature{
+	term.PHRASE_TERM_TYPE:   lucene_parser.PHRASE_FEATURE,
+	term.REGEXP_TERM_TYPE:   lucene_parser.REGEXP_FEATURE,
+	term.RANGE_TERM_TYPE:    lucene_parser.RANGE_FEATURE,
+	term.WILDCARD_TERM_TYPE: lucene_parser.WILDCARD_FEATURE,
+	term.GROUP_TERM_TYPE:    lucene_parser.TERM_GROUP_FEATURE,
+	term.FUZZY_TERM_TYPE:    lucene_parser.FUZZY_FEATURE,
+	term.BOOST_TERM_TYPE:    lucene_parser.BOOST_FEATURE,
+}
+
+func typeFeatures(t term.TermType) lucene_parser.Feature {
+	var res lucene_parser.Feature
+	for k, v := range termTypeFeatures {
+		if t&k != 0 {
+			res |= v
+		}
+	}
+	return res
+}
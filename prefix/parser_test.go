@@ -0,0 +1,71 @@
+package prefix
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser"
+)
+
+func TestNewParser(t *testing.T) {
+	type testCase struct {
+		name   string
+		opts   []Option
+		input  string
+		output string
+		err    error
+	}
+	for _, tt := range []testCase{
+		{name: "test_default", input: `x:1 -y:2`, output: `x:1 -y:2`},
+		{name: "test_default_or", opts: []Option{WithDefaultOperator(lucene_parser.OR_OPERATOR)}, input: `x:1 -y:2`, output: `x:1 -y:2`},
+		{name: "test_default_and", opts: []Option{WithDefaultOperator(lucene_parser.AND_OPERATOR)},
+			input: `x:1 -y:2 (z:3 !w:(a -b (c))) +v:4`, output: `+x:1 -y:2 +( +z:3 !w:( +a -b +( +c ) ) ) +v:4`},
+		{name: "test_invalid_default_operator", opts: []Option{WithDefaultOperator(lucene_parser.Operator(9))}, input: `x:1`, err: lucene_parser.ErrInvalidOption},
+		{name: "test_too_long", opts: []Option{WithLimits(lucene_parser.ParseLimits{MaxLength: 3})}, input: `x:10`, err: lucene_parser.ErrQueryTooLong},
+		{name: "test_too_deep", opts: []Option{WithLimits(lucene_parser.ParseLimits{MaxDepth: 1})}, input: `x:(a (b))`, err: lucene_parser.ErrTooDeep},
+		{name: "test_features", opts: []Option{WithFeatures(lucene_parser.PAREN_FEATURE | lucene_parser.TERM_GROUP_FEATURE)},
+			input: `(x:1 y:(a b))`, output: `( x:1 y:( a b ) )`},
+		{name: "test_not_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.NOT_FEATURE)},
+			input: `x:1 -y:2`, err: lucene_parser.ErrFeatureNotAllowed},
+		{name: "test_paren_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.PAREN_FEATURE)},
+			input: `x:1 (y:2)`, err: lucene_parser.ErrFeatureNotAllowed},
+		{name: "test_nested_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.REGEXP_FEATURE)},
+			input: `x:1 (y:2 (z:/a+/))`, err: lucene_parser.ErrFeatureNotAllowed},
+		{name: "test_group_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.WILDCARD_FEATURE)},
+			input: `x:(a (b*))`, err: lucene_parser.ErrFeatureNotAllowed},
+		{name: "test_group_not_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.NOT_FEATURE)},
+			input: `x:(a -b)`, err: lucene_parser.ErrFeatureNotAllowed},
+		{name: "test_field_group_feature", opts: []Option{WithFeatures(lucene_parser.ALL_FEATURES &^ lucene_parser.FIELD_GROUP_FEATURE)},
+			input: `x.*:1`, err: lucene_parser.ErrFeatureNotAllowed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewParser(tt.opts...).Parse(tt.input)
+			if tt.err != nil {
+				assert.Nil(t, q)
+				assert.True(t, errors.Is(err, tt.err), "%v", err)
+			} else if assert.Nil(t, err) {
+				assert.Equal(t, tt.output, q.String())
+			}
+		})
+	}
+}
+
+func TestParserConcurrent(t *testing.T) {
+	var p = NewParser(WithDefaultOperator(lucene_parser.AND_OPERATOR))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				q, err := p.Parse(`x:1 -y:(a b) (z:3)`)
+				if assert.Nil(t, err) {
+					assert.Equal(t, `+x:1 -y:( +a +b ) +( +z:3 )`, q.String())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
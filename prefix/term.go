@@ -3,10 +3,10 @@ package prefix
 import "github.com/zhuliquan/lucene_parser/term"
 
 type Term struct {
-	RegexpTerm *term.RegexpTerm `parser:"  @@" json:"regexp_term"`
-	FuzzyTerm  *term.FuzzyTerm  `parser:"| @@" json:"fuzzy_term"`
-	RangeTerm  *term.RangeTerm  `parser:"| @@" json:"range_term"`
-	TermGroup  *TermGroup       `parser:"| @@" json:"term_group"`
+	RegexpTerm *term.RegexpTerm `parser:"  @@" json:"regexp_term,omitempty"`
+	FuzzyTerm  *term.FuzzyTerm  `parser:"| @@" json:"fuzzy_term,omitempty"`
+	RangeTerm  *term.RangeTerm  `parser:"| @@" json:"range_term,omitempty"`
+	TermGroup  *TermGroup       `parser:"| @@" json:"term_group,omitempty"`
 }
 
 func (t *Term) String() string {
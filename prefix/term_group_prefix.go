@@ -10,9 +10,9 @@ import (
 
 // prefix operator term: a term is behind of prefix operator symbol ("+" / "-" / '!')
 type PrefixOperatorTerm struct {
-	PrefixOp       string               `parser:"WHITESPACE* @( PLUS | MINUS | '!')?" json:"prefix_op"`
-	FieldTermGroup *term.FieldTermGroup `parser:"( @@" json:"field_term_group"`
-	ParenTermGroup *PrefixTermGroup     `parser:"| LPAREN WHITESPACE* @@ WHITESPACE* RPAREN)" json:"paren_term_group"`
+	PrefixOp       string               `parser:"WHITESPACE* @( PLUS | MINUS | '!')?" json:"prefix_op,omitempty"`
+	FieldTermGroup *term.FieldTermGroup `parser:"( @@" json:"field_term_group,omitempty"`
+	ParenTermGroup *PrefixTermGroup     `parser:"| LPAREN WHITESPACE* @@ WHITESPACE* RPAREN)" json:"paren_term_group,omitempty"`
 }
 
 func (t *PrefixOperatorTerm) String() string {
@@ -39,7 +39,7 @@ func (t *PrefixOperatorTerm) GetPrefixType() op.PrefixOPType {
 }
 
 type PrefixTermGroup struct {
-	PrefixTerms []*PrefixOperatorTerm `parser:"@@*" json:"prefix_terms"`
+	PrefixTerms []*PrefixOperatorTerm `parser:"@@*" json:"prefix_terms,omitempty"`
 }
 
 func (t *PrefixTermGroup) String() string {
@@ -63,8 +63,8 @@ func (t *PrefixTermGroup) GetTermType() term.TermType {
 }
 
 type TermGroup struct {
-	PrefixTermGroup *PrefixTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"prefix_term_group"`
-	BoostSymbol     string           `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol"`
+	PrefixTermGroup *PrefixTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"prefix_term_group,omitempty"`
+	BoostSymbol     string           `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol,omitempty"`
 }
 
 func (t *TermGroup) String() string {
@@ -30,12 +30,12 @@ func TestTermGroup(t *testing.T) {
 			want: &TermGroup{
 				PrefixTermGroup: &PrefixTermGroup{
 					PrefixTerms: []*PrefixOperatorTerm{
-						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: `8908`}}},
+						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm(`8908`)}},
 						{PrefixOp: "", FieldTermGroup: &term.FieldTermGroup{PhraseTerm: &term.PhraseTerm{Chars: []string{`dsada`, ` `, `78`}}}},
 						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{PhraseTerm: &term.PhraseTerm{Chars: []string{`89080`, `  `, `xxx`}}}},
 						{PrefixOp: "-", FieldTermGroup: &term.FieldTermGroup{PhraseTerm: &term.PhraseTerm{Chars: []string{`xx`, ` `, `yyyy`}}}},
-						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: `\+`, Chars: []string{`dsada`, `\ `, `7897`}}}},
-						{PrefixOp: "-", FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: `\-\-`, Chars: []string{`dsada`, `\-`, `7897`}}}},
+						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm(`\+dsada\ 7897`)}},
+						{PrefixOp: "-", FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm(`\-\-dsada\-7897`)}},
 						{PrefixOp: "", FieldTermGroup: &term.FieldTermGroup{DRangeTerm: &term.DRangeTerm{LBRACKET: "[", LValue: &term.RangeValue{SingleValue: []string{"-", "1"}}, RValue: &term.RangeValue{SingleValue: []string{"3"}}, RBRACKET: "]"}}},
 						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SRangeTerm: &term.SRangeTerm{Symbol: ">", Value: &term.RangeValue{SingleValue: []string{`2021`, "-", "11", "-", "04"}}}}},
 						{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{SRangeTerm: &term.SRangeTerm{Symbol: "<", Value: &term.RangeValue{SingleValue: []string{`2021`, "-", "11", "-", "11"}}}}},
@@ -44,7 +44,7 @@ func TestTermGroup(t *testing.T) {
 								{PrefixOp: "!", FieldTermGroup: &term.FieldTermGroup{DRangeTerm: &term.DRangeTerm{LBRACKET: "[", LValue: &term.RangeValue{SingleValue: []string{"-", "1"}}, RValue: &term.RangeValue{SingleValue: []string{"3"}}, RBRACKET: "]"}}},
 								{PrefixOp: "", FieldTermGroup: &term.FieldTermGroup{DRangeTerm: &term.DRangeTerm{LBRACKET: "[", LValue: &term.RangeValue{SingleValue: []string{"1"}}, RValue: &term.RangeValue{SingleValue: []string{"2"}}, RBRACKET: "]"}}},
 								{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{DRangeTerm: &term.DRangeTerm{LBRACKET: "[", LValue: &term.RangeValue{SingleValue: []string{"5"}}, RValue: &term.RangeValue{SingleValue: []string{"10"}}, RBRACKET: "}"}}},
-								{PrefixOp: "-", FieldTermGroup: &term.FieldTermGroup{SingleTerm: &term.SingleTerm{Begin: "dsadad", Chars: []string{`\ `, `dsad`, `\+`, `789`}}}},
+								{PrefixOp: "-", FieldTermGroup: &term.FieldTermGroup{SingleTerm: term.NewSingleTerm(`dsadad\ dsad\+789`)}},
 								{PrefixOp: "+", FieldTermGroup: &term.FieldTermGroup{PhraseTerm: &term.PhraseTerm{Chars: []string{`dsad`, ` `, `xx`}}}},
 							},
 						}},
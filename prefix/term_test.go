@@ -42,7 +42,7 @@ func TestTerm(t *testing.T) {
 		},
 		{
 			name:      "test_single",
-			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: &term.SingleTerm{Begin: "x"}, BoostSymbol: ""}},
+			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: term.NewSingleTerm("x"), BoostSymbol: ""}},
 			want1:     "x",
 			want2:     "x",
 			boost:     term.DefaultBoost,
@@ -52,7 +52,7 @@ func TestTerm(t *testing.T) {
 		},
 		{
 			name:      "test_single_with_boost",
-			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: &term.SingleTerm{Begin: "x"}, BoostSymbol: "^8"}},
+			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: term.NewSingleTerm("x"), BoostSymbol: "^8"}},
 			want1:     "x^8",
 			want2:     "x",
 			boost:     term.BoostValue(8),
@@ -62,7 +62,7 @@ func TestTerm(t *testing.T) {
 		},
 		{
 			name:      "test_single_with_fuzzy",
-			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: &term.SingleTerm{Begin: "x"}, FuzzySymbol: "~8"}},
+			input:     &Term{FuzzyTerm: &term.FuzzyTerm{SingleTerm: term.NewSingleTerm("x"), FuzzySymbol: "~8"}},
 			want1:     "x~8",
 			want2:     "x",
 			boost:     term.DefaultBoost,
@@ -169,7 +169,7 @@ func TestTerm(t *testing.T) {
 							{
 								PrefixOp: "+",
 								FieldTermGroup: &term.FieldTermGroup{
-									SingleTerm: &term.SingleTerm{Begin: "x"},
+									SingleTerm: term.NewSingleTerm("x"),
 								},
 							},
 						},
@@ -193,13 +193,13 @@ func TestTerm(t *testing.T) {
 							{
 								PrefixOp: "+",
 								FieldTermGroup: &term.FieldTermGroup{
-									SingleTerm: &term.SingleTerm{Begin: "x"},
+									SingleTerm: term.NewSingleTerm("x"),
 								},
 							},
 							{
 								PrefixOp: "-",
 								FieldTermGroup: &term.FieldTermGroup{
-									SingleTerm: &term.SingleTerm{Begin: "y"},
+									SingleTerm: term.NewSingleTerm("y"),
 								},
 							},
 						},
@@ -0,0 +1,337 @@
+// Package regex: parser, go regexp translator and matcher of lucene regexp (content of regexp term, i.e. `x:/ab.*c/`).
+// lucene regexp is anchored implicitly and supports `@` (any string), `#` (empty language), `&` (intersection),
+// `~` (complement), `<1-100>` (numeric interval) and `"..."` (literal string) besides common operators.
+package regex
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type op uint8
+
+const (
+	opEmpty        op = iota // `#`, matches nothing
+	opEpsilon                // `()`, matches empty string
+	opClass                  // one char in ranges, i.e. `a`, `.`, `[a-z]`
+	opAnyString              // `@`, matches any string
+	opConcat                 // `ab`
+	opUnion                  // `a|b`
+	opIntersection           // `a&b`
+	opComplement             // `~a`
+	opRepeat                 // `a*`, `a{1,3}`
+)
+
+type runeRange struct {
+	lo, hi rune
+}
+
+// Regexp: node of lucene regexp, nodes are normalized by constructors (i.e. nested unions are flattened and sorted),
+// so that equivalent nodes usually have the same key.
+type Regexp struct {
+	op       op
+	ranges   []runeRange // ranges of opClass
+	subs     []*Regexp
+	min, max int // repeat count of opRepeat, max is -1 if it's unbounded
+	key      string
+	nullable bool
+}
+
+// String: return lucene regexp of node
+func (r *Regexp) String() string {
+	if r == nil {
+		return ""
+	}
+	return r.key
+}
+
+// Nullable: check whether node matches empty string
+func (r *Regexp) Nullable() bool {
+	return r != nil && r.nullable
+}
+
+var (
+	emptyRegexp     = &Regexp{op: opEmpty, key: "#"}
+	epsilonRegexp   = &Regexp{op: opEpsilon, key: "()", nullable: true}
+	anyStringRegexp = &Regexp{op: opAnyString, key: "@", nullable: true}
+	anyCharRegexp   = newClass([]runeRange{{0, unicode.MaxRune}})
+)
+
+// newClass: make class of ranges, ranges are sorted and merged
+func newClass(ranges []runeRange) *Regexp {
+	var rs = append([]runeRange{}, ranges...)
+	sort.Slice(rs, func(i, j int) bool { return rs[i].lo < rs[j].lo })
+	var merged = []runeRange{}
+	for _, x := range rs {
+		if x.lo > x.hi {
+			continue
+		} else if n := len(merged); n != 0 && x.lo <= merged[n-1].hi+1 {
+			if x.hi > merged[n-1].hi {
+				merged[n-1].hi = x.hi
+			}
+		} else {
+			merged = append(merged, x)
+		}
+	}
+	if len(merged) == 0 {
+		return emptyRegexp
+	}
+	return &Regexp{op: opClass, ranges: merged, key: classKey(merged)}
+}
+
+func newChar(c rune) *Regexp {
+	return newClass([]runeRange{{c, c}})
+}
+
+// negateRanges: return ranges of chars which aren't in ranges
+func negateRanges(ranges []runeRange) []runeRange {
+	var res = []runeRange{}
+	var lo rune = 0
+	for _, x := range newClass(ranges).ranges {
+		if x.lo > lo {
+			res = append(res, runeRange{lo, x.lo - 1})
+		}
+		lo = x.hi + 1
+	}
+	if lo <= unicode.MaxRune {
+		res = append(res, runeRange{lo, unicode.MaxRune})
+	}
+	return res
+}
+
+func (r *Regexp) contains(c rune) bool {
+	var i = sort.Search(len(r.ranges), func(i int) bool { return r.ranges[i].hi >= c })
+	return i < len(r.ranges) && r.ranges[i].lo <= c
+}
+
+func newConcat(subs ...*Regexp) *Regexp {
+	var items = []*Regexp{}
+	for _, x := range subs {
+		if x.op == opEmpty {
+			return emptyRegexp
+		} else if x.op == opEpsilon {
+			continue
+		} else if x.op == opConcat {
+			items = append(items, x.subs...)
+		} else if x.op == opAnyString && len(items) != 0 && items[len(items)-1].op == opAnyString {
+			continue
+		} else {
+			items = append(items, x)
+		}
+	}
+	if len(items) == 0 {
+		return epsilonRegexp
+	} else if len(items) == 1 {
+		return items[0]
+	}
+	var res = &Regexp{op: opConcat, subs: items, nullable: true}
+	var sl = make([]string, 0, len(items))
+	for _, x := range items {
+		res.nullable = res.nullable && x.nullable
+		if x.op == opUnion || x.op == opIntersection {
+			sl = append(sl, "("+x.key+")")
+		} else {
+			sl = append(sl, x.key)
+		}
+	}
+	res.key = strings.Join(sl, "")
+	return res
+}
+
+// newNary: make union / intersection, operands are flattened, deduplicated and sorted by key
+func newNary(o op, subs []*Regexp) *Regexp {
+	var seen = map[string]bool{}
+	var items = []*Regexp{}
+	var ranges = []runeRange{}
+	for _, x := range subs {
+		var flat = []*Regexp{x}
+		if x.op == o {
+			flat = x.subs
+		}
+		for _, y := range flat {
+			if o == opUnion && y.op == opClass {
+				// classes of union are merged to one class
+				ranges = append(ranges, y.ranges...)
+			} else if !seen[y.key] {
+				seen[y.key] = true
+				items = append(items, y)
+			}
+		}
+	}
+	if len(ranges) != 0 {
+		items = append(items, newClass(ranges))
+	}
+	var res = &Regexp{op: o, nullable: o == opIntersection}
+	for _, x := range items {
+		if o == opUnion && x.op == opAnyString {
+			return anyStringRegexp
+		} else if o == opIntersection && x.op == opEmpty {
+			return emptyRegexp
+		} else if (o == opUnion && x.op == opEmpty) || (o == opIntersection && x.op == opAnyString) {
+			continue
+		}
+		res.subs = append(res.subs, x)
+		if o == opUnion {
+			res.nullable = res.nullable || x.nullable
+		} else {
+			res.nullable = res.nullable && x.nullable
+		}
+	}
+	if len(res.subs) == 0 && o == opUnion {
+		return emptyRegexp
+	} else if len(res.subs) == 0 {
+		return anyStringRegexp
+	} else if len(res.subs) == 1 {
+		return res.subs[0]
+	}
+	sort.Slice(res.subs, func(i, j int) bool { return res.subs[i].key < res.subs[j].key })
+	var sl = make([]string, 0, len(res.subs))
+	for _, x := range res.subs {
+		if o == opIntersection && x.op == opUnion {
+			sl = append(sl, "("+x.key+")")
+		} else {
+			sl = append(sl, x.key)
+		}
+	}
+	if o == opUnion {
+		res.key = strings.Join(sl, "|")
+	} else {
+		res.key = strings.Join(sl, "&")
+	}
+	return res
+}
+
+func newUnion(subs ...*Regexp) *Regexp {
+	return newNary(opUnion, subs)
+}
+
+func newIntersection(subs ...*Regexp) *Regexp {
+	return newNary(opIntersection, subs)
+}
+
+func newComplement(r *Regexp) *Regexp {
+	switch r.op {
+	case opComplement:
+		return r.subs[0]
+	case opAnyString:
+		return emptyRegexp
+	case opEmpty:
+		return anyStringRegexp
+	}
+	var res = &Regexp{op: opComplement, subs: []*Regexp{r}, nullable: !r.nullable}
+	if isAtom(r) {
+		res.key = "~" + r.key
+	} else {
+		res.key = "~(" + r.key + ")"
+	}
+	return res
+}
+
+func newRepeat(r *Regexp, min, max int) *Regexp {
+	if max == 0 || r.op == opEpsilon {
+		return epsilonRegexp
+	} else if r.op == opEmpty {
+		if min == 0 {
+			return epsilonRegexp
+		}
+		return emptyRegexp
+	} else if r.op == opAnyString || (min == 1 && max == 1) {
+		return r
+	}
+	var res = &Regexp{op: opRepeat, subs: []*Regexp{r}, min: min, max: max, nullable: min == 0 || r.nullable}
+	var key = r.key
+	if !isAtom(r) {
+		key = "(" + key + ")"
+	}
+	if min == 0 && max < 0 {
+		key += "*"
+	} else if min == 1 && max < 0 {
+		key += "+"
+	} else if min == 0 && max == 1 {
+		key += "?"
+	} else if max < 0 {
+		key += "{" + strconv.Itoa(min) + ",}"
+	} else if min == max {
+		key += "{" + strconv.Itoa(min) + "}"
+	} else {
+		key += "{" + strconv.Itoa(min) + "," + strconv.Itoa(max) + "}"
+	}
+	res.key = key
+	return res
+}
+
+func isAtom(r *Regexp) bool {
+	switch r.op {
+	case opEmpty, opEpsilon, opClass, opAnyString:
+		return true
+	}
+	return false
+}
+
+// reserved: chars which are escaped when writing lucene regexp
+const reserved = `.?+*|{}[]()"\#@&<>~`
+
+func classKey(ranges []runeRange) string {
+	if len(ranges) == 1 && ranges[0].lo == 0 && ranges[0].hi == unicode.MaxRune {
+		return "."
+	} else if len(ranges) == 1 && ranges[0].lo == ranges[0].hi {
+		return escapeRune(ranges[0].lo, reserved)
+	}
+	var b = strings.Builder{}
+	b.WriteByte('[')
+	for _, x := range ranges {
+		b.WriteString(escapeRune(x.lo, `[]^-\`))
+		if x.hi > x.lo {
+			b.WriteByte('-')
+			b.WriteString(escapeRune(x.hi, `[]^-\`))
+		}
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func escapeRune(c rune, special string) string {
+	if strings.ContainsRune(special, c) {
+		return `\` + string(c)
+	} else if !unicode.IsPrint(c) {
+		return `\` + string(c)
+	}
+	return string(c)
+}
+
+// LiteralPrefix: return literal prefix which every matched string must begin with, i.e. `ab` of `ab[cd].*`
+func (r *Regexp) LiteralPrefix() string {
+	var b = strings.Builder{}
+	var subs = []*Regexp{r}
+	if r.op == opConcat {
+		subs = r.subs
+	}
+	for _, x := range subs {
+		if x.op != opClass || len(x.ranges) != 1 || x.ranges[0].lo != x.ranges[0].hi {
+			break
+		}
+		b.WriteRune(x.ranges[0].lo)
+	}
+	return b.String()
+}
+
+// Unbounded: return number of parts which can match strings of unbounded length, i.e. `.*`, `a+`, `@` and `~a`
+func (r *Regexp) Unbounded() int {
+	var n = 0
+	switch r.op {
+	case opAnyString, opComplement:
+		n = 1
+	case opRepeat:
+		if r.max < 0 {
+			n = 1
+		}
+	}
+	if r.op != opComplement {
+		for _, x := range r.subs {
+			n += x.Unbounded()
+		}
+	}
+	return n
+}
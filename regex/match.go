@@ -0,0 +1,138 @@
+package regex
+
+import (
+	"sync"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// maxCachedStates: cache of transitions is cleared if number of states is greater than it
+const maxCachedStates = 10000
+
+// Matcher: matcher of lucene regexp which supports all operators (including intersection and complement).
+// regexp is regarded as state of automaton, and next state is Brzozowski derivative of regexp with respect to rune,
+// so states and transitions are built lazily (like lazy DFA) and cached. it's safe for concurrent use.
+type Matcher struct {
+	re     *Regexp
+	mu     sync.Mutex
+	states map[string]*dstate
+	start  *dstate
+}
+
+type dstate struct {
+	re   *Regexp
+	next map[rune]*dstate
+}
+
+// NewMatcher: make matcher of parsed lucene regexp
+func NewMatcher(r *Regexp) *Matcher {
+	var m = &Matcher{re: r}
+	m.reset()
+	return m
+}
+
+// Compile: parse lucene regexp and make matcher
+func Compile(s string) (*Matcher, error) {
+	var r, err = Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatcher(r), nil
+}
+
+// CompileTerm: parse content of regexp term and make matcher
+func CompileTerm(t *term.RegexpTerm) (*Matcher, error) {
+	var r, err = ParseTerm(t)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatcher(r), nil
+}
+
+// Regexp: return parsed lucene regexp of matcher
+func (m *Matcher) Regexp() *Regexp {
+	return m.re
+}
+
+// Match: check whether whole s is matched by lucene regexp
+func (m *Matcher) Match(s string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var st = m.start
+	for _, c := range s {
+		if st.re.op == opEmpty {
+			return false
+		} else if st.re.op == opAnyString {
+			return true
+		}
+		st = m.step(st, c)
+	}
+	return st.re.nullable
+}
+
+func (m *Matcher) reset() {
+	m.states = map[string]*dstate{}
+	m.start = m.state(m.re)
+}
+
+func (m *Matcher) state(r *Regexp) *dstate {
+	if st, ok := m.states[r.key]; ok {
+		return st
+	}
+	var st = &dstate{re: r, next: map[rune]*dstate{}}
+	m.states[r.key] = st
+	return st
+}
+
+func (m *Matcher) step(st *dstate, c rune) *dstate {
+	if next, ok := st.next[c]; ok {
+		return next
+	}
+	if len(m.states) > maxCachedStates {
+		m.reset()
+		st = m.state(st.re)
+	}
+	var next = m.state(derive(st.re, c))
+	st.next[c] = next
+	return next
+}
+
+// derive: return Brzozowski derivative of r with respect to c, which matches {s | c + s is matched by r}
+func derive(r *Regexp, c rune) *Regexp {
+	switch r.op {
+	case opClass:
+		if r.contains(c) {
+			return epsilonRegexp
+		}
+		return emptyRegexp
+	case opAnyString:
+		return anyStringRegexp
+	case opConcat:
+		var head, tail = r.subs[0], newConcat(r.subs[1:]...)
+		var res = newConcat(derive(head, c), tail)
+		if head.nullable {
+			res = newUnion(res, derive(tail, c))
+		}
+		return res
+	case opUnion, opIntersection:
+		var subs = make([]*Regexp, 0, len(r.subs))
+		for _, x := range r.subs {
+			subs = append(subs, derive(x, c))
+		}
+		return newNary(r.op, subs)
+	case opComplement:
+		return newComplement(derive(r.subs[0], c))
+	case opRepeat:
+		// if sub regexp is nullable, r{n,m} is same as r{0,m}
+		var min, max = r.min - 1, r.max - 1
+		if min < 0 || r.subs[0].nullable {
+			min = 0
+		}
+		if r.max < 0 {
+			max = -1
+		}
+		return newConcat(derive(r.subs[0], c), newRepeat(r.subs[0], min, max))
+	default:
+		return emptyRegexp
+	}
+}
@@ -0,0 +1,390 @@
+package regex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+var (
+	ErrSyntax      = fmt.Errorf("invalid lucene regexp")
+	ErrUnsupported = fmt.Errorf("unsupported lucene regexp operator")
+	ErrNotRegexp   = fmt.Errorf("term isn't regexp term")
+)
+
+// Parse: parse lucene regexp (without surrounding slashes), grammar is same as RegExp of lucene with ALL flags:
+//
+//	union  ::= inter ( '|' inter )*
+//	inter  ::= concat ( '&' concat )*
+//	concat ::= repeat+
+//	repeat ::= compl ( '?' | '*' | '+' | '{' n '}' | '{' n ',' '}' | '{' n ',' m '}' )*
+//	compl  ::= '~' compl | '[' '^'? ( char ( '-' char )? )+ ']' | simple
+//	simple ::= char | '.' | '#' | '@' | '"' string '"' | '(' ')' | '(' union ')' | '<' n '-' m '>'
+//	char   ::= unicode char | '\' unicode char
+//
+// `\d`, `\w`, `\s` and their negations `\D`, `\W`, `\S` are classes too.
+// named automaton (i.e. `<name>`) isn't supported and ErrUnsupported is returned.
+func Parse(s string) (*Regexp, error) {
+	var p = &parser{s: []rune(s)}
+	var r, err = p.parseUnion()
+	if err != nil {
+		return nil, err
+	} else if p.more() {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+	return r, nil
+}
+
+// ParseTerm: parse content of regexp term (i.e. `ab.*` of `/ab.*/`)
+func ParseTerm(t *term.RegexpTerm) (*Regexp, error) {
+	if t == nil || len(t.Chars) == 0 {
+		return nil, ErrNotRegexp
+	}
+	return Parse(strings.Join(t.Chars, ""))
+}
+
+type parser struct {
+	s   []rune
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", ErrSyntax, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *parser) more() bool {
+	return p.pos < len(p.s)
+}
+
+func (p *parser) peek(chars string) bool {
+	return p.more() && strings.ContainsRune(chars, p.s[p.pos])
+}
+
+func (p *parser) match(c rune) bool {
+	if p.more() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseUnion() (*Regexp, error) {
+	var r, err = p.parseInter()
+	if err != nil {
+		return nil, err
+	}
+	for p.match('|') {
+		var x, err = p.parseInter()
+		if err != nil {
+			return nil, err
+		}
+		r = newUnion(r, x)
+	}
+	return r, nil
+}
+
+func (p *parser) parseInter() (*Regexp, error) {
+	var r, err = p.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	for p.match('&') {
+		var x, err = p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		r = newIntersection(r, x)
+	}
+	return r, nil
+}
+
+func (p *parser) parseConcat() (*Regexp, error) {
+	var r, err = p.parseRepeat()
+	if err != nil {
+		return nil, err
+	}
+	for p.more() && !p.peek(")|&") {
+		var x, err = p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+		r = newConcat(r, x)
+	}
+	return r, nil
+}
+
+func (p *parser) parseRepeat() (*Regexp, error) {
+	var r, err = p.parseComplement()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("?*+{") {
+		switch {
+		case p.match('?'):
+			r = newRepeat(r, 0, 1)
+		case p.match('*'):
+			r = newRepeat(r, 0, -1)
+		case p.match('+'):
+			r = newRepeat(r, 1, -1)
+		case p.match('{'):
+			var min, max = 0, 0
+			if min, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+			max = min
+			if p.match(',') {
+				if p.peek("0123456789") {
+					if max, err = p.parseInt(); err != nil {
+						return nil, err
+					}
+				} else {
+					max = -1
+				}
+			}
+			if !p.match('}') {
+				return nil, p.errorf("expected '}'")
+			} else if max >= 0 && max < min {
+				return nil, p.errorf("invalid repeat {%d,%d}", min, max)
+			}
+			r = newRepeat(r, min, max)
+		}
+	}
+	return r, nil
+}
+
+func (p *parser) parseInt() (int, error) {
+	var start = p.pos
+	for p.peek("0123456789") {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("expected integer")
+	}
+	var n, err = strconv.Atoi(string(p.s[start:p.pos]))
+	if err != nil {
+		return 0, p.errorf("invalid integer %q", string(p.s[start:p.pos]))
+	}
+	return n, nil
+}
+
+func (p *parser) parseComplement() (*Regexp, error) {
+	if p.match('~') {
+		var r, err = p.parseComplement()
+		if err != nil {
+			return nil, err
+		}
+		return newComplement(r), nil
+	}
+	return p.parseCharClass()
+}
+
+func (p *parser) parseCharClass() (*Regexp, error) {
+	if !p.match('[') {
+		return p.parseSimple()
+	}
+	var negate = p.match('^')
+	var ranges = []runeRange{}
+	for {
+		// first char of class may be `]`, i.e. `[]a]`
+		if rs, ok := p.parseShorthand(); ok {
+			ranges = append(ranges, rs...)
+		} else {
+			var lo, err = p.parseChar()
+			if err != nil {
+				return nil, err
+			}
+			var hi = lo
+			if p.pos+1 < len(p.s) && p.s[p.pos] == '-' && p.s[p.pos+1] != ']' {
+				p.pos++
+				if hi, err = p.parseChar(); err != nil {
+					return nil, err
+				} else if hi < lo {
+					return nil, p.errorf("invalid class range %q-%q", lo, hi)
+				}
+			}
+			ranges = append(ranges, runeRange{lo, hi})
+		}
+		if !p.more() {
+			return nil, p.errorf("expected ']'")
+		} else if p.match(']') {
+			break
+		}
+	}
+	if negate {
+		ranges = negateRanges(ranges)
+	}
+	return newClass(ranges), nil
+}
+
+func (p *parser) parseSimple() (*Regexp, error) {
+	if !p.more() {
+		return nil, p.errorf("unexpected end of regexp")
+	} else if rs, ok := p.parseShorthand(); ok {
+		return newClass(rs), nil
+	}
+	switch {
+	case p.match('.'):
+		return anyCharRegexp, nil
+	case p.match('#'):
+		return emptyRegexp, nil
+	case p.match('@'):
+		return anyStringRegexp, nil
+	case p.match('"'):
+		var start = p.pos
+		for p.more() && p.s[p.pos] != '"' {
+			p.pos++
+		}
+		if !p.match('"') {
+			return nil, p.errorf("expected '\"'")
+		}
+		var items = []*Regexp{}
+		for _, c := range p.s[start : p.pos-1] {
+			items = append(items, newChar(c))
+		}
+		return newConcat(items...), nil
+	case p.match('('):
+		if p.match(')') {
+			return epsilonRegexp, nil
+		}
+		var r, err = p.parseUnion()
+		if err != nil {
+			return nil, err
+		} else if !p.match(')') {
+			return nil, p.errorf("expected ')'")
+		}
+		return r, nil
+	case p.match('<'):
+		return p.parseInterval()
+	default:
+		var c, err = p.parseChar()
+		if err != nil {
+			return nil, err
+		}
+		return newChar(c), nil
+	}
+}
+
+func (p *parser) parseChar() (rune, error) {
+	p.match('\\')
+	if !p.more() {
+		return 0, p.errorf("unexpected end of regexp")
+	}
+	p.pos++
+	return p.s[p.pos-1], nil
+}
+
+var shorthands = map[rune][]runeRange{
+	'd': {{'0', '9'}},
+	'w': {{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}},
+	's': {{'\t', '\n'}, {'\v', '\v'}, {'\f', '\r'}, {' ', ' '}},
+}
+
+// parseShorthand: parse `\d`, `\w`, `\s` and negations `\D`, `\W`, `\S`
+func (p *parser) parseShorthand() ([]runeRange, bool) {
+	if p.pos+1 >= len(p.s) || p.s[p.pos] != '\\' {
+		return nil, false
+	}
+	var c = p.s[p.pos+1]
+	if rs, ok := shorthands[c]; ok {
+		p.pos += 2
+		return rs, true
+	} else if rs, ok := shorthands[unicode.ToLower(c)]; ok && unicode.IsUpper(c) {
+		p.pos += 2
+		return negateRanges(rs), true
+	}
+	return nil, false
+}
+
+// parseInterval: parse numeric interval `<n-m>`, if n and m have same number of digits, number must have exactly
+// that number of digits (i.e. `<01-10>` matches `07`), otherwise number may have any leading zeros (i.e. `<1-100>` matches `007`).
+func (p *parser) parseInterval() (*Regexp, error) {
+	var start = p.pos
+	for p.more() && p.s[p.pos] != '>' {
+		p.pos++
+	}
+	if !p.match('>') {
+		return nil, p.errorf("expected '>'")
+	}
+	var content = string(p.s[start : p.pos-1])
+	var i = strings.IndexByte(content, '-')
+	if i < 0 {
+		return nil, fmt.Errorf("%w: named automaton <%s>", ErrUnsupported, content)
+	}
+	var lo, hi = content[:i], content[i+1:]
+	if !isDigits(lo) || !isDigits(hi) {
+		return nil, p.errorf("invalid interval <%s>", content)
+	}
+	var digits = 0
+	if len(lo) == len(hi) {
+		digits = len(lo)
+	}
+	var min, err1 = strconv.ParseUint(lo, 10, 63)
+	var max, err2 = strconv.ParseUint(hi, 10, 63)
+	if err1 != nil || err2 != nil {
+		return nil, p.errorf("invalid interval <%s>", content)
+	} else if min > max {
+		min, max = max, min
+	}
+	return interval(min, max, digits), nil
+}
+
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// interval: make union of digit ranges of numbers between min and max
+func interval(min, max uint64, digits int) *Regexp {
+	if digits > 0 {
+		return digitRange(fmt.Sprintf("%0*d", digits, min), fmt.Sprintf("%0*d", digits, max))
+	}
+	var lo, hi = strconv.FormatUint(min, 10), strconv.FormatUint(max, 10)
+	var items = []*Regexp{}
+	for n := len(lo); n <= len(hi); n++ {
+		var a, b = strings.Repeat("0", n), strings.Repeat("9", n)
+		if n > 1 {
+			a = "1" + a[1:]
+		}
+		if n == len(lo) {
+			a = lo
+		}
+		if n == len(hi) {
+			b = hi
+		}
+		items = append(items, digitRange(a, b))
+	}
+	return newConcat(newRepeat(newChar('0'), 0, -1), newUnion(items...))
+}
+
+// digitRange: make regexp of numbers between a and b which have same number of digits
+func digitRange(a, b string) *Regexp {
+	if len(a) == 0 {
+		return epsilonRegexp
+	} else if a[0] == b[0] {
+		return newConcat(newChar(rune(a[0])), digitRange(a[1:], b[1:]))
+	}
+	var n = len(a) - 1
+	var zeros, nines = strings.Repeat("0", n), strings.Repeat("9", n)
+	var digit = newClass([]runeRange{{'0', '9'}})
+	if a[1:] == zeros && b[1:] == nines {
+		return newConcat(newClass([]runeRange{{rune(a[0]), rune(b[0])}}), newRepeat(digit, n, n))
+	}
+	var items = []*Regexp{
+		newConcat(newChar(rune(a[0])), digitRange(a[1:], nines)),
+		newConcat(newChar(rune(b[0])), digitRange(zeros, b[1:])),
+	}
+	if b[0]-a[0] > 1 {
+		items = append(items, newConcat(newClass([]runeRange{{rune(a[0] + 1), rune(b[0] - 1)}}), newRepeat(digit, n, n)))
+	}
+	return newUnion(items...)
+}
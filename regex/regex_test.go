@@ -0,0 +1,269 @@
+package regex
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestParse(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}
+	var testCases = []testCase{
+		{name: "literal", input: `abc`, want: `abc`},
+		{name: "any_char", input: `a.c`, want: `a.c`},
+		{name: "union_of_chars", input: `a|c|b`, want: `[a-c]`},
+		{name: "union", input: `ab|cd`, want: `ab|cd`},
+		{name: "intersection", input: `a.*&.*b`, want: `.*b&a.*`},
+		{name: "complement", input: `~(ab)`, want: `~(ab)`},
+		{name: "double_complement", input: `~~a`, want: `a`},
+		{name: "complement_of_empty", input: `~#`, want: `@`},
+		{name: "repeat", input: `a{2,3}b{2}c{1,}d+e?f*`, want: `a{2,3}b{2}c+d+e?f*`},
+		{name: "class", input: `[a-cx]`, want: `[a-cx]`},
+		{name: "class_with_bracket", input: `[]a]`, want: `[\]a]`},
+		{name: "class_with_minus", input: `[a-]`, want: `[\-a]`},
+		{name: "shorthand", input: `\d\w`, want: `[0-9][0-9A-Z_a-z]`},
+		{name: "quoted", input: `"a.b"`, want: `a\.b`},
+		{name: "escaped", input: `a\.b\/`, want: `a\.b/`},
+		{name: "epsilon", input: `a()b`, want: `ab`},
+		{name: "fixed_interval", input: `<01-10>`, want: `0[1-9]|10`},
+		{name: "interval", input: `<1-100>`, want: `0*(100|[1-9]|[1-9][0-9])`},
+		{name: "reverse_interval", input: `<9-0>`, want: `[0-9]`},
+		{name: "empty", input: ``, err: ErrSyntax},
+		{name: "unclosed_paren", input: `(ab`, err: ErrSyntax},
+		{name: "unclosed_class", input: `[ab`, err: ErrSyntax},
+		{name: "invalid_repeat", input: `a{3,2}`, err: ErrSyntax},
+		{name: "invalid_class_range", input: `[z-a]`, err: ErrSyntax},
+		{name: "trailing_union", input: `a|`, err: ErrSyntax},
+		{name: "named_automaton", input: `<name>`, err: ErrUnsupported},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var r, err = Parse(tt.input)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, r.String())
+		})
+	}
+}
+
+func TestGoRegexp(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}
+	var testCases = []testCase{
+		{name: "literal", input: `a.b`, want: `(?s)^(?:a.b)$`},
+		{name: "any_string", input: `ab@`, want: `(?s)^(?:ab.*)$`},
+		{name: "repeat", input: `(ab)*c+d{2,3}`, want: `(?s)^(?:(?:ab)*c+d{2,3})$`},
+		{name: "union_in_concat", input: `a(b|cd)`, want: `(?s)^(?:a(?:b|cd))$`},
+		{name: "meta", input: `"a+b"`, want: `(?s)^(?:a\+b)$`},
+		{name: "empty_language", input: `#`, want: `(?s)^(?:[^\x00-\x{10FFFF}])$`},
+		{name: "intersection", input: `a&b`, err: ErrUnsupported},
+		{name: "complement", input: `a~b`, err: ErrUnsupported},
+		{name: "large_repeat", input: `a{1001}`, err: ErrUnsupported},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var r, err = Parse(tt.input)
+			assert.Nil(t, err)
+			got, err := r.GoRegexp()
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			_, err = r.CompileGo()
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		match   []string
+		unmatch []string
+	}
+	var testCases = []testCase{
+		{name: "anchored", input: `ab`, match: []string{"ab"}, unmatch: []string{"", "abc", "xab"}},
+		{name: "any_string", input: `a@`, match: []string{"a", "abc", "a\nb"}, unmatch: []string{"", "ba"}},
+		{name: "empty_language", input: `#|a`, match: []string{"a"}, unmatch: []string{"", "#"}},
+		{name: "intersection", input: `@a@&@b@`, match: []string{"ab", "ba", "xaybz"}, unmatch: []string{"a", "b", "xyz"}},
+		{name: "not_contains", input: `~(@ab@)`, match: []string{"", "ba", "aa", "bba"}, unmatch: []string{"ab", "xaby"}},
+		{name: "complement_char", input: `~a`, match: []string{"", "b", "aa"}, unmatch: []string{"a"}},
+		{name: "repeat_nullable", input: `(a?){3}`, match: []string{"", "a", "aaa"}, unmatch: []string{"aaaa"}},
+		{name: "fixed_interval", input: `<01-10>`, match: []string{"01", "07", "10"}, unmatch: []string{"1", "00", "11", "007"}},
+		{name: "interval", input: `<1-100>`, match: []string{"1", "007", "100", "0100"}, unmatch: []string{"0", "101", ""}},
+		{name: "unicode", input: `中.`, match: []string{"中文"}, unmatch: []string{"中", "文中"}},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var m, err = Compile(tt.input)
+			assert.Nil(t, err)
+			for _, s := range tt.match {
+				assert.True(t, m.Match(s), s)
+			}
+			for _, s := range tt.unmatch {
+				assert.False(t, m.Match(s), s)
+			}
+		})
+	}
+}
+
+func TestMatchInterval(t *testing.T) {
+	for _, tt := range []struct {
+		input    string
+		min, max int
+		digits   int
+	}{
+		{input: `<0-999>`, min: 0, max: 999},
+		{input: `<17-5302>`, min: 17, max: 5302},
+		{input: `<0042-0815>`, min: 42, max: 815, digits: 4},
+		{input: `<300-300>`, min: 300, max: 300, digits: 3},
+	} {
+		var m, err = Compile(tt.input)
+		assert.Nil(t, err)
+		re, err := m.Regexp().CompileGo()
+		assert.Nil(t, err)
+		for n := 0; n < 10000; n++ {
+			for _, s := range []string{strconv.Itoa(n), fmt.Sprintf("%04d", n), fmt.Sprintf("%03d", n)} {
+				var want = n >= tt.min && n <= tt.max
+				if tt.digits > 0 {
+					want = want && len(s) == tt.digits
+				}
+				assert.Equal(t, want, m.Match(s), "%s %s", tt.input, s)
+				assert.Equal(t, want, re.MatchString(s), "%s %s", tt.input, s)
+			}
+		}
+	}
+}
+
+// randomRegexp: make random regexp with alphabet `abc`
+func randomRegexp(rnd *rand.Rand, depth int, complement bool) string {
+	var n = 7
+	if depth == 0 {
+		n = 3
+	} else if complement {
+		n = 9
+	}
+	switch rnd.Intn(n) {
+	case 0:
+		return string(rune('a' + rnd.Intn(3)))
+	case 1:
+		return []string{".", "@", "[ab]", "[^a]", "()", "#"}[rnd.Intn(6)]
+	case 2:
+		return `"` + string(rune('a'+rnd.Intn(3))) + string(rune('a'+rnd.Intn(3))) + `"`
+	case 3, 4:
+		return randomRegexp(rnd, depth-1, complement) + randomRegexp(rnd, depth-1, complement)
+	case 5:
+		return "(" + randomRegexp(rnd, depth-1, complement) + "|" + randomRegexp(rnd, depth-1, complement) + ")"
+	case 6:
+		var ops = []string{"*", "+", "?", "{2}", "{1,2}", "{0,}"}
+		return "(" + randomRegexp(rnd, depth-1, complement) + ")" + ops[rnd.Intn(len(ops))]
+	case 7:
+		return "(" + randomRegexp(rnd, depth-1, complement) + "&" + randomRegexp(rnd, depth-1, complement) + ")"
+	default:
+		return "~(" + randomRegexp(rnd, depth-1, complement) + ")"
+	}
+}
+
+func randomString(rnd *rand.Rand) string {
+	var b = strings.Builder{}
+	for i := rnd.Intn(7); i > 0; i-- {
+		b.WriteByte(byte('a' + rnd.Intn(3)))
+	}
+	return b.String()
+}
+
+func TestMatchRandom(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var s = randomRegexp(rnd, 4, false)
+		var r, err = Parse(s)
+		assert.Nil(t, err, s)
+		g, err := r.GoRegexp()
+		assert.Nil(t, err, s)
+		var re = regexp.MustCompile(g)
+		var m = NewMatcher(r)
+		for j := 0; j < 50; j++ {
+			var x = randomString(rnd)
+			assert.Equal(t, re.MatchString(x), m.Match(x), "%s %s", s, x)
+		}
+		// normalized regexp is equivalent to origin one
+		r2, err := Parse(r.String())
+		assert.Nil(t, err, r.String())
+		assert.Equal(t, r.String(), r2.String())
+	}
+}
+
+func TestMatchRandomBoolean(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(2))
+	for i := 0; i < 300; i++ {
+		var a, b = randomRegexp(rnd, 3, true), randomRegexp(rnd, 3, true)
+		ma, err := Compile(a)
+		assert.Nil(t, err, a)
+		mb, err := Compile(b)
+		assert.Nil(t, err, b)
+		and, err := Compile("(" + a + ")&(" + b + ")")
+		assert.Nil(t, err)
+		not, err := Compile("~(" + a + ")")
+		assert.Nil(t, err)
+		for j := 0; j < 50; j++ {
+			var x = randomString(rnd)
+			assert.Equal(t, ma.Match(x) && mb.Match(x), and.Match(x), "(%s)&(%s) %s", a, b, x)
+			assert.Equal(t, !ma.Match(x), not.Match(x), "~(%s) %s", a, x)
+		}
+	}
+}
+
+func TestCompileTerm(t *testing.T) {
+	var m, err = CompileTerm(&term.RegexpTerm{Chars: []string{`a`, `\/`, `b`, `.`, `*`}})
+	assert.Nil(t, err)
+	assert.Equal(t, `a/b.*`, m.Regexp().String())
+	assert.True(t, m.Match("a/bcd"))
+	assert.False(t, m.Match("ab"))
+
+	_, err = CompileTerm(nil)
+	assert.Equal(t, ErrNotRegexp, err)
+}
+
+func TestRegexpInfo(t *testing.T) {
+	type testCase struct {
+		input     string
+		prefix    string
+		unbounded int
+	}
+	for _, tt := range []testCase{
+		{input: `abc`, prefix: `abc`, unbounded: 0},
+		{input: `ab[cd].*`, prefix: `ab`, unbounded: 1},
+		{input: `.*.*.*a`, prefix: ``, unbounded: 3},
+		{input: `a@b+`, prefix: `a`, unbounded: 2},
+		{input: `x~(a*b*)`, prefix: `x`, unbounded: 1},
+		{input: `a|b`, prefix: ``, unbounded: 0},
+		{input: `a{2,5}`, prefix: ``, unbounded: 0},
+	} {
+		var r, err = Parse(tt.input)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.prefix, r.LiteralPrefix(), tt.input)
+		assert.Equal(t, tt.unbounded, r.Unbounded(), tt.input)
+	}
+}
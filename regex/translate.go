@@ -0,0 +1,125 @@
+package regex
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxGoRepeat: maximum repeat count which is supported by go regexp
+const MaxGoRepeat = 1000
+
+// GoRegexp: translate lucene regexp to anchored go regexp (i.e. `ab.*` is translated to `(?s)^(?:ab.*)$`),
+// ErrUnsupported is returned for intersection (`&`), complement (`~`) and repeat count which is greater than MaxGoRepeat,
+// use Match for such regexp. numeric interval (i.e. `<1-100>`) is translated to alternation of digit classes.
+func (r *Regexp) GoRegexp() (string, error) {
+	var b = strings.Builder{}
+	b.WriteString("(?s)^(?:")
+	if err := r.writeGo(&b); err != nil {
+		return "", err
+	}
+	b.WriteString(")$")
+	return b.String(), nil
+}
+
+// CompileGo: translate lucene regexp to go regexp and compile it
+func (r *Regexp) CompileGo() (*regexp.Regexp, error) {
+	var s, err = r.GoRegexp()
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(s)
+}
+
+func (r *Regexp) writeGo(b *strings.Builder) error {
+	switch r.op {
+	case opEmpty:
+		b.WriteString(`[^\x00-\x{10FFFF}]`)
+	case opEpsilon:
+		b.WriteString("(?:)")
+	case opAnyString:
+		b.WriteString(".*")
+	case opClass:
+		writeGoClass(b, r.ranges)
+	case opConcat:
+		for _, x := range r.subs {
+			if x.op == opUnion {
+				b.WriteString("(?:")
+			}
+			if err := x.writeGo(b); err != nil {
+				return err
+			}
+			if x.op == opUnion {
+				b.WriteString(")")
+			}
+		}
+	case opUnion:
+		for i, x := range r.subs {
+			if i != 0 {
+				b.WriteByte('|')
+			}
+			if err := x.writeGo(b); err != nil {
+				return err
+			}
+		}
+	case opRepeat:
+		if r.min > MaxGoRepeat || r.max > MaxGoRepeat {
+			return fmt.Errorf("%w: repeat count is greater than %d in go regexp: %s", ErrUnsupported, MaxGoRepeat, r)
+		}
+		if sub := r.subs[0]; sub.op == opClass {
+			writeGoClass(b, sub.ranges)
+		} else {
+			b.WriteString("(?:")
+			if err := sub.writeGo(b); err != nil {
+				return err
+			}
+			b.WriteString(")")
+		}
+		if r.min == 0 && r.max < 0 {
+			b.WriteString("*")
+		} else if r.min == 1 && r.max < 0 {
+			b.WriteString("+")
+		} else if r.max < 0 {
+			b.WriteString("{" + strconv.Itoa(r.min) + ",}")
+		} else {
+			b.WriteString("{" + strconv.Itoa(r.min) + "," + strconv.Itoa(r.max) + "}")
+		}
+	case opIntersection:
+		return fmt.Errorf("%w: intersection isn't supported by go regexp: %s", ErrUnsupported, r)
+	case opComplement:
+		return fmt.Errorf("%w: complement isn't supported by go regexp: %s", ErrUnsupported, r)
+	}
+	return nil
+}
+
+func writeGoClass(b *strings.Builder, ranges []runeRange) {
+	if len(ranges) == 1 && ranges[0].lo == 0 && ranges[0].hi == unicode.MaxRune {
+		b.WriteByte('.')
+		return
+	} else if len(ranges) == 1 && ranges[0].lo == ranges[0].hi && unicode.IsPrint(ranges[0].lo) {
+		b.WriteString(regexp.QuoteMeta(string(ranges[0].lo)))
+		return
+	}
+	b.WriteByte('[')
+	for _, x := range ranges {
+		writeGoClassRune(b, x.lo)
+		if x.hi > x.lo {
+			b.WriteByte('-')
+			writeGoClassRune(b, x.hi)
+		}
+	}
+	b.WriteByte(']')
+}
+
+func writeGoClassRune(b *strings.Builder, c rune) {
+	if strings.ContainsRune(`\[]^-`, c) {
+		b.WriteByte('\\')
+		b.WriteRune(c)
+	} else if unicode.IsPrint(c) {
+		b.WriteRune(c)
+	} else {
+		fmt.Fprintf(b, `\x{%x}`, c)
+	}
+}
@@ -0,0 +1,218 @@
+package standard
+
+// Clone: deep copy lucene query, the copy doesn't share any memory with origin
+func (q *Lucene) Clone() *Lucene {
+	if q == nil {
+		return nil
+	}
+	return &Lucene{Query: q.Query.Clone()}
+}
+
+func (q *Query) Clone() *Query {
+	if q == nil {
+		return nil
+	}
+	var res = &Query{}
+	if q.DisjQueries != nil {
+		res.DisjQueries = make([]*DisjQuery, 0, len(q.DisjQueries))
+		for _, x := range q.DisjQueries {
+			res.DisjQueries = append(res.DisjQueries, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *DisjQuery) Clone() *DisjQuery {
+	if q == nil {
+		return nil
+	}
+	var res = &DisjQuery{}
+	if q.ConjQueries != nil {
+		res.ConjQueries = make([]*ConjQuery, 0, len(q.ConjQueries))
+		for _, x := range q.ConjQueries {
+			res.ConjQueries = append(res.ConjQueries, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *ConjQuery) Clone() *ConjQuery {
+	if q == nil {
+		return nil
+	}
+	var res = &ConjQuery{}
+	if q.ModClauses != nil {
+		res.ModClauses = make([]*ModClause, 0, len(q.ModClauses))
+		for _, x := range q.ModClauses {
+			res.ModClauses = append(res.ModClauses, x.Clone())
+		}
+	}
+	return res
+}
+
+func (q *ModClause) Clone() *ModClause {
+	if q == nil {
+		return nil
+	}
+	return &ModClause{Modifier: q.Modifier, Clause: q.Clause.Clone()}
+}
+
+func (q *Clause) Clone() *Clause {
+	if q == nil {
+		return nil
+	}
+	return &Clause{
+		Field:      q.Field.Clone(),
+		TermExpr:   q.TermExpr.Clone(),
+		PhraseExpr: q.PhraseExpr.Clone(),
+		GroupExpr:  q.GroupExpr.Clone(),
+		RegexpExpr: q.RegexpExpr.Clone(),
+		RangeExpr:  q.RangeExpr.Clone(),
+		Boost:      q.Boost.Clone(),
+	}
+}
+
+func (q *TermExpr) Clone() *TermExpr {
+	if q == nil {
+		return nil
+	}
+	return &TermExpr{Term: q.Term.Clone(), Fuzzy: q.Fuzzy.Clone()}
+}
+
+func (q *Range) Clone() *Range {
+	if q == nil {
+		return nil
+	}
+	return &Range{SingleRange: q.SingleRange.Clone(), DoubleRange: q.DoubleRange.Clone()}
+}
+
+func (q *PhraseExpr) Clone() *PhraseExpr {
+	if q == nil {
+		return nil
+	}
+	return &PhraseExpr{Phrase: q.Phrase.Clone(), Fuzzy: q.Fuzzy.Clone()}
+}
+
+func (q *GroupExpr) Clone() *GroupExpr {
+	if q == nil {
+		return nil
+	}
+	return &GroupExpr{Query: q.Query.Clone()}
+}
+
+func (q *TERM) Clone() *TERM {
+	if q == nil {
+		return nil
+	}
+	return &TERM{Token: cloneStrings(q.Token)}
+}
+
+func (q *Phrase) Clone() *Phrase {
+	if q == nil {
+		return nil
+	}
+	return &Phrase{Token: cloneStrings(q.Token)}
+}
+
+func (q *Regexp) Clone() *Regexp {
+	if q == nil {
+		return nil
+	}
+	return &Regexp{Token: cloneStrings(q.Token)}
+}
+
+func (q *SingleRange) Clone() *SingleRange {
+	if q == nil {
+		return nil
+	}
+	return &SingleRange{Compare: q.Compare, RangeValue: q.RangeValue.Clone()}
+}
+
+func (q *DoubleRange) Clone() *DoubleRange {
+	if q == nil {
+		return nil
+	}
+	return &DoubleRange{
+		LParen: q.LParen,
+		Left:   q.Left.Clone(),
+		TO:     q.TO,
+		Right:  q.Right.Clone(),
+		RParen: q.RParen,
+	}
+}
+
+func (q *RangeValue) Clone() *RangeValue {
+	if q == nil {
+		return nil
+	}
+	return &RangeValue{Term: q.Term.Clone(), Phrase: q.Phrase.Clone(), Number: q.Number.Clone()}
+}
+
+func (q *RangeNode) Clone() *RangeNode {
+	if q == nil {
+		return nil
+	}
+	var res = &RangeNode{RangeValue: q.RangeValue.Clone()}
+	if q.Infinite != nil {
+		var infinite = *q.Infinite
+		res.Infinite = &infinite
+	}
+	return res
+}
+
+func (q *FieldName) Clone() *FieldName {
+	if q == nil {
+		return nil
+	}
+	return &FieldName{FieldName: q.FieldName.Clone()}
+}
+
+func (q *Boost) Clone() *Boost {
+	if q == nil {
+		return nil
+	}
+	return &Boost{Number: q.Number.Clone()}
+}
+
+func (q *Fuzzy) Clone() *Fuzzy {
+	if q == nil {
+		return nil
+	}
+	return &Fuzzy{Number: q.Number.Clone()}
+}
+
+func (q *Number) Clone() *Number {
+	if q == nil {
+		return nil
+	}
+	return &Number{Integer: q.Integer, Decimal: q.Decimal}
+}
+
+func (q *AND) Clone() *AND {
+	if q == nil {
+		return nil
+	}
+	return &AND{AND: q.AND}
+}
+
+func (q *OR) Clone() *OR {
+	if q == nil {
+		return nil
+	}
+	return &OR{OR: q.OR}
+}
+
+func (q *NOT) Clone() *NOT {
+	if q == nil {
+		return nil
+	}
+	return &NOT{NOT: q.NOT}
+}
+
+// cloneStrings: copy slice of string, nil slice is still nil after copying
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
@@ -0,0 +1,27 @@
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	for _, input := range []string{
+		`"jakarta apache"^4 "Apache Lucene"`,
+		`title:(+return +"pink panther")`,
+		`mod_date:[20020101 TO 20030101}`,
+		`age:>=10 roam~0.8 te?t`,
+		`age:/[0-9]+(\.[0-9])?/ OR te*t`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			q, err := ParseLucene(input)
+			assert.Nil(t, err)
+			var c = q.Clone()
+			assert.Equal(t, q, c)
+			c.Query.DisjQueries = nil
+			assert.NotEqual(t, q, c)
+		})
+	}
+	assert.Nil(t, (*Lucene)(nil).Clone())
+}
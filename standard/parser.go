@@ -0,0 +1,196 @@
+package standard
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/alecthomas/participle"
+	"github.com/zhuliquan/lucene_parser"
+)
+
+// Parser: parser of standard lucene syntax with options, Parser isn't modified after NewParser returns,
+// so that it's safe for concurrent use by multiple goroutines and should be built once and reused.
+type Parser struct {
+	caseSensitive bool
+	defaultField  *FieldName
+	features      lucene_parser.Feature
+	limits        lucene_parser.ParseLimits
+	err           error // error of options which is returned by Parse
+}
+
+// Option: option of Parser
+type Option func(*Parser)
+
+// WithDefaultField: field of clauses which are written without field, i.e. `foo` is parsed as `field:foo`
+// and `(foo bar)` is parsed as `(field:foo field:bar)`, terms of group of field (i.e. `x:(foo bar)`) are kept.
+func WithDefaultField(field string) Option {
+	return func(p *Parser) {
+		var q, err = parseParticiple(LuceneParser, field+":*")
+		if err != nil || q.Query.DisjQueries[0].ConjQueries[0].ModClauses[0].Clause.Field == nil {
+			p.err = fmt.Errorf("%w: default field %q, err: %+v", lucene_parser.ErrInvalidOption, field, err)
+			return
+		}
+		p.defaultField = q.Query.DisjQueries[0].ConjQueries[0].ModClauses[0].Clause.Field
+	}
+}
+
+// WithCaseSensitiveOperators: whether words of operators are matched case-sensitively, default is false,
+// i.e. `And` is regarded as `AND`. if it's true, only `AND` / `and`, `OR` / `or` and `TO` are operators.
+func WithCaseSensitiveOperators(caseSensitive bool) Option {
+	return func(p *Parser) {
+		p.caseSensitive = caseSensitive
+	}
+}
+
+// WithFeatures: allowed syntax features, default is ALL_FEATURES. group of clauses without field is PAREN_FEATURE,
+// group of field is TERM_GROUP_FEATURE, field with wildcard is FIELD_GROUP_FEATURE and `-` / `!` are NOT_FEATURE.
+func WithFeatures(features lucene_parser.Feature) Option {
+	return func(p *Parser) {
+		p.features = features
+	}
+}
+
+// WithLimits: limits of query which are checked before parsing, default is no limits.
+func WithLimits(limits lucene_parser.ParseLimits) Option {
+	return func(p *Parser) {
+		p.limits = limits
+	}
+}
+
+// NewParser: build parser with options, parser without options is the same as ParseLucene.
+func NewParser(opts ...Option) *Parser {
+	var p = &Parser{features: lucene_parser.ALL_FEATURES}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+var (
+	caseSensitiveParser *participle.Parser
+	caseSensitiveOnce   sync.Once
+)
+
+// participle: parser whose literals of operators are matched case-sensitively is built on first use
+func (p *Parser) participle() *participle.Parser {
+	if !p.caseSensitive {
+		return LuceneParser
+	}
+	caseSensitiveOnce.Do(func() {
+		caseSensitiveParser = participle.MustBuild(
+			&Lucene{},
+			participle.Lexer(Lexer),
+			participle.UseLookahead(1024),
+		)
+	})
+	return caseSensitiveParser
+}
+
+// Parse: parse query to Lucene struct, every call returns new ast which isn't shared with other calls.
+func (p *Parser) Parse(queryString string) (*Lucene, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := p.limits.Check(queryString); err != nil {
+		return nil, err
+	}
+	var q, err = parseParticiple(p.participle(), queryString)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.checkFeatures(q.Query); err != nil {
+		return nil, err
+	}
+	if p.defaultField != nil {
+		p.fillField(q.Query)
+	}
+	return q, nil
+}
+
+// fillField: set default field to clauses without field, groups of field aren't filled
+func (p *Parser) fillField(q *Query) {
+	for _, disj := range q.DisjQueries {
+		for _, conj := range disj.ConjQueries {
+			for _, x := range conj.ModClauses {
+				if c := x.Clause; c.Field == nil && c.GroupExpr != nil {
+					p.fillField(c.GroupExpr.Query)
+				} else if c.Field == nil {
+					c.Field = p.defaultField.Clone()
+				}
+			}
+		}
+	}
+}
+
+// checkFeatures: return ErrFeatureNotAllowed with features of the first clause which aren't allowed
+func (p *Parser) checkFeatures(q *Query) error {
+	if p.features&lucene_parser.ALL_FEATURES == lucene_parser.ALL_FEATURES {
+		return nil
+	}
+	for _, disj := range q.DisjQueries {
+		for _, conj := range disj.ConjQueries {
+			for _, x := range conj.ModClauses {
+				if f := clauseFeatures(x) &^ p.features; f != 0 {
+					return fmt.Errorf("%w: %s", lucene_parser.ErrFeatureNotAllowed, f)
+				}
+				if x.Clause.GroupExpr != nil {
+					if err := p.checkFeatures(x.Clause.GroupExpr.Query); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// clauseFeatures: features used by clause itself, features of clauses of group aren't included
+func clauseFeatures(x *ModClause) lucene_parser.Feature {
+	var res lucene_parser.Feature
+	if x.Modifier == "-" || x.Modifier == "!" {
+		res |= lucene_parser.NOT_FEATURE
+	}
+	var c = x.Clause
+	if c.Field != nil && isWildcard(c.Field.FieldName) {
+		res |= lucene_parser.FIELD_GROUP_FEATURE
+	}
+	switch {
+	case c.TermExpr != nil:
+		if isWildcard(c.TermExpr.Term) {
+			res |= lucene_parser.WILDCARD_FEATURE
+		}
+		if c.TermExpr.Fuzzy != nil {
+			res |= lucene_parser.FUZZY_FEATURE
+		}
+	case c.PhraseExpr != nil:
+		res |= lucene_parser.PHRASE_FEATURE
+		if c.PhraseExpr.Fuzzy != nil {
+			res |= lucene_parser.FUZZY_FEATURE
+		}
+	case c.GroupExpr != nil && c.Field != nil:
+		res |= lucene_parser.TERM_GROUP_FEATURE
+	case c.GroupExpr != nil:
+		res |= lucene_parser.PAREN_FEATURE
+	case c.RegexpExpr != nil:
+		res |= lucene_parser.REGEXP_FEATURE
+	case c.RangeExpr != nil:
+		res |= lucene_parser.RANGE_FEATURE
+	}
+	if c.Boost != nil {
+		res |= lucene_parser.BOOST_FEATURE
+	}
+	return res
+}
+
+// isWildcard: check whether term has wildcard, single `*` matches any value and it isn't wildcard
+func isWildcard(t *TERM) bool {
+	if t == nil || len(t.Token) == 1 && t.Token[0] == "*" {
+		return false
+	}
+	for _, x := range t.Token {
+		if x == "*" || x == "?" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package standard
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser"
+)
+
+func field(tokens ...string) *FieldName {
+	return &FieldName{FieldName: &TERM{Token: tokens}}
+}
+
+// note: lexer of standard regards '0', 'x' and '3' as whitespace, so that fields of tests don't contain them
+func TestNewParser(t *testing.T) {
+	var fields = func(q *Lucene) []*FieldName {
+		var res = []*FieldName{}
+		for _, disj := range q.Query.DisjQueries {
+			for _, conj := range disj.ConjQueries {
+				for _, x := range conj.ModClauses {
+					res = append(res, x.Clause.Field)
+				}
+			}
+		}
+		return res
+	}
+
+	q, err := NewParser().Parse(`foo title:bar`)
+	assert.Nil(t, err)
+	assert.Equal(t, []*FieldName{nil, field("title")}, fields(q))
+
+	q, err = NewParser(WithDefaultField("body.name")).Parse(`foo title:bar (baz) title:(qux)`)
+	assert.Nil(t, err)
+	assert.Equal(t, []*FieldName{field("body", ".", "name"), field("title"), nil, field("title")}, fields(q))
+	var group = q.Query.DisjQueries[2].ConjQueries[0].ModClauses[0].Clause.GroupExpr.Query
+	assert.Equal(t, field("body", ".", "name"), group.DisjQueries[0].ConjQueries[0].ModClauses[0].Clause.Field)
+	group = q.Query.DisjQueries[3].ConjQueries[0].ModClauses[0].Clause.GroupExpr.Query
+	assert.Nil(t, group.DisjQueries[0].ConjQueries[0].ModClauses[0].Clause.Field)
+
+	q, err = NewParser(WithDefaultField("a b")).Parse(`foo`)
+	assert.Nil(t, q)
+	assert.True(t, errors.Is(err, lucene_parser.ErrInvalidOption), "%v", err)
+
+	q, err = NewParser().Parse(`title:1 And y:[1 to 2]`)
+	assert.Nil(t, err)
+	assert.Len(t, q.Query.DisjQueries[0].ConjQueries[0].ModClauses, 2)
+	q, err = NewParser(WithCaseSensitiveOperators(true)).Parse(`title:1 And y:2`)
+	assert.Nil(t, err)
+	assert.Len(t, q.Query.DisjQueries, 3)
+	q, err = NewParser(WithCaseSensitiveOperators(true)).Parse(`y:[1 to 2]`)
+	assert.Nil(t, q)
+	assert.NotNil(t, err)
+
+	q, err = NewParser(WithLimits(lucene_parser.ParseLimits{MaxLength: 3})).Parse(`title:10`)
+	assert.Nil(t, q)
+	assert.True(t, errors.Is(err, lucene_parser.ErrQueryTooLong), "%v", err)
+}
+
+func TestParserFeatures(t *testing.T) {
+	type testCase struct {
+		input   string
+		feature lucene_parser.Feature
+	}
+	for _, tt := range []testCase{
+		{input: `title:"foo bar"`, feature: lucene_parser.PHRASE_FEATURE},
+		{input: `title:/fo+/`, feature: lucene_parser.REGEXP_FEATURE},
+		{input: `title:[1 TO 2]`, feature: lucene_parser.RANGE_FEATURE},
+		{input: `title:fo?o*`, feature: lucene_parser.WILDCARD_FEATURE},
+		{input: `title:foo~2`, feature: lucene_parser.FUZZY_FEATURE},
+		{input: `title:foo^2`, feature: lucene_parser.BOOST_FEATURE},
+		{input: `title:(foo bar)`, feature: lucene_parser.TERM_GROUP_FEATURE},
+		{input: `title.*:foo`, feature: lucene_parser.FIELD_GROUP_FEATURE},
+		{input: `(title:1 y:2)`, feature: lucene_parser.PAREN_FEATURE},
+		{input: `title:1 -y:2`, feature: lucene_parser.NOT_FEATURE},
+		{input: `title:1 (y:2 !z:4)`, feature: lucene_parser.NOT_FEATURE},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := NewParser(WithFeatures(lucene_parser.ALL_FEATURES &^ tt.feature)).Parse(tt.input)
+			assert.Nil(t, q)
+			assert.True(t, errors.Is(err, lucene_parser.ErrFeatureNotAllowed), "%v", err)
+
+			q, err = NewParser(WithFeatures(tt.feature | lucene_parser.PAREN_FEATURE)).Parse(tt.input)
+			assert.Nil(t, err)
+			assert.NotNil(t, q)
+		})
+	}
+	q, err := NewParser(WithFeatures(0)).Parse(`title:* foo AND bar`)
+	assert.Nil(t, err)
+	assert.NotNil(t, q)
+}
+
+func TestParserConcurrent(t *testing.T) {
+	var parsers = []*Parser{NewParser(), NewParser(WithCaseSensitiveOperators(true), WithDefaultField("body"))}
+	var want = []*Lucene{}
+	for _, p := range parsers {
+		q, err := p.Parse(`foo AND title:(bar OR baz)^2`)
+		assert.Nil(t, err)
+		want = append(want, q)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				for i, p := range parsers {
+					q, err := p.Parse(`foo AND title:(bar OR baz)^2`)
+					assert.Nil(t, err)
+					assert.Equal(t, want[i], q)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
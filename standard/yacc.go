@@ -21,19 +21,25 @@ func ShowGrammar() {
 	fmt.Println(LuceneParser)
 }
 
-// ParseLucene: parse query to Lucene struct
+// defaultParser: parser without options which is used by ParseLucene
+var defaultParser = NewParser()
+
+// ParseLucene: parse query to Lucene struct, it's safe for concurrent use
 func ParseLucene(queryString string) (*Lucene, error) {
-	var (
-		err error
-		lqy = &Lucene{}
-	)
+	return defaultParser.Parse(queryString)
+}
+
+// parseParticiple: parse query by parser which is built by participle
+func parseParticiple(parser *participle.Parser, queryString string) (lqy *Lucene, err error) {
+	// results are named, so that panic of participle is returned as error
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("failed to parse lucene, err: %+v", r)
+			lqy, err = nil, fmt.Errorf("failed to parse lucene, err: %+v", r)
 		}
 	}()
 
-	if err = LuceneParser.ParseString(queryString, lqy); err != nil {
+	lqy = &Lucene{}
+	if err = parser.ParseString(queryString, lqy); err != nil {
 		return nil, err
 	} else {
 		return lqy, nil
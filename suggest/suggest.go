@@ -0,0 +1,264 @@
+// Package suggest: cursor-aware autocomplete of lucene query. suggestions are decided by grammar of root and prefix
+// parsers: candidate is suggested only if query before cursor followed by candidate is still a viable prefix of query,
+// i.e. parser consumes all input and fails only because input ends.
+package suggest
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+	"github.com/zhuliquan/lucene_parser"
+	"github.com/zhuliquan/lucene_parser/prefix"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+type Syntax uint32
+
+const (
+	DEFAULT_SYNTAX Syntax = iota // syntax of root package, i.e. `x:1 AND NOT y:2`
+	PREFIX_SYNTAX                // syntax of prefix package, i.e. `+x:1 -y:2`
+)
+
+type SuggestionKind uint32
+
+const (
+	FIELD_SUGGESTION    SuggestionKind = iota // field name of schema with colon, i.e. `status:`
+	OPERATOR_SUGGESTION                       // `AND`, `OR`, `NOT` and `TO`
+	BRACKET_SUGGESTION                        // closing bracket, i.e. `)`, `]` and `}`
+	VALUE_SUGGESTION                          // known value of current field
+)
+
+// Suggestion: Text replaces query[Start:End], which is the partial word at cursor (Start == End if there isn't any word)
+type Suggestion struct {
+	Kind  SuggestionKind
+	Text  string
+	Start int
+	End   int
+}
+
+// Options: Fields are field names of schema, and Values returns known values of field, they are filtered by partial word
+type Options struct {
+	Syntax Syntax
+	Fields []string
+	Values func(field string) []string
+}
+
+var (
+	operators = []string{"AND", "OR", "NOT", "TO"}
+	brackets  = []string{")", "]", "}"}
+)
+
+// placeholders which check whether field, term or operand of operator is expected at cursor
+const (
+	fieldProbe   = "x:("
+	termProbe    = "x "
+	operandProbe = "x"
+)
+
+// Complete: return suggestions at cursor (byte offset of query) ordered by kind
+func Complete(query string, cursor int, opts Options) []Suggestion {
+	if cursor < 0 || cursor > len(query) {
+		return []Suggestion{}
+	}
+	var c = &completer{opts: opts, query: query, tokens: tokens(query)}
+	c.start, c.end = c.word(cursor)
+	var before, word = query[:c.start], query[c.start:cursor]
+	if c.literal(query[:cursor]) {
+		// cursor is in phrase or regexp, anything can follow
+		return []Suggestion{}
+	}
+
+	var res = []Suggestion{}
+	if c.viable(before + fieldProbe) {
+		for _, f := range opts.Fields {
+			if strings.HasPrefix(f, word) {
+				res = c.add(res, FIELD_SUGGESTION, f+":")
+			}
+		}
+	}
+	// space is required between operator and term
+	var space = ""
+	if c.start > 0 && !strings.ContainsAny(query[c.start-1:c.start], " \t([{") {
+		space = " "
+	}
+	for _, op := range operators {
+		// operator is followed by term or field, and word which can be term or range value
+		// (i.e. `AND` of prefix syntax `+x:(a AND`) isn't operator
+		var term = c.viable(before+space+op+" )") || c.viable(before+space+op+" ]")
+		if strings.HasPrefix(op, strings.ToUpper(word)) && !term && c.viable(before+space+op+" "+operandProbe) {
+			res = c.add(res, OPERATOR_SUGGESTION, space+op+" ")
+		}
+	}
+	for _, b := range brackets {
+		if c.viable(query[:cursor] + b) {
+			res = append(res, Suggestion{Kind: BRACKET_SUGGESTION, Text: b, Start: cursor, End: cursor})
+		}
+	}
+	if opts.Values != nil && c.viable(before+termProbe) {
+		if field := c.field(c.start); field != "" {
+			for _, v := range opts.Values(field) {
+				if v = quote(v); strings.HasPrefix(v, word) && c.viable(before+v+" ") {
+					res = c.add(res, VALUE_SUGGESTION, v)
+				}
+			}
+		}
+	}
+	return res
+}
+
+type completer struct {
+	opts   Options
+	query  string
+	tokens []lexer.Token
+	start  int
+	end    int
+}
+
+func (c *completer) add(res []Suggestion, kind SuggestionKind, text string) []Suggestion {
+	return append(res, Suggestion{Kind: kind, Text: text, Start: c.start, End: c.end})
+}
+
+// viable: check whether s is a prefix of valid query, that is parsing s succeeds or fails at end of s
+func (c *completer) viable(s string) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	var err error
+	if c.opts.Syntax == PREFIX_SYNTAX {
+		err = prefix.LuceneParser.ParseString(s, &prefix.Lucene{})
+	} else {
+		err = lucene_parser.LuceneParser.ParseString(s, &lucene_parser.Lucene{})
+	}
+	if err == nil {
+		return true
+	}
+	var x, isParseErr = err.(participle.Error)
+	return isParseErr && x.Token().Pos.Offset >= len(s)
+}
+
+// literal: check whether s ends in phrase or regexp, closing paren and bracket can't both follow s otherwise
+func (c *completer) literal(s string) bool {
+	return c.viable(s+")") && c.viable(s+"]")
+}
+
+var symbols = lexer.SymbolsByRune(token.Lexer)
+
+func (c *completer) name(i int) string {
+	if i < 0 || i >= len(c.tokens) {
+		return ""
+	}
+	return symbols[c.tokens[i].Type]
+}
+
+func (c *completer) wordChar(i int) bool {
+	switch c.name(i) {
+	case "IDENT", "ESCAPE", "NUMBER", "DOT", "MINUS":
+		return true
+	}
+	return false
+}
+
+// word: span of partial word at cursor, leading `-` is prefix operator instead of part of word
+func (c *completer) word(cursor int) (int, int) {
+	var start, end = -1, -1
+	for i, t := range c.tokens {
+		if c.wordChar(i) {
+			if start < 0 {
+				start = t.Pos.Offset
+			}
+			end = t.Pos.Offset + len(t.Value)
+			continue
+		} else if start >= 0 && start <= cursor && cursor <= end {
+			break
+		}
+		start = -1
+	}
+	if start < 0 || start > cursor || cursor > end {
+		return cursor, cursor
+	}
+	for start < cursor && c.query[start] == '-' {
+		start++
+	}
+	return start, end
+}
+
+// index: index of first token at or after offset
+func (c *completer) index(offset int) int {
+	for i, t := range c.tokens {
+		if t.Pos.Offset >= offset {
+			return i
+		}
+	}
+	return len(c.tokens)
+}
+
+// field: name of field whose term is at offset, i.e. `x` of `x:(1 OR |`, it's empty if field isn't found
+func (c *completer) field(offset int) string {
+	var i = c.index(offset) - 1
+	for c.name(i) == "COMPARE" {
+		i--
+	}
+	if c.name(i) != "COLON" {
+		// find unclosed bracket of term group or range
+		for depth := 0; i >= 0; i-- {
+			switch c.name(i) {
+			case "RPAREN", "RBRACK", "RBRACE":
+				depth++
+			case "LPAREN", "LBRACK", "LBRACE":
+				depth--
+			}
+			if depth < 0 {
+				break
+			}
+		}
+		if i--; c.name(i) != "COLON" {
+			return ""
+		}
+	}
+	var j = i
+	for c.name(j-1) == "IDENT" || c.name(j-1) == "ESCAPE" || c.name(j-1) == "NUMBER" || c.name(j-1) == "DOT" {
+		j--
+	}
+	var sb = strings.Builder{}
+	for ; j < i; j++ {
+		sb.WriteString(c.tokens[j].Value)
+	}
+	return sb.String()
+}
+
+// quote: quote value as phrase if it isn't a single term
+func quote(v string) string {
+	var ts = tokens(v)
+	var single = len(ts) != 0
+	for i, t := range ts {
+		switch symbols[t.Type] {
+		case "IDENT", "ESCAPE", "NUMBER", "DOT":
+		case "MINUS":
+			single = single && i != 0
+		default:
+			single = false
+		}
+	}
+	if single {
+		return v
+	}
+	return `"` + strings.Replace(v, `"`, `\"`, -1) + `"`
+}
+
+func tokens(s string) []lexer.Token {
+	var res = []lexer.Token{}
+	var lex, err = token.Lexer.Lex(strings.NewReader(s))
+	if err != nil {
+		return res
+	}
+	for {
+		var t, err = lex.Next()
+		if err != nil || t.EOF() {
+			return res
+		}
+		res = append(res, t)
+	}
+}
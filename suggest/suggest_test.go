@@ -0,0 +1,129 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func values(field string) []string {
+	if field == "status" {
+		return []string{"active", "in active", "inactive"}
+	}
+	return nil
+}
+
+func TestComplete(t *testing.T) {
+	type testCase struct {
+		name   string
+		syntax Syntax
+		input  string
+		cursor int // cursor is end of input if it's negative
+		want   []Suggestion
+	}
+	var testCases = []testCase{
+		{
+			name: "test_empty", input: "", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 0, 0}, {FIELD_SUGGESTION, "host:", 0, 0}, {OPERATOR_SUGGESTION, "NOT ", 0, 0}},
+		},
+		{
+			name: "test_partial_field", input: "sta", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 0, 3}},
+		},
+		{
+			name: "test_word_at_cursor", input: "a:1 AND st OR b:2", cursor: 9,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 8, 10}},
+		},
+		{
+			name: "test_operator_after_clause", input: "a:1 ", cursor: -1,
+			want: []Suggestion{{OPERATOR_SUGGESTION, "AND ", 4, 4}, {OPERATOR_SUGGESTION, "OR ", 4, 4}, {OPERATOR_SUGGESTION, "NOT ", 4, 4}},
+		},
+		{
+			name: "test_partial_operator", input: "a:1 an", cursor: -1,
+			want: []Suggestion{{OPERATOR_SUGGESTION, "AND ", 4, 6}},
+		},
+		{
+			name: "test_field_after_operator", input: "a:1 AND ", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 8, 8}, {FIELD_SUGGESTION, "host:", 8, 8}, {OPERATOR_SUGGESTION, "NOT ", 8, 8}},
+		},
+		{
+			name: "test_prefix_operator", input: "a:1 AND -h", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "host:", 9, 10}},
+		},
+		{
+			name: "test_close_paren", input: "(a:1 AND b:(1 OR 2", cursor: -1,
+			want: []Suggestion{{BRACKET_SUGGESTION, ")", 18, 18}},
+		},
+		{
+			name: "test_close_term_group", input: "status:(active ", cursor: -1,
+			want: []Suggestion{{OPERATOR_SUGGESTION, "AND ", 15, 15}, {OPERATOR_SUGGESTION, "OR ", 15, 15}, {OPERATOR_SUGGESTION, "NOT ", 15, 15}, {BRACKET_SUGGESTION, ")", 15, 15}},
+		},
+		{
+			name: "test_range_to", input: "a:[1 ", cursor: -1,
+			want: []Suggestion{{OPERATOR_SUGGESTION, "TO ", 5, 5}},
+		},
+		{
+			name: "test_close_range", input: "a:[1 TO 2", cursor: -1,
+			want: []Suggestion{{BRACKET_SUGGESTION, "]", 9, 9}, {BRACKET_SUGGESTION, "}", 9, 9}},
+		},
+		{
+			name: "test_values", input: "status:", cursor: -1,
+			want: []Suggestion{{VALUE_SUGGESTION, "active", 7, 7}, {VALUE_SUGGESTION, `"in active"`, 7, 7}, {VALUE_SUGGESTION, "inactive", 7, 7}},
+		},
+		{
+			name: "test_partial_value", input: "status:in", cursor: -1,
+			want: []Suggestion{{VALUE_SUGGESTION, "inactive", 7, 9}},
+		},
+		{
+			name: "test_values_of_term_group", input: "x:1 OR status:(active OR ", cursor: -1,
+			want: []Suggestion{{OPERATOR_SUGGESTION, "NOT ", 25, 25}, {VALUE_SUGGESTION, "active", 25, 25}, {VALUE_SUGGESTION, `"in active"`, 25, 25}, {VALUE_SUGGESTION, "inactive", 25, 25}},
+		},
+		{
+			name: "test_values_of_range", input: "status:[active TO i", cursor: -1,
+			want: []Suggestion{{BRACKET_SUGGESTION, "]", 19, 19}, {BRACKET_SUGGESTION, "}", 19, 19}, {VALUE_SUGGESTION, "inactive", 18, 19}},
+		},
+		{
+			name: "test_phrase", input: `status:"act`, cursor: -1,
+			want: []Suggestion{},
+		},
+		{
+			name: "test_regexp", input: `status:/act`, cursor: -1,
+			want: []Suggestion{},
+		},
+		{
+			name: "test_invalid_cursor", input: "a:1", cursor: 4,
+			want: []Suggestion{},
+		},
+		{
+			name: "test_prefix_syntax", syntax: PREFIX_SYNTAX, input: "+a:1 ", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 5, 5}, {FIELD_SUGGESTION, "host:", 5, 5}},
+		},
+		{
+			name: "test_prefix_syntax_partial_field", syntax: PREFIX_SYNTAX, input: "+a:1 -st", cursor: -1,
+			want: []Suggestion{{FIELD_SUGGESTION, "status:", 6, 8}},
+		},
+		{
+			name: "test_prefix_syntax_value", syntax: PREFIX_SYNTAX, input: "+a:1 -status:(", cursor: -1,
+			want: []Suggestion{{BRACKET_SUGGESTION, ")", 14, 14}, {VALUE_SUGGESTION, "active", 14, 14}, {VALUE_SUGGESTION, `"in active"`, 14, 14}, {VALUE_SUGGESTION, "inactive", 14, 14}},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursor = tt.cursor
+			if cursor < 0 {
+				cursor = len(tt.input)
+			}
+			var got = Complete(tt.input, cursor, Options{Syntax: tt.syntax, Fields: []string{"status", "host"}, Values: values})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, "foo", quote("foo"))
+	assert.Equal(t, "2020-01-01", quote("2020-01-01"))
+	assert.Equal(t, `"-1"`, quote("-1"))
+	assert.Equal(t, `"a b"`, quote("a b"))
+	assert.Equal(t, `"a \"b\""`, quote(`a "b"`))
+	assert.Equal(t, `"a:b"`, quote("a:b"))
+}
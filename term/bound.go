@@ -7,7 +7,7 @@ import (
 // range bound like this [1, 2] [1, 2) (1, 2] (1, 2)
 type Bound struct {
 	LeftValue    *RangeValue `json:"left_value,omitempty"`
-	RightValue   *RangeValue `json:"right_Value,omitempty"`
+	RightValue   *RangeValue `json:"right_value,omitempty"`
 	LeftInclude  bool        `json:"left_include,omitempty"`
 	RightInclude bool        `json:"right_include,omitempty"`
 }
@@ -27,10 +27,10 @@ func (n *Bound) GetBoundType() BoundType {
 }
 
 type RangeValue struct {
-	SideFlag    bool     // 表示左右的 左为false 右为true
-	InfinityVal string   `parser:"  @('*')" json:"infinity_val"`
-	PhraseValue []string `parser:"| QUOTE @( REVERSE QUOTE | !QUOTE )* QUOTE" json:"phrase_value"`
-	SingleValue []string `parser:"| @(IDENT|ESCAPE|NUMBER|DOT|PLUS|MINUS|SOR|SLASH|COLON)+" json:"simple_value"`
+	SideFlag    bool     `parser:"" json:"side_flag,omitempty"` // 表示左右的 左为false 右为true
+	InfinityVal string   `parser:"  @('*')" json:"infinity_val,omitempty"`
+	PhraseValue []string `parser:"| QUOTE @( REVERSE QUOTE | !QUOTE )* QUOTE" json:"phrase_value,omitempty"`
+	SingleValue []string `parser:"| @(IDENT|ESCAPE|NUMBER|DOT|PLUS|MINUS|SOR|SLASH|COLON)+" json:"simple_value,omitempty"`
 }
 
 func (v *RangeValue) String() string {
@@ -0,0 +1,200 @@
+package term
+
+import "github.com/zhuliquan/lucene_parser/token"
+
+// Clone: deep copy field, the copy doesn't share any memory with origin
+func (f *Field) Clone() *Field {
+	if f == nil {
+		return nil
+	}
+	var res = &Field{Value: cloneStrings(f.Value)}
+	if f.Group != nil {
+		res.Group = make([]*Field, 0, len(f.Group))
+		for _, x := range f.Group {
+			res.Group = append(res.Group, x.Clone())
+		}
+	}
+	return res
+}
+
+func (t *Term) Clone() *Term {
+	if t == nil {
+		return nil
+	}
+	return &Term{
+		RegexpTerm: t.RegexpTerm.Clone(),
+		FuzzyTerm:  t.FuzzyTerm.Clone(),
+		RangeTerm:  t.RangeTerm.Clone(),
+		TermGroup:  t.TermGroup.Clone(),
+	}
+}
+
+func (t *SingleTerm) Clone() *SingleTerm {
+	if t == nil {
+		return nil
+	}
+	return &SingleTerm{Begin: t.Begin, Chars: append([]token.Char(nil), t.Chars...)}
+}
+
+func (t *PhraseTerm) Clone() *PhraseTerm {
+	if t == nil {
+		return nil
+	}
+	return &PhraseTerm{Chars: cloneStrings(t.Chars)}
+}
+
+func (t *RegexpTerm) Clone() *RegexpTerm {
+	if t == nil {
+		return nil
+	}
+	return &RegexpTerm{Chars: cloneStrings(t.Chars)}
+}
+
+func (t *DRangeTerm) Clone() *DRangeTerm {
+	if t == nil {
+		return nil
+	}
+	return &DRangeTerm{
+		LBRACKET: t.LBRACKET,
+		LValue:   t.LValue.Clone(),
+		RValue:   t.RValue.Clone(),
+		RBRACKET: t.RBRACKET,
+	}
+}
+
+func (t *SRangeTerm) Clone() *SRangeTerm {
+	if t == nil {
+		return nil
+	}
+	return &SRangeTerm{Symbol: t.Symbol, Value: t.Value.Clone()}
+}
+
+func (v *RangeValue) Clone() *RangeValue {
+	if v == nil {
+		return nil
+	}
+	return &RangeValue{
+		SideFlag:    v.SideFlag,
+		InfinityVal: v.InfinityVal,
+		PhraseValue: cloneStrings(v.PhraseValue),
+		SingleValue: cloneStrings(v.SingleValue),
+	}
+}
+
+func (n *Bound) Clone() *Bound {
+	if n == nil {
+		return nil
+	}
+	return &Bound{
+		LeftValue:    n.LeftValue.Clone(),
+		RightValue:   n.RightValue.Clone(),
+		LeftInclude:  n.LeftInclude,
+		RightInclude: n.RightInclude,
+	}
+}
+
+func (t *RangeTerm) Clone() *RangeTerm {
+	if t == nil {
+		return nil
+	}
+	return &RangeTerm{
+		SRangeTerm:  t.SRangeTerm.Clone(),
+		DRangeTerm:  t.DRangeTerm.Clone(),
+		BoostSymbol: t.BoostSymbol,
+	}
+}
+
+func (t *FuzzyTerm) Clone() *FuzzyTerm {
+	if t == nil {
+		return nil
+	}
+	return &FuzzyTerm{
+		SingleTerm:  t.SingleTerm.Clone(),
+		PhraseTerm:  t.PhraseTerm.Clone(),
+		FuzzySymbol: t.FuzzySymbol,
+		BoostSymbol: t.BoostSymbol,
+	}
+}
+
+func (t *FieldTermGroup) Clone() *FieldTermGroup {
+	if t == nil {
+		return nil
+	}
+	return &FieldTermGroup{
+		SingleTerm: t.SingleTerm.Clone(),
+		PhraseTerm: t.PhraseTerm.Clone(),
+		SRangeTerm: t.SRangeTerm.Clone(),
+		DRangeTerm: t.DRangeTerm.Clone(),
+	}
+}
+
+func (t *TermGroup) Clone() *TermGroup {
+	if t == nil {
+		return nil
+	}
+	return &TermGroup{LogicTermGroup: t.LogicTermGroup.Clone(), BoostSymbol: t.BoostSymbol}
+}
+
+func (t *LogicTermGroup) Clone() *LogicTermGroup {
+	if t == nil {
+		return nil
+	}
+	var res = &LogicTermGroup{OrTermGroup: t.OrTermGroup.Clone()}
+	if t.OSTermGroup != nil {
+		res.OSTermGroup = make([]*OSTermGroup, 0, len(t.OSTermGroup))
+		for _, x := range t.OSTermGroup {
+			res.OSTermGroup = append(res.OSTermGroup, x.Clone())
+		}
+	}
+	return res
+}
+
+func (t *OrTermGroup) Clone() *OrTermGroup {
+	if t == nil {
+		return nil
+	}
+	var res = &OrTermGroup{AndTermGroup: t.AndTermGroup.Clone()}
+	if t.AnSTermGroup != nil {
+		res.AnSTermGroup = make([]*AnSTermGroup, 0, len(t.AnSTermGroup))
+		for _, x := range t.AnSTermGroup {
+			res.AnSTermGroup = append(res.AnSTermGroup, x.Clone())
+		}
+	}
+	return res
+}
+
+func (t *OSTermGroup) Clone() *OSTermGroup {
+	if t == nil {
+		return nil
+	}
+	return &OSTermGroup{OrSymbol: t.OrSymbol.Clone(), OrTermGroup: t.OrTermGroup.Clone()}
+}
+
+func (t *AndTermGroup) Clone() *AndTermGroup {
+	if t == nil {
+		return nil
+	}
+	return &AndTermGroup{
+		NotSymbol:      t.NotSymbol.Clone(),
+		ParenTermGroup: t.ParenTermGroup.Clone(),
+		FieldTermGroup: t.FieldTermGroup.Clone(),
+	}
+}
+
+func (t *AnSTermGroup) Clone() *AnSTermGroup {
+	if t == nil {
+		return nil
+	}
+	return &AnSTermGroup{
+		AndSymbol:    t.AndSymbol.Clone(),
+		NotSymbol:    t.NotSymbol.Clone(),
+		AndTermGroup: t.AndTermGroup.Clone(),
+	}
+}
+
+func (t *ParenTermGroup) Clone() *ParenTermGroup {
+	if t == nil {
+		return nil
+	}
+	return &ParenTermGroup{SubTermGroup: t.SubTermGroup.Clone()}
+}
@@ -0,0 +1,72 @@
+package term
+
+import (
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestClone(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&Term{},
+		participle.Lexer(token.Lexer),
+	)
+
+	for _, input := range []string{
+		`foo*`,
+		`foo~2`,
+		`"foo bar"^2`,
+		`/\d+/`,
+		`[1 TO *}^2`,
+		`>=1`,
+		`(foo AND !(bar OR "baz") OR [1 TO 2])^2`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var out = &Term{}
+			assert.Nil(t, termParser.ParseString(input, out))
+			var c = out.Clone()
+			assert.Equal(t, out, c)
+			assert.Equal(t, out.String(), c.String())
+			assert.Equal(t, out.GetBound(), c.GetBound())
+		})
+	}
+
+	t.Run("test_wildcard", func(t *testing.T) {
+		var s = NewSingleTerm("foo*")
+		assert.True(t, s.haveWildcard())
+		var c = s.Clone()
+		c.Chars[0] = token.Char{Type: token.IDENT_TOKEN_TYPE, Value: "o"}
+		assert.False(t, c.haveWildcard())
+		assert.True(t, s.haveWildcard())
+		assert.Equal(t, "foo*", s.String())
+	})
+
+	t.Run("test_srange", func(t *testing.T) {
+		var s = &SRangeTerm{Symbol: ">", Value: &RangeValue{SingleValue: []string{"1"}}}
+		assert.Equal(t, "{ 1 TO * }", s.String())
+		var c = s.Clone()
+		c.Value.SingleValue[0] = "2"
+		assert.Equal(t, "{ 2 TO * }", c.String())
+		assert.Equal(t, "{ 1 TO * }", s.String())
+	})
+
+	var out *Term
+	assert.Nil(t, out.Clone())
+	assert.Nil(t, (*Bound)(nil).Clone())
+	assert.Equal(t, &Bound{LeftValue: Inf, LeftInclude: true}, (&Bound{LeftValue: Inf, LeftInclude: true}).Clone())
+}
+
+func TestGetBoundNotModifyTerm(t *testing.T) {
+	var left, right = &RangeValue{SingleValue: []string{"1"}, SideFlag: true}, &RangeValue{InfinityVal: "*"}
+	var d = &DRangeTerm{LBRACKET: "[", LValue: left, RValue: right, RBRACKET: "]"}
+	var bound = d.GetBound()
+	assert.False(t, bound.LeftValue.SideFlag)
+	assert.True(t, bound.RightValue.SideFlag)
+	assert.True(t, bound.RightValue.IsInf(1))
+	// range value of term is unchanged
+	assert.True(t, left.SideFlag)
+	assert.False(t, right.SideFlag)
+	assert.True(t, right.IsInf(-1))
+}
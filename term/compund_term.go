@@ -2,9 +2,9 @@ package term
 
 // single side range term or double side range and with boost like this [1 TO 2]^2
 type RangeTerm struct {
-	SRangeTerm  *SRangeTerm `parser:"( @@ " json:"s_range_term"`
-	DRangeTerm  *DRangeTerm `parser:"| @@)" json:"d_range_term"`
-	BoostSymbol string      `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol"`
+	SRangeTerm  *SRangeTerm `parser:"( @@ " json:"s_range_term,omitempty"`
+	DRangeTerm  *DRangeTerm `parser:"| @@)" json:"d_range_term,omitempty"`
+	BoostSymbol string      `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol,omitempty"`
 }
 
 func (t *RangeTerm) GetTermType() TermType {
@@ -50,12 +50,12 @@ func (t *RangeTerm) Boost() BoostValue {
 	}
 }
 
-// fuzzy term: term can by suffix with fuzzy or boost like this foo^2 / "foo bar"^2 / foo~ / "foo bar"~2
+// fuzzy term: term can by suffix with fuzzy or boost like this foo^2 / "foo bar"^2 / foo~ / "foo bar"~2 / foo~AUTO:3,6
 type FuzzyTerm struct {
-	SingleTerm  *SingleTerm `parser:"( @@ " json:"single_term"`
-	PhraseTerm  *PhraseTerm `parser:"| @@)" json:"phrase_term"`
-	FuzzySymbol string      `parser:"( @(FUZZY NUMBER? (DOT NUMBER)?)  " json:"fuzzy_symbol"`
-	BoostSymbol string      `parser:"| @(BOOST NUMBER? (DOT NUMBER)?))?" json:"boost_symbol"`
+	SingleTerm  *SingleTerm `parser:"( @@ " json:"single_term,omitempty"`
+	PhraseTerm  *PhraseTerm `parser:"| @@)" json:"phrase_term,omitempty"`
+	FuzzySymbol string      `parser:"( @(FUZZY (NUMBER (DOT NUMBER)? | 'AUTO' (COLON NUMBER ',' NUMBER)?)?)  " json:"fuzzy_symbol,omitempty"`
+	BoostSymbol string      `parser:"| @(BOOST NUMBER? (DOT NUMBER)?))?" json:"boost_symbol,omitempty"`
 }
 
 func (t *FuzzyTerm) GetTermType() TermType {
@@ -130,10 +130,10 @@ func (t *FuzzyTerm) Value(f func(string) (interface{}, error)) (interface{}, err
 
 // term group element
 type FieldTermGroup struct {
-	SingleTerm *SingleTerm `parser:"  @@" json:"single_term"`
-	PhraseTerm *PhraseTerm `parser:"| @@" json:"phrase_term"`
-	SRangeTerm *SRangeTerm `parser:"| @@" json:"single_range_term"`
-	DRangeTerm *DRangeTerm `parser:"| @@" json:"double_range_term"`
+	SingleTerm *SingleTerm `parser:"  @@" json:"single_term,omitempty"`
+	PhraseTerm *PhraseTerm `parser:"| @@" json:"phrase_term,omitempty"`
+	SRangeTerm *SRangeTerm `parser:"| @@" json:"single_range_term,omitempty"`
+	DRangeTerm *DRangeTerm `parser:"| @@" json:"double_range_term,omitempty"`
 }
 
 func (t *FieldTermGroup) String() string {
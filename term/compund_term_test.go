@@ -317,7 +317,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_wildcard",
 			input:    `\/dsada\/\ dasda80980?*`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`}}},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*`)},
 			valueS:   `\/dsada\/\ dasda80980?*`,
 			wildcard: true,
 			fuzzy:    NoFuzzy,
@@ -326,7 +326,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_wildcard_and_boost",
 			input:    `\/dsada\/\ dasda80980?*\^\^^08`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, BoostSymbol: `^08`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), BoostSymbol: `^08`},
 			valueS:   `\/dsada\/\ dasda80980?*\^\^^08`,
 			wildcard: true,
 			fuzzy:    NoFuzzy,
@@ -335,7 +335,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_wildcard_and_none_number_boost",
 			input:    `\/dsada\/\ dasda80980?*\^\^^`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, BoostSymbol: `^`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), BoostSymbol: `^`},
 			valueS:   `\/dsada\/\ dasda80980?*\^\^^`,
 			wildcard: true,
 			fuzzy:    NoFuzzy,
@@ -344,7 +344,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_wildcard_and_fuzzy",
 			input:    `\/dsada\/\ dasda80980?*\^\^~8`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, FuzzySymbol: `~8`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), FuzzySymbol: `~8`},
 			valueS:   `\/dsada\/\ dasda80980?*\^\^~8`,
 			wildcard: true,
 			fuzzy:    Fuzziness(8),
@@ -353,7 +353,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_wildcard_and_none_number_fuzzy",
 			input:    `\/dsada\/\ dasda80980?*\^\^~`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, FuzzySymbol: `~`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), FuzzySymbol: `~`},
 			valueS:   `\/dsada\/\ dasda80980?*\^\^~`,
 			wildcard: true,
 			fuzzy:    AutoFuzzy,
@@ -362,7 +362,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape",
 			input:    `\/dsada\/\ dasda80980\?\*`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `\?\*`}}},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980\?\*`)},
 			valueS:   `\/dsada\/\ dasda80980\?\*`,
 			wildcard: false,
 			fuzzy:    NoFuzzy,
@@ -371,7 +371,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_number_boost",
 			input:    `\/dsada\/\ dasda80980\?\*\^\^^08`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `\?\*\^\^`}}, BoostSymbol: `^08`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980\?\*\^\^`), BoostSymbol: `^08`},
 			valueS:   `\/dsada\/\ dasda80980\?\*\^\^^08`,
 			wildcard: false,
 			fuzzy:    NoFuzzy,
@@ -380,7 +380,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_fuzzy",
 			input:    `\/dsada\/\ dasda80980\?\*\^\^~8`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `\?\*\^\^`}}, FuzzySymbol: `~8`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980\?\*\^\^`), FuzzySymbol: `~8`},
 			valueS:   `\/dsada\/\ dasda80980\?\*\^\^~8`,
 			wildcard: false,
 			fuzzy:    Fuzziness(8),
@@ -389,7 +389,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_none_number_fuzzy",
 			input:    `\/dsada\/\ dasda80980\?\*\^\^~`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `\?\*\^\^`}}, FuzzySymbol: `~`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980\?\*\^\^`), FuzzySymbol: `~`},
 			valueS:   `\/dsada\/\ dasda80980\?\*\^\^~`,
 			wildcard: false,
 			fuzzy:    AutoFuzzy,
@@ -398,7 +398,7 @@ func TestFuzzyTerm(t *testing.T) {
 		{
 			name:     "test_single_with_escape_and_boost",
 			input:    `\/dsada\/\ dasda80980?*\^\^^.8`,
-			want:     &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, BoostSymbol: `^.8`},
+			want:     &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), BoostSymbol: `^.8`},
 			valueS:   `\/dsada\/\ dasda80980?*\^\^^.8`,
 			wildcard: true,
 			fuzzy:    NoFuzzy,
@@ -463,7 +463,7 @@ func TestFieldTermGroup(t *testing.T) {
 		{
 			name: "test_single_term_group",
 			input: &FieldTermGroup{
-				SingleTerm: &SingleTerm{Begin: "123"},
+				SingleTerm: NewSingleTerm("123"),
 			},
 			tType:   SINGLE_TERM_TYPE,
 			valueS:  "123",
@@ -14,6 +14,13 @@ const (
 	GROUP_TERM_TYPE
 	FUZZY_TERM_TYPE
 	BOOST_TERM_TYPE
+	EXISTS_TERM_TYPE // `*` which checks whether field exists
+)
+
+// pseudo fields of checking whether field (value of term) exists, i.e. `_exists_:user.email`
+const (
+	ExistsField  = "_exists_"
+	MissingField = "_missing_"
 )
 
 type FieldType uint32
@@ -0,0 +1,240 @@
+package term
+
+// Equal: check whether two term nodes are structurally equal. a and b must be pointers to the same type of node,
+// different spellings of bool operator are regarded as equal.
+func Equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *Field:
+		y, ok := b.(*Field)
+		return ok && x.Equal(y)
+	case *Term:
+		y, ok := b.(*Term)
+		return ok && x.Equal(y)
+	case *SingleTerm:
+		y, ok := b.(*SingleTerm)
+		return ok && x.Equal(y)
+	case *PhraseTerm:
+		y, ok := b.(*PhraseTerm)
+		return ok && x.Equal(y)
+	case *RegexpTerm:
+		y, ok := b.(*RegexpTerm)
+		return ok && x.Equal(y)
+	case *DRangeTerm:
+		y, ok := b.(*DRangeTerm)
+		return ok && x.Equal(y)
+	case *SRangeTerm:
+		y, ok := b.(*SRangeTerm)
+		return ok && x.Equal(y)
+	case *RangeValue:
+		y, ok := b.(*RangeValue)
+		return ok && x.Equal(y)
+	case *Bound:
+		y, ok := b.(*Bound)
+		return ok && x.Equal(y)
+	case *RangeTerm:
+		y, ok := b.(*RangeTerm)
+		return ok && x.Equal(y)
+	case *FuzzyTerm:
+		y, ok := b.(*FuzzyTerm)
+		return ok && x.Equal(y)
+	case *FieldTermGroup:
+		y, ok := b.(*FieldTermGroup)
+		return ok && x.Equal(y)
+	case *TermGroup:
+		y, ok := b.(*TermGroup)
+		return ok && x.Equal(y)
+	case *LogicTermGroup:
+		y, ok := b.(*LogicTermGroup)
+		return ok && x.Equal(y)
+	case *OrTermGroup:
+		y, ok := b.(*OrTermGroup)
+		return ok && x.Equal(y)
+	case *OSTermGroup:
+		y, ok := b.(*OSTermGroup)
+		return ok && x.Equal(y)
+	case *AndTermGroup:
+		y, ok := b.(*AndTermGroup)
+		return ok && x.Equal(y)
+	case *AnSTermGroup:
+		y, ok := b.(*AnSTermGroup)
+		return ok && x.Equal(y)
+	case *ParenTermGroup:
+		y, ok := b.(*ParenTermGroup)
+		return ok && x.Equal(y)
+	default:
+		return false
+	}
+}
+
+func (f *Field) Equal(other *Field) bool {
+	if f == nil || other == nil {
+		return f == nil && other == nil
+	}
+	return f.String() == other.String()
+}
+
+func (t *Term) Equal(other *Term) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.RegexpTerm.Equal(other.RegexpTerm) &&
+		t.FuzzyTerm.Equal(other.FuzzyTerm) &&
+		t.RangeTerm.Equal(other.RangeTerm) &&
+		t.TermGroup.Equal(other.TermGroup)
+}
+
+func (t *SingleTerm) Equal(other *SingleTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.String() == other.String()
+}
+
+func (t *PhraseTerm) Equal(other *PhraseTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.String() == other.String()
+}
+
+func (t *RegexpTerm) Equal(other *RegexpTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.String() == other.String()
+}
+
+func (t *DRangeTerm) Equal(other *DRangeTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.LBRACKET == other.LBRACKET &&
+		t.RBRACKET == other.RBRACKET &&
+		t.LValue.Equal(other.LValue) &&
+		t.RValue.Equal(other.RValue)
+}
+
+func (t *SRangeTerm) Equal(other *SRangeTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.Symbol == other.Symbol && t.Value.Equal(other.Value)
+}
+
+// Equal: side flag isn't compared, because it's decided by position of range value in bound
+func (v *RangeValue) Equal(other *RangeValue) bool {
+	if v == nil || other == nil {
+		return v == nil && other == nil
+	}
+	return v.InfinityVal == other.InfinityVal &&
+		(len(v.PhraseValue) == 0) == (len(other.PhraseValue) == 0) &&
+		v.String() == other.String()
+}
+
+func (n *Bound) Equal(other *Bound) bool {
+	if n == nil || other == nil {
+		return n == nil && other == nil
+	}
+	return n.LeftInclude == other.LeftInclude &&
+		n.RightInclude == other.RightInclude &&
+		n.LeftValue.Equal(other.LeftValue) &&
+		n.RightValue.Equal(other.RightValue)
+}
+
+func (t *RangeTerm) Equal(other *RangeTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.BoostSymbol == other.BoostSymbol &&
+		t.SRangeTerm.Equal(other.SRangeTerm) &&
+		t.DRangeTerm.Equal(other.DRangeTerm)
+}
+
+func (t *FuzzyTerm) Equal(other *FuzzyTerm) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.FuzzySymbol == other.FuzzySymbol &&
+		t.BoostSymbol == other.BoostSymbol &&
+		t.SingleTerm.Equal(other.SingleTerm) &&
+		t.PhraseTerm.Equal(other.PhraseTerm)
+}
+
+func (t *FieldTermGroup) Equal(other *FieldTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.SingleTerm.Equal(other.SingleTerm) &&
+		t.PhraseTerm.Equal(other.PhraseTerm) &&
+		t.SRangeTerm.Equal(other.SRangeTerm) &&
+		t.DRangeTerm.Equal(other.DRangeTerm)
+}
+
+func (t *TermGroup) Equal(other *TermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.BoostSymbol == other.BoostSymbol && t.LogicTermGroup.Equal(other.LogicTermGroup)
+}
+
+func (t *LogicTermGroup) Equal(other *LogicTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	if !t.OrTermGroup.Equal(other.OrTermGroup) || len(t.OSTermGroup) != len(other.OSTermGroup) {
+		return false
+	}
+	for i := range t.OSTermGroup {
+		if !t.OSTermGroup[i].Equal(other.OSTermGroup[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *OrTermGroup) Equal(other *OrTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	if !t.AndTermGroup.Equal(other.AndTermGroup) || len(t.AnSTermGroup) != len(other.AnSTermGroup) {
+		return false
+	}
+	for i := range t.AnSTermGroup {
+		if !t.AnSTermGroup[i].Equal(other.AnSTermGroup[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *OSTermGroup) Equal(other *OSTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.OrSymbol.Equal(other.OrSymbol) && t.OrTermGroup.Equal(other.OrTermGroup)
+}
+
+func (t *AndTermGroup) Equal(other *AndTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.NotSymbol.Equal(other.NotSymbol) &&
+		t.ParenTermGroup.Equal(other.ParenTermGroup) &&
+		t.FieldTermGroup.Equal(other.FieldTermGroup)
+}
+
+func (t *AnSTermGroup) Equal(other *AnSTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.AndSymbol.Equal(other.AndSymbol) &&
+		t.NotSymbol.Equal(other.NotSymbol) &&
+		t.AndTermGroup.Equal(other.AndTermGroup)
+}
+
+func (t *ParenTermGroup) Equal(other *ParenTermGroup) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return t.SubTermGroup.Equal(other.SubTermGroup)
+}
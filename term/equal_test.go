@@ -0,0 +1,106 @@
+package term
+
+import (
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestEqual(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&Term{},
+		participle.Lexer(token.Lexer),
+	)
+
+	type testCase struct {
+		name  string
+		input string
+		other string
+		want  bool
+	}
+	var testCases = []testCase{
+		{
+			name:  "test_single_term",
+			input: `foo*`,
+			other: `foo*`,
+			want:  true,
+		},
+		{
+			name:  "test_fuzzy_term",
+			input: `foo~2`,
+			other: `foo~1`,
+			want:  false,
+		},
+		{
+			name:  "test_phrase_term",
+			input: `"foo bar"^2`,
+			other: `"foo bar"^2`,
+			want:  true,
+		},
+		{
+			name:  "test_phrase_and_single",
+			input: `"foo"`,
+			other: `foo`,
+			want:  false,
+		},
+		{
+			name:  "test_regexp_term",
+			input: `/\d+/`,
+			other: `/\d+/`,
+			want:  true,
+		},
+		{
+			name:  "test_drange_term",
+			input: `[1 TO 2}`,
+			other: `[1   TO   2}`,
+			want:  true,
+		},
+		{
+			name:  "test_srange_term",
+			input: `>1`,
+			other: `>=1`,
+			want:  false,
+		},
+		{
+			name:  "test_term_group",
+			input: `(foo AND !bar)^2`,
+			other: `(foo && NOT bar)^2`,
+			want:  true,
+		},
+		{
+			name:  "test_term_group_operator",
+			input: `(foo AND bar)`,
+			other: `(foo OR bar)`,
+			want:  false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var t1, t2 = &Term{}, &Term{}
+			assert.Nil(t, termParser.ParseString(tt.input, t1))
+			assert.Nil(t, termParser.ParseString(tt.other, t2))
+			assert.Equal(t, tt.want, t1.Equal(t2))
+			assert.Equal(t, tt.want, Equal(t1, t2))
+		})
+	}
+
+	t.Run("test_cache_is_ignored", func(t *testing.T) {
+		var t1 = NewSingleTerm("foo*")
+		var t2 = NewSingleTerm("foo*")
+		t1.haveWildcard()
+		assert.True(t, t1.Equal(t2))
+	})
+
+	t.Run("test_side_flag_is_ignored", func(t *testing.T) {
+		var v1 = &RangeValue{InfinityVal: "*", SideFlag: true}
+		var v2 = &RangeValue{InfinityVal: "*"}
+		assert.True(t, v1.Equal(v2))
+	})
+
+	t.Run("test_different_type", func(t *testing.T) {
+		assert.False(t, Equal(NewSingleTerm("1"), &PhraseTerm{Chars: []string{"1"}}))
+		assert.False(t, Equal(1, 1))
+	})
+}
@@ -4,14 +4,109 @@ import (
 	"strings"
 )
 
+// Field: name of field, field is pattern if it includes wildcard (i.e. `http.*`, `title\*`) or
+// it's group of fields (i.e. `(title OR body)`), pattern is expanded to concrete fields by schema.
 type Field struct {
-	Value []string `parser:"@(IDENT|ESCAPE|MINUS|NUMBER|DOT)+"`
+	Value []string `parser:"  @(IDENT|ESCAPE|MINUS|NUMBER|DOT|'*')+" json:"value,omitempty"`
+	Group []*Field `parser:"| LPAREN WHITESPACE* @@ ((WHITESPACE+ ('OR' | 'or') WHITESPACE+ | WHITESPACE* SOR SOR WHITESPACE*) @@)* WHITESPACE* RPAREN" json:"group,omitempty"`
+}
+
+// NewField: make field of unescaped name, special chars of name are escaped (except for '-' which is allowed in field)
+func NewField(name string) *Field {
+	return &Field{Value: []string{strings.ReplaceAll(Escape(name), `\-`, "-")}}
 }
 
 func (f *Field) String() string {
 	if f == nil {
 		return ""
+	} else if len(f.Group) != 0 {
+		var sl = make([]string, 0, len(f.Group))
+		for _, x := range f.Group {
+			sl = append(sl, x.String())
+		}
+		return "( " + strings.Join(sl, " OR ") + " )"
 	} else {
 		return strings.Join(f.Value, "")
 	}
 }
+
+// IsExists: check whether field is pseudo field `_exists_`
+func (f *Field) IsExists() bool {
+	return f != nil && len(f.Group) == 0 && f.String() == ExistsField
+}
+
+// IsMissing: check whether field is pseudo field `_missing_`
+func (f *Field) IsMissing() bool {
+	return f != nil && len(f.Group) == 0 && f.String() == MissingField
+}
+
+// IsPattern: check whether field is pattern which should be expanded to concrete fields
+func (f *Field) IsPattern() bool {
+	return f != nil && (len(f.Group) != 0 || len(f.parts()) > 1)
+}
+
+// Match: check whether unescaped name of concrete field matches field, '*' and '\*' of field match any chars,
+// field which isn't pattern matches its unescaped name only, group of fields matches name of any element.
+func (f *Field) Match(name string) bool {
+	if f == nil {
+		return false
+	} else if len(f.Group) != 0 {
+		for _, x := range f.Group {
+			if x.Match(name) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchParts(f.parts(), name)
+}
+
+// parts: split unescaped field by wildcard, both '*' and '\*' are regarded as wildcard
+func (f *Field) parts() []string {
+	var parts = []string{""}
+	for _, x := range f.Value {
+		for i := 0; i < len(x); i++ {
+			if x[i] == '*' || (x[i] == '\\' && i+1 < len(x) && x[i+1] == '*') {
+				if x[i] == '\\' {
+					i++
+				}
+				parts = append(parts, "")
+			} else {
+				if x[i] == '\\' && i+1 < len(x) {
+					i++
+				}
+				parts[len(parts)-1] += x[i : i+1]
+			}
+		}
+	}
+	return parts
+}
+
+// matchParts: match name with literal parts which are joined by '*'
+func matchParts(parts []string, name string) bool {
+	if len(parts) == 1 {
+		return parts[0] == name
+	} else if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, p := range parts[1 : len(parts)-1] {
+		var i = strings.Index(name, p)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(p):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+// Expand: return concrete fields of schema which match field in order of schema
+func (f *Field) Expand(schema []string) []string {
+	var res = []string{}
+	for _, name := range schema {
+		if f.Match(name) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
@@ -46,6 +46,22 @@ func TestField(t *testing.T) {
 			want:  &Field{Value: []string{`x`, `.`, `y`, `-`, `z`}},
 			wantS: `x.y-z`,
 		},
+		{
+			name:  "test_wildcard",
+			input: `http.*`,
+			want:  &Field{Value: []string{`http`, `.`, `*`}},
+			wantS: `http.*`,
+		},
+		{
+			name:  "test_group",
+			input: `(title OR body\* || x.*)`,
+			want: &Field{Group: []*Field{
+				{Value: []string{`title`}},
+				{Value: []string{`body`, `\*`}},
+				{Value: []string{`x`, `.`, `*`}},
+			}},
+			wantS: `( title OR body\* OR x.* )`,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,3 +82,47 @@ func TestField(t *testing.T) {
 		t.Errorf("expect got empty")
 	}
 }
+
+func TestFieldMatch(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&Field{},
+		participle.Lexer(token.Lexer),
+	)
+	var schema = []string{"title", "body", "http.request", "http.response.code", "x:y", "x*y", "xy"}
+
+	type testCase struct {
+		name      string
+		input     string
+		isPattern bool
+		want      []string
+	}
+	var testCases = []testCase{
+		{name: "test_concrete", input: `title`, isPattern: false, want: []string{"title"}},
+		{name: "test_escape", input: `x\:y`, isPattern: false, want: []string{"x:y"}},
+		{name: "test_escape_backslash", input: `x\\*`, isPattern: true, want: []string{}},
+		{name: "test_wildcard", input: `http.*`, isPattern: true, want: []string{"http.request", "http.response.code"}},
+		{name: "test_escape_wildcard", input: `x\*y`, isPattern: true, want: []string{"x:y", "x*y", "xy"}},
+		{name: "test_middle_wildcard", input: `h*.*e`, isPattern: true, want: []string{"http.response.code"}},
+		{name: "test_all", input: `*`, isPattern: true, want: schema},
+		{name: "test_group", input: `(title OR http.*)`, isPattern: true, want: []string{"title", "http.request", "http.response.code"}},
+		{name: "test_no_match", input: `foo*`, isPattern: true, want: []string{}},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &Field{}
+			if err := termParser.ParseString(tt.input, out); err != nil {
+				t.Fatalf("failed to parse input: %s, err: %+v", tt.input, err)
+			}
+			if out.IsPattern() != tt.isPattern {
+				t.Errorf("IsPattern() = %v, want %v", out.IsPattern(), tt.isPattern)
+			}
+			if got := out.Expand(schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	var f *Field
+	if f.IsPattern() || f.Match("x") {
+		t.Errorf("expect nil field isn't pattern")
+	}
+}
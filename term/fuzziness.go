@@ -0,0 +1,185 @@
+package term
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxEditDistance: maximum edit distance which is supported by lucene
+const MaxEditDistance = 2
+
+var (
+	ErrNotPhraseTerm       = fmt.Errorf("slop is only for phrase term")
+	ErrNotSingleTerm       = fmt.Errorf("edit distance is only for single term")
+	ErrInvalidSlop         = fmt.Errorf("slop must be non-negative integer")
+	ErrInvalidEditDistance = fmt.Errorf("edit distance must be 0 ~ 2 or similarity between 0 and 1")
+	ErrInvalidAutoConfig   = fmt.Errorf("low and high of AUTO fuzziness must be 0 <= low <= high")
+)
+
+// AutoConfig: parameters of AUTO fuzziness (`AUTO:low,high` in ES), edit distance of term whose length is less than Low is 0,
+// edit distance of term whose length is less than High is 1, otherwise it's 2.
+type AutoConfig struct {
+	Low  int
+	High int
+}
+
+// DefaultAutoConfig: default parameters of AUTO fuzziness in ES (`AUTO:3,6`)
+var DefaultAutoConfig = AutoConfig{Low: 3, High: 6}
+
+// EditDistance: return edit distance of term whose length is number of runes
+func (c AutoConfig) EditDistance(length int) Fuzziness {
+	if length < c.Low {
+		return 0
+	} else if length < c.High {
+		return 1
+	} else {
+		return 2
+	}
+}
+
+func (c AutoConfig) validate() error {
+	if c.Low < 0 || c.Low > c.High {
+		return fmt.Errorf("%w: AUTO:%d,%d", ErrInvalidAutoConfig, c.Low, c.High)
+	}
+	return nil
+}
+
+// SimilarityFunc: map legacy similarity (0 < similarity < 1, i.e. `foo~0.8`) to edit distance,
+// length is number of runes of unescaped term.
+type SimilarityFunc func(similarity float64, length int) int
+
+// LuceneSimilarity: mapping of legacy similarity in lucene (FuzzyQuery.floatToEdits), edit distance is
+// (1 - similarity) * length and it's not greater than MaxEditDistance.
+func LuceneSimilarity(similarity float64, length int) int {
+	var res = int((1 - similarity) * float64(length))
+	if res > MaxEditDistance {
+		return MaxEditDistance
+	}
+	return res
+}
+
+// Similarity: return legacy similarity of single term (i.e. 0.8 of `foo~0.8`), it returns 0 if fuzziness isn't similarity
+func (t *FuzzyTerm) Similarity() float64 {
+	if t == nil || t.SingleTerm == nil {
+		return 0
+	} else if v := t.Fuzzy().Float(); v > 0 && v < 1 {
+		return v
+	}
+	return 0
+}
+
+// Slop: return slop of phrase term (i.e. 2 of `"foo bar"~2`), slop is 0 if it isn't specified.
+// ErrNotPhraseTerm is returned for single term and ErrInvalidSlop is returned for negative or float slop.
+func (t *FuzzyTerm) Slop() (int, error) {
+	if t == nil || t.PhraseTerm == nil {
+		if t != nil && len(t.FuzzySymbol) != 0 {
+			return 0, ErrNotPhraseTerm
+		}
+		return 0, nil
+	}
+	var v = t.Fuzzy()
+	if strings.HasPrefix(t.FuzzySymbol, "~AUTO") {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSlop, t.FuzzySymbol)
+	} else if v == AutoFuzzy || v == NoFuzzy {
+		return 0, nil
+	} else if v < 0 || v.Float() != math.Trunc(v.Float()) {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSlop, t.FuzzySymbol)
+	}
+	return int(v), nil
+}
+
+// EditDistance: return edit distance of single term with LuceneSimilarity, see EditDistanceWith
+func (t *FuzzyTerm) EditDistance() (Fuzziness, error) {
+	return t.EditDistanceWith(LuceneSimilarity)
+}
+
+// EditDistanceWith: return edit distance of single term (i.e. 1 of `foo~1`), it returns AutoFuzzy for `foo~`
+// and NoFuzzy if fuzziness isn't specified. legacy similarity (i.e. `foo~0.8`) is mapped to edit distance by similarity,
+// and float edit distance is rounded (i.e. `foo~1.6` is 2). ErrNotSingleTerm is returned for phrase term,
+// and ErrInvalidEditDistance is returned for edit distance which is greater than MaxEditDistance (i.e. `foo~3`).
+func (t *FuzzyTerm) EditDistanceWith(similarity SimilarityFunc) (Fuzziness, error) {
+	if t == nil || t.SingleTerm == nil {
+		if t != nil && len(t.FuzzySymbol) != 0 {
+			return NoFuzzy, ErrNotSingleTerm
+		}
+		return NoFuzzy, nil
+	}
+	var v = t.Fuzzy()
+	if v == AutoFuzzy || v == NoFuzzy {
+		return v, nil
+	} else if v > 0 && v < 1 {
+		return Fuzziness(similarity(v.Float(), utf8.RuneCountInString(Unescape(t.SingleTerm.String())))), nil
+	} else if v < 0 || math.Round(v.Float()) > MaxEditDistance {
+		return NoFuzzy, fmt.Errorf("%w: %s", ErrInvalidEditDistance, t.FuzzySymbol)
+	}
+	return Fuzziness(math.Round(v.Float())), nil
+}
+
+// AutoConfig: return parameters of AUTO fuzziness which are specified in query (i.e. `foo~AUTO:3,6`),
+// it returns false if fuzziness isn't AUTO or parameters aren't specified (i.e. `foo~` / `foo~AUTO`).
+func (t *FuzzyTerm) AutoConfig() (AutoConfig, bool) {
+	if t == nil || !strings.HasPrefix(t.FuzzySymbol, "~AUTO:") {
+		return AutoConfig{}, false
+	}
+	var params = strings.Split(strings.TrimPrefix(t.FuzzySymbol, "~AUTO:"), ",")
+	if len(params) != 2 {
+		return AutoConfig{}, false
+	}
+	low, err1 := strconv.Atoi(params[0])
+	high, err2 := strconv.Atoi(params[1])
+	if err1 != nil || err2 != nil {
+		return AutoConfig{}, false
+	}
+	return AutoConfig{Low: low, High: high}, true
+}
+
+// Validate: check slop of phrase term and edit distance of single term
+func (t *FuzzyTerm) Validate() error {
+	if t == nil {
+		return nil
+	} else if t.PhraseTerm != nil {
+		_, err := t.Slop()
+		return err
+	} else if c, ok := t.AutoConfig(); ok {
+		return c.validate()
+	} else {
+		_, err := t.EditDistance()
+		return err
+	}
+}
+
+// ResolveFuzziness: return edit distance of term like EditDistance, and AUTO fuzziness is resolved to edit distance
+// according to unescaped length (number of runes) of term. parameters of AUTO in query (i.e. `foo~AUTO:3,6`)
+// take precedence over config.
+func ResolveFuzziness(t *Term, config AutoConfig) (Fuzziness, error) {
+	var v, err = t.EditDistance()
+	if err != nil || v != AutoFuzzy {
+		return v, err
+	}
+	if c, ok := t.FuzzyTerm.AutoConfig(); ok {
+		config = c
+	}
+	if err := config.validate(); err != nil {
+		return NoFuzzy, err
+	}
+	return config.EditDistance(utf8.RuneCountInString(Unescape(t.FuzzyTerm.SingleTerm.String()))), nil
+}
+
+// Slop: return slop of phrase term, it's 0 for other terms
+func (t *Term) Slop() (int, error) {
+	if t == nil || t.FuzzyTerm == nil {
+		return 0, nil
+	}
+	return t.FuzzyTerm.Slop()
+}
+
+// EditDistance: return edit distance of single term, it's NoFuzzy for other terms
+func (t *Term) EditDistance() (Fuzziness, error) {
+	if t == nil || t.FuzzyTerm == nil {
+		return NoFuzzy, nil
+	}
+	return t.FuzzyTerm.EditDistance()
+}
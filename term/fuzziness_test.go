@@ -0,0 +1,142 @@
+package term
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestFuzzyTermSlopAndEditDistance(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&FuzzyTerm{},
+		participle.Lexer(token.Lexer),
+	)
+
+	type testCase struct {
+		name       string
+		input      string
+		slop       int
+		slopErr    error
+		distance   Fuzziness
+		distErr    error
+		similarity float64
+	}
+	var testCases = []testCase{
+		{name: "test_no_fuzzy", input: `foo`, distance: NoFuzzy},
+		{name: "test_auto_fuzzy", input: `foo~`, distance: AutoFuzzy, slopErr: ErrNotPhraseTerm},
+		{name: "test_edit_distance", input: `foo~1`, distance: 1, slopErr: ErrNotPhraseTerm},
+		{name: "test_round_edit_distance", input: `foo~1.6`, distance: 2, slopErr: ErrNotPhraseTerm},
+		{name: "test_too_large_edit_distance", input: `foo~3`, distErr: ErrInvalidEditDistance, slopErr: ErrNotPhraseTerm},
+		{name: "test_similarity", input: `foobar~0.8`, distance: 1, slopErr: ErrNotPhraseTerm, similarity: 0.8},
+		{name: "test_similarity_escape", input: `f\*o~0.5`, distance: 1, slopErr: ErrNotPhraseTerm, similarity: 0.5},
+		{name: "test_similarity_limit", input: `foobarbaz~0.1`, distance: 2, slopErr: ErrNotPhraseTerm, similarity: 0.1},
+		{name: "test_no_slop", input: `"foo bar"`, distance: NoFuzzy},
+		{name: "test_default_slop", input: `"foo bar"~`, distErr: ErrNotSingleTerm},
+		{name: "test_slop", input: `"foo bar"~5`, slop: 5, distErr: ErrNotSingleTerm},
+		{name: "test_float_slop", input: `"foo bar"~1.5`, slopErr: ErrInvalidSlop, distErr: ErrNotSingleTerm},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &FuzzyTerm{}
+			assert.Nil(t, termParser.ParseString(tt.input, out))
+			slop, err := out.Slop()
+			assert.True(t, errors.Is(err, tt.slopErr), "%v", err)
+			assert.Equal(t, tt.slop, slop)
+			distance, err := out.EditDistance()
+			assert.True(t, errors.Is(err, tt.distErr), "%v", err)
+			assert.Equal(t, tt.distance, distance)
+			assert.Equal(t, tt.similarity, out.Similarity())
+			if out.PhraseTerm != nil {
+				assert.True(t, errors.Is(out.Validate(), tt.slopErr))
+			} else {
+				assert.True(t, errors.Is(out.Validate(), tt.distErr))
+			}
+		})
+	}
+
+	t.Run("test_negative_slop", func(t *testing.T) {
+		var out = &FuzzyTerm{PhraseTerm: &PhraseTerm{Chars: []string{"foo"}}, FuzzySymbol: "~-2"}
+		_, err := out.Slop()
+		assert.True(t, errors.Is(err, ErrInvalidSlop))
+	})
+
+	t.Run("test_custom_similarity", func(t *testing.T) {
+		var out = &FuzzyTerm{}
+		assert.Nil(t, termParser.ParseString(`foo~0.8`, out))
+		distance, err := out.EditDistanceWith(func(similarity float64, length int) int {
+			assert.Equal(t, 3, length)
+			return 2
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, Fuzziness(2), distance)
+	})
+
+	t.Run("test_term", func(t *testing.T) {
+		var out *Term
+		slop, err := out.Slop()
+		assert.Equal(t, 0, slop)
+		assert.Nil(t, err)
+		distance, err := (&Term{RegexpTerm: &RegexpTerm{Chars: []string{"a"}}}).EditDistance()
+		assert.Equal(t, NoFuzzy, distance)
+		assert.Nil(t, err)
+	})
+}
+
+func TestResolveFuzziness(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&Term{},
+		participle.Lexer(token.Lexer),
+	)
+
+	type testCase struct {
+		name   string
+		input  string
+		config AutoConfig
+		want   Fuzziness
+		err    error
+	}
+	var testCases = []testCase{
+		{name: "test_no_fuzzy", input: `foo`, config: DefaultAutoConfig, want: NoFuzzy},
+		{name: "test_edit_distance", input: `foo~1`, config: DefaultAutoConfig, want: 1},
+		{name: "test_auto_short", input: `fo~`, config: DefaultAutoConfig, want: 0},
+		{name: "test_auto_middle", input: `foo~`, config: DefaultAutoConfig, want: 1},
+		{name: "test_auto_long", input: `foobar~AUTO`, config: DefaultAutoConfig, want: 2},
+		{name: "test_auto_escape", input: `f\*\*~`, config: DefaultAutoConfig, want: 1},
+		{name: "test_auto_rune", input: `中文字~`, config: DefaultAutoConfig, want: 1},
+		{name: "test_auto_config", input: `foo~`, config: AutoConfig{Low: 1, High: 2}, want: 2},
+		{name: "test_auto_syntax", input: `foobar~AUTO:7,8`, config: DefaultAutoConfig, want: 0},
+		{name: "test_invalid_config", input: `foo~`, config: AutoConfig{Low: 6, High: 3}, err: ErrInvalidAutoConfig},
+		{name: "test_invalid_syntax", input: `foo~AUTO:6,3`, config: DefaultAutoConfig, err: ErrInvalidAutoConfig},
+		{name: "test_phrase", input: `"foo bar"~`, config: DefaultAutoConfig, err: ErrNotSingleTerm},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &Term{}
+			assert.Nil(t, termParser.ParseString(tt.input, out))
+			got, err := ResolveFuzziness(out, tt.config)
+			assert.True(t, errors.Is(err, tt.err), "%v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("test_auto_symbol", func(t *testing.T) {
+		var out = &Term{}
+		assert.Nil(t, termParser.ParseString(`foo~AUTO:3,6`, out))
+		assert.Equal(t, `foo~AUTO:3,6`, out.String())
+		assert.Equal(t, AutoFuzzy, out.Fuzziness())
+		c, ok := out.FuzzyTerm.AutoConfig()
+		assert.True(t, ok)
+		assert.Equal(t, DefaultAutoConfig, c)
+		assert.Nil(t, out.FuzzyTerm.Validate())
+
+		assert.Nil(t, termParser.ParseString(`foo~AUTO:6,3`, out))
+		assert.True(t, errors.Is(out.FuzzyTerm.Validate(), ErrInvalidAutoConfig))
+
+		assert.Nil(t, termParser.ParseString(`"foo bar"~AUTO`, out))
+		_, err := out.Slop()
+		assert.True(t, errors.Is(err, ErrInvalidSlop))
+	})
+}
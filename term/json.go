@@ -0,0 +1,196 @@
+package term
+
+import (
+	"github.com/zhuliquan/lucene_parser/internal/codec"
+)
+
+// json encoding of term is an object with "type" of term, for instance {"type":"single_term","begin":"foo"}.
+func (f *Field) MarshalJSON() ([]byte, error) {
+	type alias Field
+	return codec.MarshalNode("field", (*alias)(f))
+}
+
+func (f *Field) UnmarshalJSON(data []byte) error {
+	type alias Field
+	return codec.UnmarshalNode(data, "field", (*alias)(f))
+}
+
+func (t *Term) MarshalJSON() ([]byte, error) {
+	type alias Term
+	return codec.MarshalNode("term", (*alias)(t))
+}
+
+func (t *Term) UnmarshalJSON(data []byte) error {
+	type alias Term
+	return codec.UnmarshalNode(data, "term", (*alias)(t))
+}
+
+func (t *SingleTerm) MarshalJSON() ([]byte, error) {
+	type alias SingleTerm
+	return codec.MarshalNode("single_term", (*alias)(t))
+}
+
+func (t *SingleTerm) UnmarshalJSON(data []byte) error {
+	type alias SingleTerm
+	return codec.UnmarshalNode(data, "single_term", (*alias)(t))
+}
+
+func (t *PhraseTerm) MarshalJSON() ([]byte, error) {
+	type alias PhraseTerm
+	return codec.MarshalNode("phrase_term", (*alias)(t))
+}
+
+func (t *PhraseTerm) UnmarshalJSON(data []byte) error {
+	type alias PhraseTerm
+	return codec.UnmarshalNode(data, "phrase_term", (*alias)(t))
+}
+
+func (t *RegexpTerm) MarshalJSON() ([]byte, error) {
+	type alias RegexpTerm
+	return codec.MarshalNode("regexp_term", (*alias)(t))
+}
+
+func (t *RegexpTerm) UnmarshalJSON(data []byte) error {
+	type alias RegexpTerm
+	return codec.UnmarshalNode(data, "regexp_term", (*alias)(t))
+}
+
+func (t *DRangeTerm) MarshalJSON() ([]byte, error) {
+	type alias DRangeTerm
+	return codec.MarshalNode("double_range_term", (*alias)(t))
+}
+
+func (t *DRangeTerm) UnmarshalJSON(data []byte) error {
+	type alias DRangeTerm
+	return codec.UnmarshalNode(data, "double_range_term", (*alias)(t))
+}
+
+func (t *SRangeTerm) MarshalJSON() ([]byte, error) {
+	type alias SRangeTerm
+	return codec.MarshalNode("single_range_term", (*alias)(t))
+}
+
+func (t *SRangeTerm) UnmarshalJSON(data []byte) error {
+	type alias SRangeTerm
+	return codec.UnmarshalNode(data, "single_range_term", (*alias)(t))
+}
+
+func (v *RangeValue) MarshalJSON() ([]byte, error) {
+	type alias RangeValue
+	return codec.MarshalNode("range_value", (*alias)(v))
+}
+
+func (v *RangeValue) UnmarshalJSON(data []byte) error {
+	type alias RangeValue
+	return codec.UnmarshalNode(data, "range_value", (*alias)(v))
+}
+
+func (n *Bound) MarshalJSON() ([]byte, error) {
+	type alias Bound
+	return codec.MarshalNode("bound", (*alias)(n))
+}
+
+func (n *Bound) UnmarshalJSON(data []byte) error {
+	type alias Bound
+	return codec.UnmarshalNode(data, "bound", (*alias)(n))
+}
+
+func (t *RangeTerm) MarshalJSON() ([]byte, error) {
+	type alias RangeTerm
+	return codec.MarshalNode("range_term", (*alias)(t))
+}
+
+func (t *RangeTerm) UnmarshalJSON(data []byte) error {
+	type alias RangeTerm
+	return codec.UnmarshalNode(data, "range_term", (*alias)(t))
+}
+
+func (t *FuzzyTerm) MarshalJSON() ([]byte, error) {
+	type alias FuzzyTerm
+	return codec.MarshalNode("fuzzy_term", (*alias)(t))
+}
+
+func (t *FuzzyTerm) UnmarshalJSON(data []byte) error {
+	type alias FuzzyTerm
+	return codec.UnmarshalNode(data, "fuzzy_term", (*alias)(t))
+}
+
+func (t *FieldTermGroup) MarshalJSON() ([]byte, error) {
+	type alias FieldTermGroup
+	return codec.MarshalNode("field_term_group", (*alias)(t))
+}
+
+func (t *FieldTermGroup) UnmarshalJSON(data []byte) error {
+	type alias FieldTermGroup
+	return codec.UnmarshalNode(data, "field_term_group", (*alias)(t))
+}
+
+func (t *TermGroup) MarshalJSON() ([]byte, error) {
+	type alias TermGroup
+	return codec.MarshalNode("term_group", (*alias)(t))
+}
+
+func (t *TermGroup) UnmarshalJSON(data []byte) error {
+	type alias TermGroup
+	return codec.UnmarshalNode(data, "term_group", (*alias)(t))
+}
+
+func (t *LogicTermGroup) MarshalJSON() ([]byte, error) {
+	type alias LogicTermGroup
+	return codec.MarshalNode("logic_term_group", (*alias)(t))
+}
+
+func (t *LogicTermGroup) UnmarshalJSON(data []byte) error {
+	type alias LogicTermGroup
+	return codec.UnmarshalNode(data, "logic_term_group", (*alias)(t))
+}
+
+func (t *OrTermGroup) MarshalJSON() ([]byte, error) {
+	type alias OrTermGroup
+	return codec.MarshalNode("or_term_group", (*alias)(t))
+}
+
+func (t *OrTermGroup) UnmarshalJSON(data []byte) error {
+	type alias OrTermGroup
+	return codec.UnmarshalNode(data, "or_term_group", (*alias)(t))
+}
+
+func (t *OSTermGroup) MarshalJSON() ([]byte, error) {
+	type alias OSTermGroup
+	return codec.MarshalNode("or_symbol_term_group", (*alias)(t))
+}
+
+func (t *OSTermGroup) UnmarshalJSON(data []byte) error {
+	type alias OSTermGroup
+	return codec.UnmarshalNode(data, "or_symbol_term_group", (*alias)(t))
+}
+
+func (t *AndTermGroup) MarshalJSON() ([]byte, error) {
+	type alias AndTermGroup
+	return codec.MarshalNode("and_term_group", (*alias)(t))
+}
+
+func (t *AndTermGroup) UnmarshalJSON(data []byte) error {
+	type alias AndTermGroup
+	return codec.UnmarshalNode(data, "and_term_group", (*alias)(t))
+}
+
+func (t *AnSTermGroup) MarshalJSON() ([]byte, error) {
+	type alias AnSTermGroup
+	return codec.MarshalNode("and_symbol_term_group", (*alias)(t))
+}
+
+func (t *AnSTermGroup) UnmarshalJSON(data []byte) error {
+	type alias AnSTermGroup
+	return codec.UnmarshalNode(data, "and_symbol_term_group", (*alias)(t))
+}
+
+func (t *ParenTermGroup) MarshalJSON() ([]byte, error) {
+	type alias ParenTermGroup
+	return codec.MarshalNode("paren_term_group", (*alias)(t))
+}
+
+func (t *ParenTermGroup) UnmarshalJSON(data []byte) error {
+	type alias ParenTermGroup
+	return codec.UnmarshalNode(data, "paren_term_group", (*alias)(t))
+}
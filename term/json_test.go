@@ -0,0 +1,76 @@
+package term
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&Term{},
+		participle.Lexer(token.Lexer),
+	)
+
+	for _, input := range []string{
+		`foo*`,
+		`foo~2`,
+		`"foo bar"^2`,
+		`/\d+/`,
+		`[1 TO *}^2`,
+		`>=1`,
+		`(foo AND !(bar OR "baz") OR [1 TO 2] OR <3)^2`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var out = &Term{}
+			assert.Nil(t, termParser.ParseString(input, out))
+			b, err := json.Marshal(out)
+			assert.Nil(t, err)
+			var res = &Term{}
+			assert.Nil(t, json.Unmarshal(b, res))
+			assert.Equal(t, out, res)
+			assert.Equal(t, out.GetTermType(), res.GetTermType())
+		})
+	}
+}
+
+func TestJSONPrivateCache(t *testing.T) {
+	t.Run("test_wildcard", func(t *testing.T) {
+		var s = NewSingleTerm("foo*")
+		assert.True(t, s.haveWildcard())
+		b, err := json.Marshal(s)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"type":"single_term","begin":"foo","chars":["*"]}`, string(b))
+
+		var res = NewSingleTerm("bar")
+		assert.Nil(t, json.Unmarshal(b, res))
+		assert.True(t, res.haveWildcard())
+	})
+
+	t.Run("test_srange", func(t *testing.T) {
+		var s = &SRangeTerm{Symbol: "<", Value: &RangeValue{SingleValue: []string{"1"}}}
+		assert.Equal(t, "{ * TO 1 }", s.String())
+		b, err := json.Marshal(s)
+		assert.Nil(t, err)
+		var res = &SRangeTerm{}
+		assert.Nil(t, json.Unmarshal(b, res))
+		assert.Equal(t, s.GetBound(), res.GetBound())
+	})
+
+	t.Run("test_bound", func(t *testing.T) {
+		var bound = &Bound{LeftValue: &RangeValue{SingleValue: []string{"1"}}, RightValue: &RangeValue{InfinityVal: "*", SideFlag: true}, LeftInclude: true}
+		b, err := json.Marshal(bound)
+		assert.Nil(t, err)
+		var res = &Bound{}
+		assert.Nil(t, json.Unmarshal(b, res))
+		assert.Equal(t, bound, res)
+		assert.True(t, res.RightValue.IsInf(1))
+	})
+
+	t.Run("test_wrong_type", func(t *testing.T) {
+		assert.NotNil(t, json.Unmarshal([]byte(`{"type":"phrase_term","chars":["foo"]}`), &SingleTerm{}))
+	})
+}
@@ -4,14 +4,55 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
 	"github.com/zhuliquan/lucene_parser/token"
 )
 
-// simple term: is a single term without escape char and whitespace
+// simple term: is a single term without escape char and whitespace, tokens of term carry their types from lexer
+// (see Parse), so that term is classified without lexing it again.
 type SingleTerm struct {
-	Begin    string   `parser:"@(IDENT|ESCAPE|NUMBER|WILDCARD|MINUS|PLUS)" json:"begin"`
-	Chars    []string `parser:"@(IDENT|ESCAPE|NUMBER|DOT|WILDCARD|MINUS|PLUS|MINUS|SOR|SLASH)*" json:"chars"`
-	wildcard int8
+	Begin token.Char   `json:"begin,omitempty"`
+	Chars []token.Char `json:"chars,omitempty"`
+}
+
+// types of tokens which begin single term and follow the beginning
+const (
+	singleTermBegin uint32 = 1<<token.IDENT_TOKEN_TYPE | 1<<token.ESCAPE_TOKEN_TYPE | 1<<token.NUMBER_TOKEN_TYPE |
+		1<<token.WILDCARD_TOKEN_TYPE | 1<<token.MINUS_TOKEN_TYPE | 1<<token.PLUS_TOKEN_TYPE
+	singleTermChars uint32 = singleTermBegin | 1<<token.DOT_TOKEN_TYPE | 1<<token.SOR_TOKEN_TYPE | 1<<token.SLASH_TOKEN_TYPE
+)
+
+// NewSingleTerm: make single term of escaped string (i.e. `foo\ bar*`), s is split to tokens by lexer.
+func NewSingleTerm(s string) *SingleTerm {
+	var chars = token.Split(s)
+	if len(chars) == 0 {
+		return &SingleTerm{}
+	} else if len(chars) == 1 {
+		return &SingleTerm{Begin: chars[0]}
+	}
+	return &SingleTerm{Begin: chars[0], Chars: chars[1:]}
+}
+
+// Parse: parse single term by participle, it's the same as grammar
+// `@(IDENT|ESCAPE|NUMBER|WILDCARD|MINUS|PLUS) @(IDENT|ESCAPE|NUMBER|DOT|WILDCARD|MINUS|PLUS|SOR|SLASH)*`,
+// but tokens are captured with their types, which are lost if they are captured by struct tags of grammar.
+func (t *SingleTerm) Parse(lex *lexer.PeekingLexer) error {
+	var tok, _ = lex.Peek(0)
+	if tok.EOF() || !matchChar(singleTermBegin, tok) {
+		return participle.NextMatch
+	}
+	_, _ = lex.Next()
+	t.Begin = token.NewChar(tok)
+	for tok, _ = lex.Peek(0); !tok.EOF() && matchChar(singleTermChars, tok); tok, _ = lex.Peek(0) {
+		_, _ = lex.Next()
+		t.Chars = append(t.Chars, token.NewChar(tok))
+	}
+	return nil
+}
+
+func matchChar(types uint32, tok lexer.Token) bool {
+	return types&(1<<token.NewChar(tok).Type) != 0
 }
 
 func (t *SingleTerm) GetTermType() TermType {
@@ -22,9 +63,17 @@ func (t *SingleTerm) GetTermType() TermType {
 	if t.haveWildcard() {
 		res |= WILDCARD_TERM_TYPE
 	}
+	if t.IsExists() {
+		res |= EXISTS_TERM_TYPE
+	}
 	return res
 }
 
+// IsExists: check whether term is single `*` which matches any value of field
+func (t *SingleTerm) IsExists() bool {
+	return t != nil && t.Begin.Value == "*" && len(t.Chars) == 0
+}
+
 func (t *SingleTerm) Value(f func(string) (interface{}, error)) (interface{}, error) {
 	if t == nil {
 		return nil, ErrEmptySingleTerm
@@ -36,34 +85,37 @@ func (t *SingleTerm) Value(f func(string) (interface{}, error)) (interface{}, er
 func (t *SingleTerm) String() string {
 	if t == nil {
 		return ""
+	} else if len(t.Chars) == 0 {
+		return t.Begin.Value
 	} else {
-		return t.Begin + strings.Join(t.Chars, "")
+		var sb strings.Builder
+		sb.WriteString(t.Begin.Value)
+		for _, c := range t.Chars {
+			sb.WriteString(c.Value)
+		}
+		return sb.String()
 	}
 }
 
+// haveWildcard: check whether term includes wildcard by types of tokens which are carried from lexer,
+// leading wildcard (i.e. `*foo`) is wildcard too, but single `*` is exists term rather than wildcard.
 func (t *SingleTerm) haveWildcard() bool {
-	if t == nil {
-		return false
-	} else if t.wildcard == -1 {
+	if t == nil || t.IsExists() {
 		return false
-	} else if t.wildcard == 1 {
+	} else if t.Begin.Type == token.WILDCARD_TOKEN_TYPE {
 		return true
-	} else {
-		for _, tk := range t.Chars {
-			if token.GetTokenType(tk) == token.WILDCARD_TOKEN_TYPE {
-				t.wildcard = 1
-				return true
-			}
+	}
+	for _, c := range t.Chars {
+		if c.Type == token.WILDCARD_TOKEN_TYPE {
+			return true
 		}
-		t.wildcard = -1
-		return false
 	}
-
+	return false
 }
 
 // phrase term: a series of terms be surrounded with quotation, for instance "foo bar".
 type PhraseTerm struct {
-	Chars []string `parser:"QUOTE @( REVERSE QUOTE | !QUOTE )* QUOTE" json:"chars"`
+	Chars []string `parser:"QUOTE @( REVERSE QUOTE | !QUOTE )* QUOTE" json:"chars,omitempty"`
 }
 
 func (t *PhraseTerm) GetTermType() TermType {
@@ -92,7 +144,7 @@ func (t *PhraseTerm) String() string {
 
 // a regexp term is surrounded be slash, for instance /\d+\.?\d+/ in here if you want present '/' you should type '\/'
 type RegexpTerm struct {
-	Chars []string `parser:"SLASH @( REVERSE SLASH | !SLASH )+ SLASH" json:"chars"`
+	Chars []string `parser:"SLASH @( REVERSE SLASH | !SLASH )+ SLASH" json:"chars,omitempty"`
 }
 
 func (t *RegexpTerm) GetTermType() TermType {
@@ -127,10 +179,10 @@ func (t *RegexpTerm) String() string {
 
 // double side of range term: a term is surrounded by brace / bracket, for instance [1 TO 2] / [1 TO 2} / {1 TO 2] / {1 TO 2}
 type DRangeTerm struct {
-	LBRACKET string      `parser:"@(LBRACE|LBRACK) WHITESPACE*" json:"left_bracket"`
-	LValue   *RangeValue `parser:"@@ WHITESPACE+ 'TO'" json:"left_value"`
-	RValue   *RangeValue `parser:"WHITESPACE+ @@" json:"right_value"`
-	RBRACKET string      `parser:"WHITESPACE* @(RBRACK|RBRACE)" json:"right_bracket"`
+	LBRACKET string      `parser:"@(LBRACE|LBRACK) WHITESPACE*" json:"left_bracket,omitempty"`
+	LValue   *RangeValue `parser:"@@ WHITESPACE+ 'TO'" json:"left_value,omitempty"`
+	RValue   *RangeValue `parser:"WHITESPACE+ @@" json:"right_value,omitempty"`
+	RBRACKET string      `parser:"WHITESPACE* @(RBRACK|RBRACE)" json:"right_bracket,omitempty"`
 }
 
 func (t *DRangeTerm) GetTermType() TermType {
@@ -140,18 +192,19 @@ func (t *DRangeTerm) GetTermType() TermType {
 	return RANGE_TERM_TYPE
 }
 
+// GetBound: values of bound are copies of range values, so that setting side flag won't modify this term
 func (t *DRangeTerm) GetBound() *Bound {
 	var res *Bound
 	if t == nil {
 		return nil
 	} else if t.LBRACKET == "[" && t.RBRACKET == "]" {
-		res = &Bound{LeftValue: t.LValue, RightValue: t.RValue, LeftInclude: true, RightInclude: true}
+		res = &Bound{LeftValue: t.LValue.Clone(), RightValue: t.RValue.Clone(), LeftInclude: true, RightInclude: true}
 	} else if t.LBRACKET == "[" && t.RBRACKET == "}" {
-		res = &Bound{LeftValue: t.LValue, RightValue: t.RValue, LeftInclude: true, RightInclude: false}
+		res = &Bound{LeftValue: t.LValue.Clone(), RightValue: t.RValue.Clone(), LeftInclude: true, RightInclude: false}
 	} else if t.LBRACKET == "{" && t.RBRACKET == "]" {
-		res = &Bound{LeftValue: t.LValue, RightValue: t.RValue, LeftInclude: false, RightInclude: true}
+		res = &Bound{LeftValue: t.LValue.Clone(), RightValue: t.RValue.Clone(), LeftInclude: false, RightInclude: true}
 	} else if t.LBRACKET == "{" && t.RBRACKET == "}" {
-		res = &Bound{LeftValue: t.LValue, RightValue: t.RValue, LeftInclude: false, RightInclude: false}
+		res = &Bound{LeftValue: t.LValue.Clone(), RightValue: t.RValue.Clone(), LeftInclude: false, RightInclude: false}
 	} else {
 		return nil
 	}
@@ -172,9 +225,8 @@ func (t *DRangeTerm) String() string {
 
 // single side of range term: a term is behind of symbol ('>' / '<' / '>=' / '<=')
 type SRangeTerm struct {
-	Symbol string      `parser:"@COMPARE" json:"symbol"`
-	Value  *RangeValue `parser:"@@" json:"value"`
-	drange *DRangeTerm
+	Symbol string      `parser:"@COMPARE" json:"symbol,omitempty"`
+	Value  *RangeValue `parser:"@@" json:"value,omitempty"`
 }
 
 func (t *SRangeTerm) GetTermType() TermType {
@@ -184,23 +236,23 @@ func (t *SRangeTerm) GetTermType() TermType {
 	return RANGE_TERM_TYPE
 }
 
+// toDRangeTerm: double range term is built on every call rather than cached in term,
+// so that term isn't modified by reading and it's safe to share parsed ast between goroutines.
 func (t *SRangeTerm) toDRangeTerm() *DRangeTerm {
 	if t == nil || t.Value == nil {
 		return nil
-	} else if t.drange != nil {
-		return t.drange
-	} else {
-		if t.Symbol == ">" && t.Value != nil {
-			t.drange = &DRangeTerm{LBRACKET: "{", LValue: t.Value, RValue: &RangeValue{InfinityVal: "*"}, RBRACKET: "}"}
-		} else if t.Symbol == ">=" && t.Value != nil {
-			t.drange = &DRangeTerm{LBRACKET: "[", LValue: t.Value, RValue: &RangeValue{InfinityVal: "*"}, RBRACKET: "}"}
-		} else if t.Symbol == "<" && t.Value != nil {
-			t.drange = &DRangeTerm{LBRACKET: "{", LValue: &RangeValue{InfinityVal: "*"}, RValue: t.Value, RBRACKET: "}"}
-		} else if t.Symbol == "<=" && t.Value != nil {
-			t.drange = &DRangeTerm{LBRACKET: "{", LValue: &RangeValue{InfinityVal: "*"}, RValue: t.Value, RBRACKET: "]"}
-		}
 	}
-	return t.drange
+	switch t.Symbol {
+	case ">":
+		return &DRangeTerm{LBRACKET: "{", LValue: t.Value, RValue: &RangeValue{InfinityVal: "*"}, RBRACKET: "}"}
+	case ">=":
+		return &DRangeTerm{LBRACKET: "[", LValue: t.Value, RValue: &RangeValue{InfinityVal: "*"}, RBRACKET: "}"}
+	case "<":
+		return &DRangeTerm{LBRACKET: "{", LValue: &RangeValue{InfinityVal: "*"}, RValue: t.Value, RBRACKET: "}"}
+	case "<=":
+		return &DRangeTerm{LBRACKET: "{", LValue: &RangeValue{InfinityVal: "*"}, RValue: t.Value, RBRACKET: "]"}
+	}
+	return nil
 }
 
 func (t *SRangeTerm) GetBound() *Bound {
@@ -23,26 +23,60 @@ func TestSingleTerm(t *testing.T) {
 	}
 	var testCases = []testCase{
 		{
-			name:     "test_escape_slush_and_?_wildcard",
-			input:    `\/dsada\/\ dasda80980?`,
-			want:     &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`}},
+			name:  "test_escape_slush_and_?_wildcard",
+			input: `\/dsada\/\ dasda80980?`,
+			want: &SingleTerm{Begin: escape(`\/`), Chars: []token.Char{
+				ident(`dsada`), escape(`\/\ `), ident(`dasda`), number(`80980`), wildcard(`?`),
+			}},
 			values:   `\/dsada\/\ dasda80980?`,
 			wildcard: true,
 		},
 		{
-			name:     "test_escape_slush_and_*_wildcard",
-			input:    `\/dsada\/\ dasda80980*`,
-			want:     &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `*`}},
+			name:  "test_escape_slush_and_*_wildcard",
+			input: `\/dsada\/\ dasda80980*`,
+			want: &SingleTerm{Begin: escape(`\/`), Chars: []token.Char{
+				ident(`dsada`), escape(`\/\ `), ident(`dasda`), number(`80980`), wildcard(`*`),
+			}},
 			values:   `\/dsada\/\ dasda80980*`,
 			wildcard: true,
 		},
 		{
-			name:     "test_escape_slush_and_escape_wildcard",
-			input:    `\/dsada\/\ dasda8\?0980\*`,
-			want:     &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `8`, `\?`, `0980`, `\*`}},
+			name:  "test_escape_slush_and_escape_wildcard",
+			input: `\/dsada\/\ dasda8\?0980\*`,
+			want: &SingleTerm{Begin: escape(`\/`), Chars: []token.Char{
+				ident(`dsada`), escape(`\/\ `), ident(`dasda`), number(`8`), escape(`\?`), number(`0980`), escape(`\*`),
+			}},
 			values:   `\/dsada\/\ dasda8\?0980\*`,
 			wildcard: false,
 		},
+		{
+			name:     "test_leading_wildcard",
+			input:    `*dsada`,
+			want:     &SingleTerm{Begin: wildcard(`*`), Chars: []token.Char{ident(`dsada`)}},
+			values:   `*dsada`,
+			wildcard: true,
+		},
+		{
+			name:     "test_leading_?_wildcard",
+			input:    `?`,
+			want:     &SingleTerm{Begin: wildcard(`?`)},
+			values:   `?`,
+			wildcard: true,
+		},
+		{
+			name:     "test_leading_escape_wildcard",
+			input:    `\*dsada`,
+			want:     &SingleTerm{Begin: escape(`\*`), Chars: []token.Char{ident(`dsada`)}},
+			values:   `\*dsada`,
+			wildcard: false,
+		},
+		{
+			name:     "test_exists_isnt_wildcard",
+			input:    `*`,
+			want:     &SingleTerm{Begin: wildcard(`*`)},
+			values:   `*`,
+			wildcard: false,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -61,8 +95,29 @@ func TestSingleTerm(t *testing.T) {
 	assert.Equal(t, UNKNOWN_TERM_TYPE, s.GetTermType())
 	_, err := s.Value(func(s string) (interface{}, error) { return s, nil })
 	assert.Equal(t, ErrEmptySingleTerm, err)
+	assert.NotNil(t, termParser.ParseString(`.foo`, &SingleTerm{}))
+
+	// leading wildcard is wildcard, single `*` is exists term only
+	assert.Equal(t, SINGLE_TERM_TYPE|WILDCARD_TERM_TYPE, NewSingleTerm(`*foo`).GetTermType())
+	assert.Equal(t, SINGLE_TERM_TYPE|WILDCARD_TERM_TYPE, NewSingleTerm(`**`).GetTermType())
+	assert.Equal(t, SINGLE_TERM_TYPE|EXISTS_TERM_TYPE, NewSingleTerm(`*`).GetTermType())
+}
+
+func TestNewSingleTerm(t *testing.T) {
+	// chars of term are the same as chars which are parsed
+	assert.Equal(t, &SingleTerm{Begin: ident(`foo`), Chars: []token.Char{escape(`\ `), number(`1`), wildcard(`*`)}},
+		NewSingleTerm(`foo\ 1*`))
+	assert.Equal(t, &SingleTerm{Begin: wildcard(`*`)}, NewSingleTerm(`*`))
+	assert.Equal(t, &SingleTerm{}, NewSingleTerm(``))
+	assert.True(t, NewSingleTerm(`foo?`).haveWildcard())
+	assert.False(t, NewSingleTerm(`foo\?`).haveWildcard())
 }
 
+func ident(s string) token.Char    { return token.Char{Type: token.IDENT_TOKEN_TYPE, Value: s} }
+func escape(s string) token.Char   { return token.Char{Type: token.ESCAPE_TOKEN_TYPE, Value: s} }
+func number(s string) token.Char   { return token.Char{Type: token.NUMBER_TOKEN_TYPE, Value: s} }
+func wildcard(s string) token.Char { return token.Char{Type: token.WILDCARD_TOKEN_TYPE, Value: s} }
+
 func TestPhraseTerm(t *testing.T) {
 	var termParser = participle.MustBuild(
 		&PhraseTerm{},
@@ -463,3 +518,21 @@ func TestSRangeTerm(t *testing.T) {
 	assert.Equal(t, UNKNOWN_TERM_TYPE, s.GetTermType())
 	assert.Nil(t, s.GetBound())
 }
+
+// reading range term mustn't modify it, so that parsed term can be shared between goroutines (run with -race)
+func TestSRangeTermConcurrent(t *testing.T) {
+	var s = &SRangeTerm{Symbol: ">=", Value: &RangeValue{SingleValue: []string{"1"}}}
+	var done = make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for n := 0; n < 100; n++ {
+				assert.Equal(t, "[ 1 TO * }", s.String())
+				assert.True(t, s.GetBound().RightValue.IsInf(1))
+			}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+}
@@ -1,10 +1,10 @@
 package term
 
 type Term struct {
-	RegexpTerm *RegexpTerm `parser:"  @@" json:"regexp_term"`
-	FuzzyTerm  *FuzzyTerm  `parser:"| @@" json:"fuzzy_term"`
-	RangeTerm  *RangeTerm  `parser:"| @@" json:"range_term"`
-	TermGroup  *TermGroup  `parser:"| @@" json:"term_group"`
+	RegexpTerm *RegexpTerm `parser:"  @@" json:"regexp_term,omitempty"`
+	FuzzyTerm  *FuzzyTerm  `parser:"| @@" json:"fuzzy_term,omitempty"`
+	RangeTerm  *RangeTerm  `parser:"| @@" json:"range_term,omitempty"`
+	TermGroup  *TermGroup  `parser:"| @@" json:"term_group,omitempty"`
 }
 
 func (t *Term) String() string {
@@ -39,6 +39,11 @@ func (t *Term) GetTermType() TermType {
 	}
 }
 
+// IsExists: check whether term is `*` without fuzzy modifier, i.e. `x:*` checks whether x exists
+func (t *Term) IsExists() bool {
+	return t != nil && t.FuzzyTerm != nil && t.FuzzyTerm.SingleTerm.IsExists() && len(t.FuzzyTerm.FuzzySymbol) == 0
+}
+
 func (t *Term) Value(f func(string) (interface{}, error)) (interface{}, error) {
 	if t == nil {
 		return nil, ErrEmptyTerm
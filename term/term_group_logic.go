@@ -8,8 +8,8 @@ import (
 
 // logic term group: join sum term elem by OR / AND / NOT
 type LogicTermGroup struct {
-	OrTermGroup *OrTermGroup   `parser:"@@ " json:"or_term_group"`
-	OSTermGroup []*OSTermGroup `parser:"@@*" json:"or_symbol_term_group"`
+	OrTermGroup *OrTermGroup   `parser:"@@ " json:"or_term_group,omitempty"`
+	OSTermGroup []*OSTermGroup `parser:"@@*" json:"or_symbol_term_group,omitempty"`
 }
 
 func (t *LogicTermGroup) String() string {
@@ -25,8 +25,8 @@ func (t *LogicTermGroup) String() string {
 }
 
 type OrTermGroup struct {
-	AndTermGroup *AndTermGroup   `parser:"@@ " json:"and_term_group"`
-	AnSTermGroup []*AnSTermGroup `parser:"@@*" json:"and_symbol_term_group"`
+	AndTermGroup *AndTermGroup   `parser:"@@ " json:"and_term_group,omitempty"`
+	AnSTermGroup []*AnSTermGroup `parser:"@@*" json:"and_symbol_term_group,omitempty"`
 }
 
 func (t *OrTermGroup) String() string {
@@ -43,8 +43,8 @@ func (t *OrTermGroup) String() string {
 
 // "or" | " !" | " not "
 type OSTermGroup struct {
-	OrSymbol    *op.OrSymbol `parser:"@@" json:"or_symbol"`
-	OrTermGroup *OrTermGroup `parser:"@@" json:"or_term_group"`
+	OrSymbol    *op.OrSymbol `parser:"@@" json:"or_symbol,omitempty"`
+	OrTermGroup *OrTermGroup `parser:"@@" json:"or_term_group,omitempty"`
 }
 
 func (t *OSTermGroup) String() string {
@@ -56,9 +56,9 @@ func (t *OSTermGroup) String() string {
 }
 
 type AndTermGroup struct {
-	NotSymbol      *op.NotSymbol   `parser:"@@?" json:"not_symbol"`
-	ParenTermGroup *ParenTermGroup `parser:"( @@ " json:"paren_term_group"`
-	FieldTermGroup *FieldTermGroup `parser:"| @@)" json:"field_term_group"`
+	NotSymbol      *op.NotSymbol   `parser:"@@?" json:"not_symbol,omitempty"`
+	ParenTermGroup *ParenTermGroup `parser:"( @@ " json:"paren_term_group,omitempty"`
+	FieldTermGroup *FieldTermGroup `parser:"| @@)" json:"field_term_group,omitempty"`
 }
 
 func (t *AndTermGroup) String() string {
@@ -74,9 +74,9 @@ func (t *AndTermGroup) String() string {
 }
 
 type AnSTermGroup struct {
-	AndSymbol    *op.AndSymbol `parser:"( @@ " json:"and_symbol"`
-	NotSymbol    *op.NotSymbol `parser:"| WHITESPACE+ @@)" json:"not_symbol"`
-	AndTermGroup *AndTermGroup `parser:"@@" json:"and_term_group"`
+	AndSymbol    *op.AndSymbol `parser:"( @@ " json:"and_symbol,omitempty"`
+	NotSymbol    *op.NotSymbol `parser:"| WHITESPACE+ @@)" json:"not_symbol,omitempty"`
+	AndTermGroup *AndTermGroup `parser:"@@" json:"and_term_group,omitempty"`
 }
 
 func (t *AnSTermGroup) String() string {
@@ -92,7 +92,7 @@ func (t *AnSTermGroup) String() string {
 }
 
 type ParenTermGroup struct {
-	SubTermGroup *LogicTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_term_group"`
+	SubTermGroup *LogicTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"sub_term_group,omitempty"`
 }
 
 func (t *ParenTermGroup) String() string {
@@ -105,8 +105,8 @@ func (t *ParenTermGroup) String() string {
 
 // term group: join sum prefix term group together
 type TermGroup struct {
-	LogicTermGroup *LogicTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"logic_term_group"`
-	BoostSymbol    string          `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol"`
+	LogicTermGroup *LogicTermGroup `parser:"LPAREN WHITESPACE* @@ WHITESPACE* RPAREN" json:"logic_term_group,omitempty"`
+	BoostSymbol    string          `parser:"@(BOOST NUMBER? (DOT NUMBER)?)?" json:"boost_symbol,omitempty"`
 }
 
 func (t *TermGroup) String() string {
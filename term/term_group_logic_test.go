@@ -38,7 +38,7 @@ func TestLogicTermGroup(t *testing.T) {
 												OrTermGroup: &OrTermGroup{
 													AndTermGroup: &AndTermGroup{
 														FieldTermGroup: &FieldTermGroup{
-															SingleTerm: &SingleTerm{Begin: "quick"},
+															SingleTerm: NewSingleTerm("quick"),
 														},
 													},
 													AnSTermGroup: []*AnSTermGroup{
@@ -46,7 +46,7 @@ func TestLogicTermGroup(t *testing.T) {
 															AndSymbol: &op.AndSymbol{Symbol: "AND"},
 															AndTermGroup: &AndTermGroup{
 																FieldTermGroup: &FieldTermGroup{
-																	SingleTerm: &SingleTerm{Begin: "fox"},
+																	SingleTerm: NewSingleTerm("fox"),
 																},
 															},
 														},
@@ -66,7 +66,7 @@ func TestLogicTermGroup(t *testing.T) {
 														OrTermGroup: &OrTermGroup{
 															AndTermGroup: &AndTermGroup{
 																FieldTermGroup: &FieldTermGroup{
-																	SingleTerm: &SingleTerm{Begin: "brown"},
+																	SingleTerm: NewSingleTerm("brown"),
 																},
 															},
 															AnSTermGroup: []*AnSTermGroup{
@@ -74,7 +74,7 @@ func TestLogicTermGroup(t *testing.T) {
 																	AndSymbol: &op.AndSymbol{Symbol: "AND"},
 																	AndTermGroup: &AndTermGroup{
 																		FieldTermGroup: &FieldTermGroup{
-																			SingleTerm: &SingleTerm{Begin: "fox"},
+																			SingleTerm: NewSingleTerm("fox"),
 																		},
 																	},
 																},
@@ -90,7 +90,7 @@ func TestLogicTermGroup(t *testing.T) {
 										OrTermGroup: &OrTermGroup{
 											AndTermGroup: &AndTermGroup{
 												FieldTermGroup: &FieldTermGroup{
-													SingleTerm: &SingleTerm{Begin: "fox"},
+													SingleTerm: NewSingleTerm("fox"),
 												},
 											},
 										},
@@ -105,7 +105,7 @@ func TestLogicTermGroup(t *testing.T) {
 							AndTermGroup: &AndTermGroup{
 								NotSymbol: &op.NotSymbol{Symbol: "NOT"},
 								FieldTermGroup: &FieldTermGroup{
-									SingleTerm: &SingleTerm{Begin: "news"},
+									SingleTerm: NewSingleTerm("news"),
 								},
 							},
 						},
@@ -147,7 +147,7 @@ func TestTermGroup(t *testing.T) {
 					OrTermGroup: &OrTermGroup{
 						AndTermGroup: &AndTermGroup{
 							FieldTermGroup: &FieldTermGroup{
-								SingleTerm: &SingleTerm{Begin: "x"},
+								SingleTerm: NewSingleTerm("x"),
 							},
 						},
 						AnSTermGroup: []*AnSTermGroup{
@@ -155,7 +155,7 @@ func TestTermGroup(t *testing.T) {
 								NotSymbol: &op.NotSymbol{Symbol: "not"},
 								AndTermGroup: &AndTermGroup{
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "y"},
+										SingleTerm: NewSingleTerm("y"),
 									},
 								},
 							},
@@ -163,7 +163,7 @@ func TestTermGroup(t *testing.T) {
 								NotSymbol: &op.NotSymbol{Symbol: "!"},
 								AndTermGroup: &AndTermGroup{
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "z"},
+										SingleTerm: NewSingleTerm("z"),
 									},
 								},
 							},
@@ -171,7 +171,7 @@ func TestTermGroup(t *testing.T) {
 								AndSymbol: &op.AndSymbol{Symbol: "and"},
 								AndTermGroup: &AndTermGroup{
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "x", Chars: []string{"1"}},
+										SingleTerm: NewSingleTerm("x1"),
 									},
 								},
 							},
@@ -180,7 +180,7 @@ func TestTermGroup(t *testing.T) {
 								AndTermGroup: &AndTermGroup{
 									NotSymbol: &op.NotSymbol{Symbol: "not"},
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "x", Chars: []string{"2"}},
+										SingleTerm: NewSingleTerm("x2"),
 									},
 								},
 							},
@@ -188,7 +188,7 @@ func TestTermGroup(t *testing.T) {
 								NotSymbol: &op.NotSymbol{Symbol: "not"},
 								AndTermGroup: &AndTermGroup{
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "x", Chars: []string{"3"}},
+										SingleTerm: NewSingleTerm("x3"),
 									},
 								},
 							},
@@ -201,7 +201,7 @@ func TestTermGroup(t *testing.T) {
 								AndTermGroup: &AndTermGroup{
 									NotSymbol: &op.NotSymbol{Symbol: "not"},
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "x", Chars: []string{"4"}},
+										SingleTerm: NewSingleTerm("x4"),
 									},
 								},
 							},
@@ -229,7 +229,7 @@ func TestTermGroup(t *testing.T) {
 													OrTermGroup: &OrTermGroup{
 														AndTermGroup: &AndTermGroup{
 															FieldTermGroup: &FieldTermGroup{
-																SingleTerm: &SingleTerm{Begin: "quick"},
+																SingleTerm: NewSingleTerm("quick"),
 															},
 														},
 														AnSTermGroup: []*AnSTermGroup{
@@ -237,7 +237,7 @@ func TestTermGroup(t *testing.T) {
 																AndSymbol: &op.AndSymbol{Symbol: "and"},
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "fox"},
+																		SingleTerm: NewSingleTerm("fox"),
 																	},
 																},
 															},
@@ -257,7 +257,7 @@ func TestTermGroup(t *testing.T) {
 															OrTermGroup: &OrTermGroup{
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "brown"},
+																		SingleTerm: NewSingleTerm("brown"),
 																	},
 																},
 																AnSTermGroup: []*AnSTermGroup{
@@ -265,7 +265,7 @@ func TestTermGroup(t *testing.T) {
 																		AndSymbol: &op.AndSymbol{Symbol: "AND"},
 																		AndTermGroup: &AndTermGroup{
 																			FieldTermGroup: &FieldTermGroup{
-																				SingleTerm: &SingleTerm{Begin: "fox"},
+																				SingleTerm: NewSingleTerm("fox"),
 																			},
 																		},
 																	},
@@ -281,7 +281,7 @@ func TestTermGroup(t *testing.T) {
 											OrTermGroup: &OrTermGroup{
 												AndTermGroup: &AndTermGroup{
 													FieldTermGroup: &FieldTermGroup{
-														SingleTerm: &SingleTerm{Begin: "fox"},
+														SingleTerm: NewSingleTerm("fox"),
 													},
 												},
 											},
@@ -296,7 +296,7 @@ func TestTermGroup(t *testing.T) {
 								AndTermGroup: &AndTermGroup{
 									NotSymbol: &op.NotSymbol{Symbol: "NOT"},
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "news"},
+										SingleTerm: NewSingleTerm("news"),
 									},
 								},
 							},
@@ -325,7 +325,7 @@ func TestTermGroup(t *testing.T) {
 													OrTermGroup: &OrTermGroup{
 														AndTermGroup: &AndTermGroup{
 															FieldTermGroup: &FieldTermGroup{
-																SingleTerm: &SingleTerm{Begin: "quick"},
+																SingleTerm: NewSingleTerm("quick"),
 															},
 														},
 														AnSTermGroup: []*AnSTermGroup{
@@ -333,7 +333,7 @@ func TestTermGroup(t *testing.T) {
 																AndSymbol: &op.AndSymbol{Symbol: "and"},
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "fox"},
+																		SingleTerm: NewSingleTerm("fox"),
 																	},
 																},
 															},
@@ -353,7 +353,7 @@ func TestTermGroup(t *testing.T) {
 															OrTermGroup: &OrTermGroup{
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "brown"},
+																		SingleTerm: NewSingleTerm("brown"),
 																	},
 																},
 																AnSTermGroup: []*AnSTermGroup{
@@ -361,7 +361,7 @@ func TestTermGroup(t *testing.T) {
 																		AndSymbol: &op.AndSymbol{Symbol: "AND"},
 																		AndTermGroup: &AndTermGroup{
 																			FieldTermGroup: &FieldTermGroup{
-																				SingleTerm: &SingleTerm{Begin: "fox"},
+																				SingleTerm: NewSingleTerm("fox"),
 																			},
 																		},
 																	},
@@ -377,7 +377,7 @@ func TestTermGroup(t *testing.T) {
 											OrTermGroup: &OrTermGroup{
 												AndTermGroup: &AndTermGroup{
 													FieldTermGroup: &FieldTermGroup{
-														SingleTerm: &SingleTerm{Begin: "fox"},
+														SingleTerm: NewSingleTerm("fox"),
 													},
 												},
 											},
@@ -392,7 +392,7 @@ func TestTermGroup(t *testing.T) {
 								AndTermGroup: &AndTermGroup{
 									NotSymbol: &op.NotSymbol{Symbol: "NOT"},
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "news"},
+										SingleTerm: NewSingleTerm("news"),
 									},
 								},
 							},
@@ -420,7 +420,7 @@ func TestTermGroup(t *testing.T) {
 													OrTermGroup: &OrTermGroup{
 														AndTermGroup: &AndTermGroup{
 															FieldTermGroup: &FieldTermGroup{
-																SingleTerm: &SingleTerm{Begin: "quick"},
+																SingleTerm: NewSingleTerm("quick"),
 															},
 														},
 														AnSTermGroup: []*AnSTermGroup{
@@ -428,7 +428,7 @@ func TestTermGroup(t *testing.T) {
 																AndSymbol: &op.AndSymbol{Symbol: "and"},
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "fox"},
+																		SingleTerm: NewSingleTerm("fox"),
 																	},
 																},
 															},
@@ -448,7 +448,7 @@ func TestTermGroup(t *testing.T) {
 															OrTermGroup: &OrTermGroup{
 																AndTermGroup: &AndTermGroup{
 																	FieldTermGroup: &FieldTermGroup{
-																		SingleTerm: &SingleTerm{Begin: "brown"},
+																		SingleTerm: NewSingleTerm("brown"),
 																	},
 																},
 																AnSTermGroup: []*AnSTermGroup{
@@ -456,7 +456,7 @@ func TestTermGroup(t *testing.T) {
 																		AndSymbol: &op.AndSymbol{Symbol: "AND"},
 																		AndTermGroup: &AndTermGroup{
 																			FieldTermGroup: &FieldTermGroup{
-																				SingleTerm: &SingleTerm{Begin: "fox"},
+																				SingleTerm: NewSingleTerm("fox"),
 																			},
 																		},
 																	},
@@ -472,7 +472,7 @@ func TestTermGroup(t *testing.T) {
 											OrTermGroup: &OrTermGroup{
 												AndTermGroup: &AndTermGroup{
 													FieldTermGroup: &FieldTermGroup{
-														SingleTerm: &SingleTerm{Begin: "fox"},
+														SingleTerm: NewSingleTerm("fox"),
 													},
 												},
 											},
@@ -487,7 +487,7 @@ func TestTermGroup(t *testing.T) {
 								AndTermGroup: &AndTermGroup{
 									NotSymbol: &op.NotSymbol{Symbol: "NOT"},
 									FieldTermGroup: &FieldTermGroup{
-										SingleTerm: &SingleTerm{Begin: "news"},
+										SingleTerm: NewSingleTerm("news"),
 									},
 								},
 							},
@@ -96,7 +96,7 @@ func TestTerm(t *testing.T) {
 		{
 			name:      "test_single_with_escape_and_wildcard",
 			input:     `\/dsada\/\ dasda80980?*`,
-			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`}}}},
+			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*`)}},
 			wantStr:   `\/dsada\/\ dasda80980?*`,
 			boost:     DefaultBoost,
 			valueS:    `\/dsada\/\ dasda80980?*`,
@@ -107,7 +107,7 @@ func TestTerm(t *testing.T) {
 		{
 			name:      "test_single_with_escape_and_wildcard_and_boost",
 			input:     `\/dsada\/\ dasda80980?*\^\^^08`,
-			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, BoostSymbol: `^08`}},
+			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), BoostSymbol: `^08`}},
 			wantStr:   `\/dsada\/\ dasda80980?*\^\^^08`,
 			boost:     BoostValue(8),
 			valueS:    `\/dsada\/\ dasda80980?*\^\^`,
@@ -118,7 +118,7 @@ func TestTerm(t *testing.T) {
 		{
 			name:      "test_single_with_escape_and_wildcard_and_fuzzy",
 			input:     `\/dsada\/\ dasda80980?*\^\^~8`,
-			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: &SingleTerm{Begin: `\/`, Chars: []string{`dsada`, `\/\ `, `dasda`, `80980`, `?`, `*`, `\^\^`}}, FuzzySymbol: `~8`}},
+			want:      &Term{FuzzyTerm: &FuzzyTerm{SingleTerm: NewSingleTerm(`\/dsada\/\ dasda80980?*\^\^`), FuzzySymbol: `~8`}},
 			wantStr:   `\/dsada\/\ dasda80980?*\^\^~8`,
 			boost:     DefaultBoost,
 			valueS:    `\/dsada\/\ dasda80980?*\^\^`,
@@ -517,7 +517,7 @@ func TestTerm(t *testing.T) {
 				LogicTermGroup: &LogicTermGroup{
 					OrTermGroup: &OrTermGroup{
 						AndTermGroup: &AndTermGroup{
-							FieldTermGroup: &FieldTermGroup{SingleTerm: &SingleTerm{Begin: "foo"}},
+							FieldTermGroup: &FieldTermGroup{SingleTerm: NewSingleTerm("foo")},
 						},
 					},
 					OSTermGroup: []*OSTermGroup{
@@ -525,7 +525,7 @@ func TestTerm(t *testing.T) {
 							OrSymbol: &operator.OrSymbol{Symbol: "or"},
 							OrTermGroup: &OrTermGroup{
 								AndTermGroup: &AndTermGroup{
-									FieldTermGroup: &FieldTermGroup{SingleTerm: &SingleTerm{Begin: "bar"}},
+									FieldTermGroup: &FieldTermGroup{SingleTerm: NewSingleTerm("bar")},
 								},
 							},
 						},
@@ -546,7 +546,7 @@ func TestTerm(t *testing.T) {
 				LogicTermGroup: &LogicTermGroup{
 					OrTermGroup: &OrTermGroup{
 						AndTermGroup: &AndTermGroup{
-							FieldTermGroup: &FieldTermGroup{SingleTerm: &SingleTerm{Begin: "foo"}},
+							FieldTermGroup: &FieldTermGroup{SingleTerm: NewSingleTerm("foo")},
 						},
 					},
 					OSTermGroup: []*OSTermGroup{
@@ -554,7 +554,7 @@ func TestTerm(t *testing.T) {
 							OrSymbol: &operator.OrSymbol{Symbol: "OR"},
 							OrTermGroup: &OrTermGroup{
 								AndTermGroup: &AndTermGroup{
-									FieldTermGroup: &FieldTermGroup{SingleTerm: &SingleTerm{Begin: "bar"}},
+									FieldTermGroup: &FieldTermGroup{SingleTerm: NewSingleTerm("bar")},
 								},
 							},
 						},
@@ -767,6 +767,70 @@ func TestTermIsWildcard(t *testing.T) {
 	}
 }
 
+func TestTermIsExists(t *testing.T) {
+
+	var termParser = participle.MustBuild(
+		&Term{},
+		participle.Lexer(token.Lexer),
+	)
+
+	type testCase struct {
+		name  string
+		input string
+		want  bool
+	}
+
+	var testCases = []testCase{
+		{
+			name:  "test_star",
+			input: `*`,
+			want:  true,
+		},
+		{
+			name:  "test_star_boost",
+			input: `*^2`,
+			want:  true,
+		},
+		{
+			name:  "test_star_fuzzy",
+			input: `*~`,
+			want:  false,
+		},
+		{
+			name:  "test_wildcard",
+			input: `1*`,
+			want:  false,
+		},
+		{
+			name:  "test_escape_*",
+			input: `\*`,
+			want:  false,
+		},
+		{
+			name:  "test_phrase",
+			input: `"*"`,
+			want:  false,
+		},
+		{
+			name:  "test_group",
+			input: `(*)`,
+			want:  false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var out = &Term{}
+			err := termParser.ParseString(tt.input, out)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, out.IsExists())
+		})
+	}
+	var out = &Term{}
+	assert.Nil(t, termParser.ParseString(`*`, out))
+	assert.Equal(t, EXISTS_TERM_TYPE, out.GetTermType()&EXISTS_TERM_TYPE)
+}
+
 func TestTermIsRange(t *testing.T) {
 	var termParser = participle.MustBuild(
 		&Term{},
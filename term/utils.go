@@ -2,6 +2,7 @@ package term
 
 import (
 	"strconv"
+	"strings"
 )
 
 type BoostValue float64
@@ -16,7 +17,7 @@ func (f Fuzziness) Float() float64 {
 	return float64(f)
 }
 
-var AutoFuzzy Fuzziness = -1      // only ~
+var AutoFuzzy Fuzziness = -1      // only ~ or ~AUTO
 var DefaultBoost BoostValue = 1.0 // no boost symbol
 
 var NoFuzzy Fuzziness = 0.0
@@ -33,7 +34,7 @@ func GetBoostValue(boostSymbol string) BoostValue {
 }
 
 func getFuzzyValue(fuzzySymbol string) Fuzziness {
-	if fuzzySymbol == "~" {
+	if fuzzySymbol == "~" || strings.HasPrefix(fuzzySymbol, "~AUTO") {
 		// auto fuzziness
 		return AutoFuzzy
 	} else {
@@ -41,3 +42,46 @@ func getFuzzyValue(fuzzySymbol string) Fuzziness {
 		return Fuzziness(v)
 	}
 }
+
+// cloneStrings: copy slice of string, nil slice is still nil after copying
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+// Unescape: remove backslash of escaped char, for instance `foo\*bar` is unescaped as `foo*bar` and `\\` is unescaped as `\`
+func Unescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var b = strings.Builder{}
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// escapeChars: chars which must be escaped in field and single term
+const escapeChars = " \t\r\f:&|?*\\^~()![]{}+-/><="
+
+// Escape: add backslash in front of special chars, it's reverse of Unescape, for instance `foo*bar` is escaped as `foo\*bar`
+func Escape(s string) string {
+	if !strings.ContainsAny(s, escapeChars) {
+		return s
+	}
+	var b = strings.Builder{}
+	b.Grow(len(s) + 4)
+	for _, c := range s {
+		if strings.ContainsRune(escapeChars, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
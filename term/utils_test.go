@@ -0,0 +1,39 @@
+package term
+
+import "testing"
+
+func TestUnescape(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		want  string
+	}
+
+	var testCases = []testCase{
+		{name: "test_no_escape", input: `foo bar`, want: `foo bar`},
+		{name: "test_escape_char", input: `foo\*bar\:\ `, want: `foo*bar: `},
+		{name: "test_escape_backslash", input: `foo\\bar`, want: `foo\bar`},
+		{name: "test_tail_backslash", input: `foo\`, want: `foo\`},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unescape(tt.input); got != tt.want {
+				t.Errorf("Unescape() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for _, input := range []string{`foo`, `foo*bar`, `a:b c`, `\`, `x-y`, `(1+1)`} {
+		if got := Unescape(Escape(input)); got != input {
+			t.Errorf("Unescape(Escape(%s)) = %v, want %v", input, got, input)
+		}
+	}
+	if got := Escape(`foo*bar`); got != `foo\*bar` {
+		t.Errorf("Escape() = %v, want %v", got, `foo\*bar`)
+	}
+	if got := NewField(`a:b-c`).String(); got != `a\:b-c` {
+		t.Errorf("NewField() = %v, want %v", got, `a\:b-c`)
+	}
+}
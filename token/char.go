@@ -0,0 +1,51 @@
+package token
+
+import (
+	"encoding/json"
+
+	"github.com/alecthomas/participle/lexer"
+)
+
+// Char: token of term which carries its type from lexer into ast, so that term is classified (i.e. wildcard)
+// without lexing its value again. json of Char is its value only, type is recovered by GetTokenType when decoding.
+type Char struct {
+	Type  TokenType
+	Value string
+}
+
+// NewChar: make char of token which is emitted by Lexer or Tokenize
+func NewChar(t lexer.Token) Char {
+	return Char{Type: tokenTypes[t.Type], Value: t.Value}
+}
+
+// Split: split string to chars by hand-written lexer, it's used to build term of string which isn't parsed
+// (i.e. term of rewritten query). rest of s which can't be tokenized is one char of UNKNOWN_TOKEN_TYPE.
+func Split(s string) []Char {
+	var res []Char
+	for i := 0; i < len(s); {
+		var typ, n = next(s[i:])
+		if n == 0 {
+			return append(res, Char{Type: UNKNOWN_TOKEN_TYPE, Value: s[i:]})
+		}
+		res = append(res, Char{Type: tokenTypes[typ], Value: s[i : i+n]})
+		i += n
+	}
+	return res
+}
+
+func (c Char) String() string {
+	return c.Value
+}
+
+func (c Char) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value)
+}
+
+func (c *Char) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*c = Char{Type: GetTokenType(value), Value: value}
+	return nil
+}
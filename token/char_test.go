@@ -0,0 +1,43 @@
+package token
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChar(t *testing.T) {
+	for _, input := range []string{
+		`foo`, `foo\ bar*`, `fo?o.12-+|/`, `x:>=1`, `中文　词`, "a\nb",
+	} {
+		t.Run(input, func(t *testing.T) {
+			var tokens, err = lex(input)
+			assert.Nil(t, err)
+			var want = []Char{}
+			for _, tok := range tokens[:len(tokens)-1] {
+				// type of token which is captured from lexer is the same as type of its value
+				var c = NewChar(tok)
+				assert.Equal(t, GetTokenType(tok.Value), c.Type)
+				assert.Equal(t, tok.Value, c.String())
+				want = append(want, c)
+			}
+			assert.Equal(t, want, Split(input))
+		})
+	}
+	assert.Nil(t, Split(``))
+	assert.Equal(t, []Char{{Type: IDENT_TOKEN_TYPE, Value: `x`}, {Type: UNKNOWN_TOKEN_TYPE, Value: "=\xff"}}, Split("x=\xff"))
+}
+
+func TestCharJSON(t *testing.T) {
+	var chars = Split(`foo\ *`)
+	b, err := json.Marshal(chars)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `["foo","\\ ","*"]`, string(b))
+
+	var res []Char
+	assert.Nil(t, json.Unmarshal(b, &res))
+	assert.Equal(t, chars, res)
+	assert.Equal(t, WILDCARD_TOKEN_TYPE, res[2].Type)
+	assert.NotNil(t, json.Unmarshal([]byte(`[1]`), &res))
+}
@@ -108,11 +108,7 @@ var rules = []stateful.Rule{
 	},
 }
 
-var Lexer *stateful.Definition
-
-func init() {
-	Lexer, _ = stateful.NewSimple(rules)
-}
+var Lexer, _ = stateful.NewSimple(rules)
 
 var Scanner *participle.Parser
 
@@ -269,8 +265,19 @@ func init() {
 	)
 }
 
+// GetTokenType: type of first token of c, it's UNKNOWN_TOKEN_TYPE if c is empty or can't be tokenized.
+// c is classified by hand-written lexer (see Tokenize) instead of parsing by Scanner. tokens of parsed ast carry
+// their types (see Char), so that it's only needed for strings which aren't captured by lexer (i.e. decoded ast).
 func GetTokenType(c string) TokenType {
-	t := &Token{}
-	Scanner.ParseString(c, t)
-	return t.getTokenType()
+	var res = UNKNOWN_TOKEN_TYPE
+	for i := 0; i < len(c); {
+		var typ, n = next(c[i:])
+		if n == 0 {
+			return UNKNOWN_TOKEN_TYPE
+		} else if i == 0 {
+			res = tokenTypes[typ]
+		}
+		i += n
+	}
+	return res
 }
@@ -0,0 +1,165 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+)
+
+var symbols = Lexer.Symbols()
+
+// symbols of tokens which are matched by pattern of more than one char
+var (
+	eolSymbol        = symbols["EOL"]
+	whitespaceSymbol = symbols["WHITESPACE"]
+	identSymbol      = symbols["IDENT"]
+	escapeSymbol     = symbols["ESCAPE"]
+	numberSymbol     = symbols["NUMBER"]
+	compareSymbol    = symbols["COMPARE"]
+)
+
+// tokenTypes: token types of symbols
+var tokenTypes = map[rune]TokenType{
+	symbols["EOL"]: EOL_TOKEN_TYPE, symbols["WHITESPACE"]: WHITESPACE_TOKEN_TYPE, symbols["IDENT"]: IDENT_TOKEN_TYPE,
+	symbols["ESCAPE"]: ESCAPE_TOKEN_TYPE, symbols["DOT"]: DOT_TOKEN_TYPE, symbols["NUMBER"]: NUMBER_TOKEN_TYPE,
+	symbols["QUOTE"]: QUOTE_TOKEN_TYPE, symbols["SLASH"]: SLASH_TOKEN_TYPE, symbols["REVERSE"]: REVERSE_TOKEN_TYPE,
+	symbols["COLON"]: COLON_TOKEN_TYPE, symbols["COMPARE"]: COMPARE_TOKEN_TYPE, symbols["PLUS"]: PLUS_TOKEN_TYPE,
+	symbols["MINUS"]: MINUS_TOKEN_TYPE, symbols["FUZZY"]: FUZZY_TOKEN_TYPE, symbols["BOOST"]: BOOST_TOKEN_TYPE,
+	symbols["WILDCARD"]: WILDCARD_TOKEN_TYPE, symbols["LPAREN"]: LPAREN_TOKEN_TYPE, symbols["RPAREN"]: RPAREN_TOKEN_TYPE,
+	symbols["LBRACK"]: LBRACK_TOKEN_TYPE, symbols["RBRACK"]: RBRACK_TOKEN_TYPE, symbols["LBRACE"]: LBRACE_TOKEN_TYPE,
+	symbols["RBRACE"]: RBRACE_TOKEN_TYPE, symbols["AND"]: AND_TOKEN_TYPE, symbols["SOR"]: SOR_TOKEN_TYPE,
+	symbols["NOT"]: NOT_TOKEN_TYPE,
+}
+
+// punctuations: symbols of single char tokens
+var punctuations = map[byte]rune{
+	'.': symbols["DOT"], '"': symbols["QUOTE"], '/': symbols["SLASH"], '\\': symbols["REVERSE"], ':': symbols["COLON"],
+	'+': symbols["PLUS"], '-': symbols["MINUS"], '~': symbols["FUZZY"], '^': symbols["BOOST"], '?': symbols["WILDCARD"],
+	'*': symbols["WILDCARD"], '(': symbols["LPAREN"], ')': symbols["RPAREN"], '[': symbols["LBRACK"], ']': symbols["RBRACK"],
+	'{': symbols["LBRACE"], '}': symbols["RBRACE"], '&': symbols["AND"], '|': symbols["SOR"], '!': symbols["NOT"],
+}
+
+// ErrTooManyTokens: query has more tokens than limit of TokenizeContext
+var ErrTooManyTokens = fmt.Errorf("query has too many tokens")
+
+// Tokenize: hand-written lexer which splits query to the same tokens (including positions) as Lexer, last token is EOF.
+// it doesn't use regexp, so it's much faster than Lexer. error is returned if some char can't be matched by any rule.
+func Tokenize(query string) ([]lexer.Token, error) {
+	return TokenizeContext(context.Background(), query, 0)
+}
+
+// TokenizeContext: tokenize query like Tokenize, but stop as soon as ctx is done (error of ctx is returned) or query has
+// more than maxTokens tokens (ErrTooManyTokens is returned). whitespaces aren't counted, zero maxTokens means no limit.
+func TokenizeContext(ctx context.Context, query string, maxTokens int) ([]lexer.Token, error) {
+	var (
+		res    = make([]lexer.Token, 0, len(query)/2+1)
+		pos    = lexer.Position{Line: 1, Column: 1}
+		tokens int
+	)
+	for pos.Offset < len(query) {
+		// ctx is checked periodically, because it may be expensive (i.e. cancelCtx is locked)
+		if len(res)%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		var s = query[pos.Offset:]
+		var typ, n = next(s)
+		if n == 0 {
+			var sample = s
+			if len(s) >= 16 {
+				sample = s[:16] + "..."
+			}
+			return nil, participle.Errorf(pos, "no lexer rules in state %q matched input text %q", "Root", sample)
+		}
+		if typ != whitespaceSymbol && typ != eolSymbol {
+			if tokens++; maxTokens > 0 && tokens > maxTokens {
+				return nil, fmt.Errorf("%w: more than %d tokens", ErrTooManyTokens, maxTokens)
+			}
+		}
+		res = append(res, lexer.Token{Type: typ, Value: s[:n], Pos: pos})
+		// update position like Lexer, column is counted by runes
+		pos.Offset += n
+		if typ == eolSymbol || strings.Contains(s[:n], "\n") {
+			pos.Line += strings.Count(s[:n], "\n")
+			pos.Column = utf8.RuneCountInString(s[strings.LastIndex(s[:n], "\n"):n])
+		} else {
+			pos.Column += utf8.RuneCountInString(s[:n])
+		}
+	}
+	return append(res, lexer.EOFToken(pos)), nil
+}
+
+// next: type and length of first token of s, rules are tried by order of Lexer, length is 0 if no rule matches
+func next(s string) (rune, int) {
+	if s[0] == '\n' {
+		return eolSymbol, 1
+	}
+	if n := span(s, isWhitespace); n > 0 {
+		return whitespaceSymbol, n
+	}
+	if n := span(s, isIdent); n > 0 {
+		return identSymbol, n
+	}
+	var n = 0
+	for n+1 < len(s) && s[n] == '\\' {
+		var r, size = utf8.DecodeRuneInString(s[n+1:])
+		if !isEscaped(r) {
+			break
+		}
+		n += 1 + size
+	}
+	if n > 0 {
+		return escapeSymbol, n
+	}
+	if n := span(s, func(r rune) bool { return '0' <= r && r <= '9' }); n > 0 {
+		return numberSymbol, n
+	}
+	if s[0] == '<' || s[0] == '>' {
+		if len(s) > 1 && s[1] == '=' {
+			return compareSymbol, 2
+		}
+		return compareSymbol, 1
+	}
+	if typ, ok := punctuations[s[0]]; ok {
+		return typ, 1
+	}
+	return 0, 0
+}
+
+// span: length of longest prefix of s whose runes satisfy f
+func span(s string, f func(r rune) bool) int {
+	var n = 0
+	for n < len(s) {
+		var r, size = utf8.DecodeRuneInString(s[n:])
+		if !f(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// isSpace: `\s` of regexp
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r'
+}
+
+func isWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\f' || r == '　'
+}
+
+func isIdent(r rune) bool {
+	if isSpace(r) || '0' <= r && r <= '9' {
+		return false
+	}
+	return !strings.ContainsRune(`-!:|&"?*\^~(){}[]+/><=.`, r)
+}
+
+func isEscaped(r rune) bool {
+	return isSpace(r) || strings.ContainsRune(`:&|?*\^~()![]{}+-/><=`, r)
+}
@@ -0,0 +1,89 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/participle/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func lex(query string) ([]lexer.Token, error) {
+	var l, err = Lexer.Lex(strings.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	var res = []lexer.Token{}
+	for {
+		var t, err = l.Next()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+		if t.EOF() {
+			return res, nil
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`x:1`,
+		`x:1 AND y:2 && !z:3 || NOT w:4`,
+		`a.b\:c:(foo OR bar)^1.5 AND x:"foo \"bar\" baz"~2`,
+		`x:/a\/b[0-9]+/ AND y:[1 TO 2} AND z:>=2020-01-01 AND w:<*`,
+		`x:foo\ bar\\\* AND y:\"a\" AND z:fo?o*`,
+		`x:1　AND y:2 AND z:中文　词`,
+		"x:1\n AND\ty:2\r\n\f",
+		"x:\xff\xfe AND y:\v",
+		`x:1 = 2`,
+		`x:a=b`,
+		`x:\`,
+		`x:\a`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var want, wantErr = lex(input)
+			var got, err = Tokenize(input)
+			assert.Equal(t, want, got)
+			if wantErr != nil {
+				assert.Equal(t, wantErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestTokenizeContext(t *testing.T) {
+	var query = "x:1 AND\ty:2\n"
+	tokens, err := TokenizeContext(context.Background(), query, 7)
+	assert.Nil(t, err)
+	assert.Len(t, tokens, 11)
+
+	tokens, err = TokenizeContext(context.Background(), query, 6)
+	assert.Nil(t, tokens)
+	assert.True(t, errors.Is(err, ErrTooManyTokens), "%v", err)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	tokens, err = TokenizeContext(ctx, query, 0)
+	assert.Nil(t, tokens)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestGetTokenType(t *testing.T) {
+	for _, input := range []string{
+		``, `foo`, `123`, `\*`, `*`, `?`, `.`, `"`, `/`, `\`, `:`, `>=`, `+`, `-`, `~`, `^`, `(`, `)`, `[`, `]`, `{`, `}`,
+		`&`, `|`, `!`, " ", "\n", `foo123`, `12foo`, `=`, `foo=`, `中文`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			// type of first token which is parsed by Scanner, error is returned if there are more tokens
+			var want = &Token{}
+			_ = Scanner.ParseString(input, want)
+			assert.Equal(t, want.getTokenType(), GetTokenType(input))
+		})
+	}
+}
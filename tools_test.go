@@ -66,9 +66,7 @@ func TestTermGroupToLucene(t *testing.T) {
 			args: args{field: &term.Field{Value: []string{"x"}}, termGroup: &term.TermGroup{LogicTermGroup: &term.LogicTermGroup{
 				OrTermGroup: &term.OrTermGroup{AndTermGroup: &term.AndTermGroup{
 					FieldTermGroup: &term.FieldTermGroup{
-						SingleTerm: &term.SingleTerm{
-							Begin: "y",
-						},
+						SingleTerm: term.NewSingleTerm("y"),
 					},
 				}},
 			}}},
@@ -79,7 +77,7 @@ func TestTermGroupToLucene(t *testing.T) {
 							Field: &term.Field{Value: []string{"x"}},
 							Term: &term.Term{
 								FuzzyTerm: &term.FuzzyTerm{
-									SingleTerm: &term.SingleTerm{Begin: "y"},
+									SingleTerm: term.NewSingleTerm("y"),
 								},
 							},
 						},
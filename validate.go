@@ -0,0 +1,22 @@
+package lucene_parser
+
+import (
+	"fmt"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+// Validate: check values of query which can be parsed but are illegal,
+// i.e. edit distance of `x:foo~3` is greater than term.MaxEditDistance and `x:"foo bar"~1.5` has float slop.
+func Validate(q *Lucene) error {
+	var err error
+	Inspect(q, func(node interface{}, offset int) bool {
+		if x, ok := node.(*term.FuzzyTerm); ok && err == nil {
+			if e := x.Validate(); e != nil {
+				err = fmt.Errorf("invalid term %s at %d: %w", x.String(), offset, e)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
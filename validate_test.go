@@ -0,0 +1,30 @@
+package lucene_parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+func TestValidate(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		err   error
+	}
+	for _, tt := range []testCase{
+		{name: "test_valid", input: `x:foo~2 AND y:"foo bar"~3 OR z:foo~0.5`},
+		{name: "test_edit_distance", input: `x:1 AND (y:foo~3 OR z:1)`, err: term.ErrInvalidEditDistance},
+		{name: "test_slop", input: `x:1 AND NOT y:"foo bar"~1.5`, err: term.ErrInvalidSlop},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseLucene(tt.input)
+			assert.Nil(t, err)
+			err = Validate(q)
+			assert.True(t, errors.Is(err, tt.err), "%v", err)
+		})
+	}
+	assert.Nil(t, Validate(nil))
+}
@@ -0,0 +1,189 @@
+// Package wildcard: compile wildcard single term (i.e. `foo*`, `f?o`) to matcher, SQL LIKE pattern and go regexp.
+// `*` matches any runes (including empty) and `?` matches one rune, escaped `\*` and `\?` are literal chars.
+package wildcard
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zhuliquan/lucene_parser/term"
+)
+
+type PatternType uint32
+
+const (
+	EXACT_PATTERN    PatternType = iota // `foo`, no wildcard
+	PREFIX_PATTERN                      // `foo*`
+	SUFFIX_PATTERN                      // `*foo`
+	CONTAINS_PATTERN                    // `*foo*`
+	GENERAL_PATTERN                     // others, i.e. `f?o*bar`
+)
+
+// LikeEscape: escape char of SQL LIKE pattern made by Pattern.Like, i.e. `x LIKE 'foo\%%' ESCAPE '\'`
+const LikeEscape = '\\'
+
+type elemType uint8
+
+const (
+	literalElem elemType = iota
+	anyElem              // ?
+	starElem             // *
+)
+
+type elem struct {
+	typ elemType
+	r   rune
+}
+
+// Pattern: compiled wildcard pattern
+type Pattern struct {
+	typ     PatternType
+	literal string
+	elems   []elem
+}
+
+// Compile: compile raw wildcard as it is written in query (i.e. `foo\*bar*`), consecutive `*` are regarded as one
+func Compile(s string) *Pattern {
+	var p = &Pattern{}
+	for i := 0; i < len(s); {
+		var r, n = utf8.DecodeRuneInString(s[i:])
+		if r == '\\' && i+n < len(s) {
+			r, n = utf8.DecodeRuneInString(s[i+1:])
+			p.elems, i = append(p.elems, elem{typ: literalElem, r: r}), i+1+n
+			continue
+		}
+		switch r {
+		case '*':
+			if len(p.elems) == 0 || p.elems[len(p.elems)-1].typ != starElem {
+				p.elems = append(p.elems, elem{typ: starElem})
+			}
+		case '?':
+			p.elems = append(p.elems, elem{typ: anyElem})
+		default:
+			p.elems = append(p.elems, elem{typ: literalElem, r: r})
+		}
+		i += n
+	}
+	p.typ, p.literal = classify(p.elems)
+	return p
+}
+
+// CompileTerm: compile single term, escaped chars (ESCAPE token) are literal chars
+func CompileTerm(t *term.SingleTerm) *Pattern {
+	return Compile(t.String())
+}
+
+// classify: find fast path of pattern, literal is literal part of exact / prefix / suffix / contains pattern
+func classify(elems []elem) (PatternType, string) {
+	var leading = len(elems) != 0 && elems[0].typ == starElem
+	var trailing = len(elems) > 1 && elems[len(elems)-1].typ == starElem
+	var inner = elems
+	if leading {
+		inner = inner[1:]
+	}
+	if trailing {
+		inner = inner[:len(inner)-1]
+	}
+	var b = strings.Builder{}
+	for _, e := range inner {
+		if e.typ != literalElem {
+			return GENERAL_PATTERN, ""
+		}
+		b.WriteRune(e.r)
+	}
+	if leading && trailing {
+		return CONTAINS_PATTERN, b.String()
+	} else if leading {
+		return SUFFIX_PATTERN, b.String()
+	} else if trailing {
+		return PREFIX_PATTERN, b.String()
+	} else {
+		return EXACT_PATTERN, b.String()
+	}
+}
+
+// Type: return type of pattern
+func (p *Pattern) Type() PatternType {
+	return p.typ
+}
+
+// Literal: return literal part of pattern which isn't GENERAL_PATTERN, i.e. `foo` of `*foo*`
+func (p *Pattern) Literal() string {
+	return p.literal
+}
+
+// Match: check whether s is matched by pattern
+func (p *Pattern) Match(s string) bool {
+	switch p.typ {
+	case EXACT_PATTERN:
+		return s == p.literal
+	case PREFIX_PATTERN:
+		return strings.HasPrefix(s, p.literal)
+	case SUFFIX_PATTERN:
+		return strings.HasSuffix(s, p.literal)
+	case CONTAINS_PATTERN:
+		return strings.Contains(s, p.literal)
+	default:
+		return p.match([]rune(s))
+	}
+}
+
+// match: match runes with backtracking to the last star, it takes O(len(s) * len(elems)) in the worst case
+func (p *Pattern) match(s []rune) bool {
+	var i, j, star, next = 0, 0, -1, 0
+	for i < len(s) {
+		if j < len(p.elems) && (p.elems[j].typ == anyElem || (p.elems[j].typ == literalElem && p.elems[j].r == s[i])) {
+			i, j = i+1, j+1
+		} else if j < len(p.elems) && p.elems[j].typ == starElem {
+			star, next, j = j, i, j+1
+		} else if star >= 0 {
+			next++
+			i, j = next, star+1
+		} else {
+			return false
+		}
+	}
+	for j < len(p.elems) && p.elems[j].typ == starElem {
+		j++
+	}
+	return j == len(p.elems)
+}
+
+// Like: convert pattern to SQL LIKE pattern, `*` is converted to `%` and `?` is converted to `_`,
+// literal `%`, `_` and `\` are escaped with LikeEscape.
+func (p *Pattern) Like() string {
+	var b = strings.Builder{}
+	for _, e := range p.elems {
+		switch e.typ {
+		case starElem:
+			b.WriteByte('%')
+		case anyElem:
+			b.WriteByte('_')
+		default:
+			if e.r == '%' || e.r == '_' || e.r == LikeEscape {
+				b.WriteRune(LikeEscape)
+			}
+			b.WriteRune(e.r)
+		}
+	}
+	return b.String()
+}
+
+// Regexp: convert pattern to anchored go regexp, i.e. `f?o*` is converted to `^f.o.*$`, `.` matches new line too
+func (p *Pattern) Regexp() string {
+	var b = strings.Builder{}
+	b.WriteString("(?s)^")
+	for _, e := range p.elems {
+		switch e.typ {
+		case starElem:
+			b.WriteString(".*")
+		case anyElem:
+			b.WriteByte('.')
+		default:
+			b.WriteString(regexp.QuoteMeta(string(e.r)))
+		}
+	}
+	b.WriteByte('$')
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package wildcard
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/alecthomas/participle"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhuliquan/lucene_parser/term"
+	"github.com/zhuliquan/lucene_parser/token"
+)
+
+func TestCompile(t *testing.T) {
+	type testCase struct {
+		name    string
+		input   string
+		typ     PatternType
+		literal string
+		like    string
+		regexp  string
+	}
+	for _, tt := range []testCase{
+		{name: "test_exact", input: `foo`, typ: EXACT_PATTERN, literal: "foo", like: `foo`, regexp: `(?s)^foo$`},
+		{name: "test_escape", input: `foo\*\?`, typ: EXACT_PATTERN, literal: "foo*?", like: `foo*?`, regexp: `(?s)^foo\*\?$`},
+		{name: "test_prefix", input: `foo**`, typ: PREFIX_PATTERN, literal: "foo", like: `foo%`, regexp: `(?s)^foo.*$`},
+		{name: "test_suffix", input: `*foo`, typ: SUFFIX_PATTERN, literal: "foo", like: `%foo`, regexp: `(?s)^.*foo$`},
+		{name: "test_contains", input: `*f\*o*`, typ: CONTAINS_PATTERN, literal: "f*o", like: `%f*o%`, regexp: `(?s)^.*f\*o.*$`},
+		{name: "test_star", input: `*`, typ: SUFFIX_PATTERN, literal: "", like: `%`, regexp: `(?s)^.*$`},
+		{name: "test_general", input: `f?o*b.r`, typ: GENERAL_PATTERN, like: `f_o%b.r`, regexp: `(?s)^f.o.*b\.r$`},
+		{name: "test_like_escape", input: `50%_\\*`, typ: PREFIX_PATTERN, literal: `50%_\`, like: `50\%\_\\%`, regexp: `(?s)^50%_\\.*$`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var p = Compile(tt.input)
+			assert.Equal(t, tt.typ, p.Type())
+			assert.Equal(t, tt.literal, p.Literal())
+			assert.Equal(t, tt.like, p.Like())
+			assert.Equal(t, tt.regexp, p.Regexp())
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	type testCase struct {
+		pattern string
+		input   string
+		want    bool
+	}
+	for _, tt := range []testCase{
+		{pattern: `foo*`, input: `foobar`, want: true},
+		{pattern: `foo*`, input: `fo`, want: false},
+		{pattern: `*bar`, input: `foobar`, want: true},
+		{pattern: `*oba*`, input: `foobar`, want: true},
+		{pattern: `f?o`, input: `f中o`, want: true},
+		{pattern: `f?o`, input: `fo`, want: false},
+		{pattern: `a*b*c`, input: `aXbYbZc`, want: true},
+		{pattern: `a*b*c`, input: `aXbYbZ`, want: false},
+		{pattern: `foo\*`, input: `foobar`, want: false},
+		{pattern: `foo\*`, input: `foo*`, want: true},
+		{pattern: `\?*`, input: `?x`, want: true},
+		{pattern: ``, input: ``, want: true},
+	} {
+		t.Run(tt.pattern+"_"+tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compile(tt.pattern).Match(tt.input))
+		})
+	}
+}
+
+func TestMatchRegexp(t *testing.T) {
+	var r = rand.New(rand.NewSource(1))
+	var random = func(alphabet []rune, n int) string {
+		var res = make([]rune, r.Intn(n+1))
+		for i := range res {
+			res[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(res)
+	}
+	for i := 0; i < 500; i++ {
+		var p = Compile(random([]rune(`ab*?\中`), 6))
+		var re = regexp.MustCompile(p.Regexp())
+		for j := 0; j < 20; j++ {
+			var s = random([]rune("ab*?\\中\n"), 8)
+			assert.Equal(t, re.MatchString(s), p.Match(s), "%s %q", p.Regexp(), s)
+		}
+	}
+}
+
+func TestCompileTerm(t *testing.T) {
+	var termParser = participle.MustBuild(
+		&term.SingleTerm{},
+		participle.Lexer(token.Lexer),
+	)
+	var out = &term.SingleTerm{}
+	assert.Nil(t, termParser.ParseString(`foo\*ba?*`, out))
+	var p = CompileTerm(out)
+	assert.Equal(t, GENERAL_PATTERN, p.Type())
+	assert.True(t, p.Match("foo*bar"))
+	assert.False(t, p.Match("fooxbar"))
+}